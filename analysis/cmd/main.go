@@ -13,6 +13,7 @@ import (
 	hs "mtsbank/analysis/internal/client/history_service"
 	"mtsbank/analysis/internal/config"
 	"mtsbank/analysis/internal/repo"
+	"mtsbank/pkg/instrument"
 	"net"
 	"net/http"
 	"os"
@@ -36,11 +37,6 @@ func main() {
 		l = logger.New(level)
 	}
 
-	analyzers := make([]analyzer.Analyzer, len(cfg.CurrencyPairs))
-	for i := range analyzers {
-		analyzers[i] = analyzer.NewCurrencyPairAnalyzer(cfg.CurrencyPairs[i], cfg.TimeFrames, l)
-	}
-
 	memRepo := repo.NewInmemoryRepo(l)
 
 	l.Debug("%+v", cfg.Generator)
@@ -51,6 +47,27 @@ func main() {
 	generator, err := gs.NewClientWithResponses("http://" + net.JoinHostPort(cfg.Generator.Host, cfg.Generator.Port))
 	checkErr(err)
 
+	instruments := instrument.NewRegistry(generator, l)
+	// shutdown gracefully, signals also interrupt waiting for instrument registry
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	checkErr(instruments.Wait(ctx, cfg.PollPeriod))
+
+	// all enabled instruments are analyzed by default
+	currencyPairs := cfg.CurrencyPairs
+	if len(currencyPairs) == 0 {
+		currencyPairs = instruments.Enabled()
+	}
+
+	analyzers := make([]analyzer.Analyzer, len(currencyPairs))
+	for i := range analyzers {
+		if _, ok := instruments.Get(currencyPairs[i]); !ok {
+			log.Fatalf("unknown currency pair '%s'", currencyPairs[i])
+		}
+		analyzers[i] = analyzer.NewCurrencyPairAnalyzer(currencyPairs[i], cfg.TimeFrames, instruments.Calendar(currencyPairs[i]), l)
+	}
+
 	service := internal.NewService(
 		analyzers,
 		cfg.Batch.Period,
@@ -70,24 +87,20 @@ func main() {
 
 	r := chi.NewRouter()
 	r.Use(middleware.OapiRequestValidator(swagger))
-	v1.HandlerFromMux(service, r)
+	v1.HandlerWithOptions(service, v1.ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: []v1.MiddlewareFunc{instruments.Validator()},
+	})
 
 	s := &http.Server{
 		Handler: r,
 		Addr:    net.JoinHostPort(cfg.Http.Host, cfg.Http.Port),
 	}
 
-	// shutdown gracefully
-	ctx, cancel := context.WithCancel(context.Background())
-
 	idleConnsClosed := make(chan struct{})
 
-	exit := make(chan os.Signal, 1)
-
-	signal.Notify(exit, os.Interrupt, syscall.SIGTERM)
 	go func() {
-		<-exit
-		cancel()
+		<-ctx.Done()
 		if err := s.Shutdown(context.Background()); err != nil {
 			log.Printf("HTTP server Shutdown: %v", err)
 		}
@@ -12,6 +12,7 @@ require (
 	github.com/sosodev/duration v1.0.1
 	github.com/stretchr/testify v1.8.0
 	gotest.tools/v3 v3.3.0
+	mtsbank/pkg v0.0.0
 )
 
 require (
@@ -27,3 +28,5 @@ require (
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace mtsbank/pkg => ../pkg
@@ -13,6 +13,7 @@ import (
 	hs "mtsbank/analysis/internal/client/history_service"
 	"mtsbank/analysis/internal/model"
 	"mtsbank/analysis/internal/repo"
+	"mtsbank/pkg/decimal"
 	"net/http"
 	"sync"
 	"time"
@@ -79,14 +80,7 @@ func (s *service) GetRatesCurrencyPairTimeFrame(w http.ResponseWriter, r *http.R
 			return
 		}
 		for i := range buffer {
-			out = append(out, api.OHLC{
-				Close:     buffer[i].Close,
-				CloseTime: buffer[i].CloseTime,
-				High:      buffer[i].High,
-				Low:       buffer[i].Low,
-				Open:      buffer[i].Open,
-				OpenTime:  buffer[i].OpenTime,
-			})
+			out = append(out, toOHLC(buffer[i]))
 		}
 	case params.To != nil && params.From != nil:
 		buffer, err = s.repo.GetMany(r.Context(), currencyPair, timeFrame, *params.Last, buffer)
@@ -95,14 +89,7 @@ func (s *service) GetRatesCurrencyPairTimeFrame(w http.ResponseWriter, r *http.R
 			return
 		}
 		for i := range buffer {
-			out = append(out, api.OHLC{
-				Close:     buffer[i].Close,
-				CloseTime: buffer[i].CloseTime,
-				High:      buffer[i].High,
-				Low:       buffer[i].Low,
-				Open:      buffer[i].Open,
-				OpenTime:  buffer[i].OpenTime,
-			})
+			out = append(out, toOHLC(buffer[i]))
 		}
 	default:
 		ohlc, err := s.repo.GetLast(r.Context(), currencyPair, timeFrame)
@@ -111,14 +98,7 @@ func (s *service) GetRatesCurrencyPairTimeFrame(w http.ResponseWriter, r *http.R
 			return
 		}
 
-		out = append(out, api.OHLC{
-			Close:     ohlc.Close,
-			CloseTime: ohlc.CloseTime,
-			High:      ohlc.High,
-			Low:       ohlc.Low,
-			Open:      ohlc.Open,
-			OpenTime:  ohlc.OpenTime,
-		})
+		out = append(out, toOHLC(*ohlc))
 	}
 
 	// content is set to application/json only that order
@@ -130,6 +110,23 @@ func (s *service) GetRatesCurrencyPairTimeFrame(w http.ResponseWriter, r *http.R
 	}
 }
 
+// toOHLC renders candle prices as decimal strings
+func toOHLC(o model.OHLC) api.OHLC {
+	out := api.OHLC{
+		Close:     decimal.Format(o.Close, o.Scale),
+		CloseTime: o.CloseTime,
+		High:      decimal.Format(o.High, o.Scale),
+		Low:       decimal.Format(o.Low, o.Scale),
+		Open:      decimal.Format(o.Open, o.Scale),
+		OpenTime:  o.OpenTime,
+		Volume:    o.Volume,
+	}
+	if o.Session != "" {
+		out.Session = &o.Session
+	}
+	return out
+}
+
 func (s *service) writeError(w http.ResponseWriter, code int, message string) {
 	petErr := api.Error{
 		Code:    int32(code),
@@ -221,8 +218,9 @@ func (s *service) newCycle2(ctx context.Context, historyCollected <-chan struct{
 	go func() {
 		ticker := time.NewTicker(s.pollPeriod)
 		defer ticker.Stop()
+		var since time.Time
 		for {
-			s.collectNewRates(ctx, in, a.CurrencyPair())
+			since = s.collectNewRates(ctx, in, a.CurrencyPair(), since)
 			select {
 			case <-ctx.Done():
 				return
@@ -269,15 +267,18 @@ func (s *service) collectHistory(ctx context.Context, in chan<- []model.Exchange
 
 	rates := make([]model.ExchangeRate, len(*resp.JSON200))
 	for i := range rates {
-		rates[i] = model.ExchangeRate{
-			Time: (*resp.JSON200)[i].Time,
-			Rate: (*resp.JSON200)[i].Rate,
+		r := (*resp.JSON200)[i]
+		if rates[i], err = model.ParseExchangeRate(r.Time, r.Mid, r.Bid, r.Ask, r.Volume); err != nil {
+			s.logger.Error("model.ParseExchangeRate: %v", err)
+			return
 		}
 	}
 	in <- rates
 }
 
-func (s *service) collectNewRates(ctx context.Context, in chan<- []model.ExchangeRate, currencyPair string) {
+// collectNewRates sends rates created after since to in and returns time of the last sent rate.
+// Zero since means all rates.
+func (s *service) collectNewRates(ctx context.Context, in chan<- []model.ExchangeRate, currencyPair string, since time.Time) time.Time {
 	s.logger.Debug("service.collectNewRates2: start")
 	defer s.logger.Debug("service.collectNewRates2: end")
 
@@ -285,13 +286,29 @@ func (s *service) collectNewRates(ctx context.Context, in chan<- []model.Exchang
 	//
 	//cant use sync.Pool bc writing slice to channel in (reading side should put slice back to pool somehow)
 	out := make([]model.ExchangeRate, 0)
-	out, err := s.generator.GetRates(ctx, currencyPair, out)
-	if err != nil && err != gs.ErrBufferGrow {
+	var err error
+	if since.IsZero() {
+		out, err = s.generator.GetRates(ctx, currencyPair, out)
+	} else {
+		out, err = s.generator.GetRatesSince(ctx, currencyPair, since, out)
+	}
+
+	switch err {
+	case nil, gs.ErrBufferGrow:
+	case gs.ErrRatesGap:
+		s.logger.Warn("rates of %s after %v are lost", currencyPair, since)
+	default:
 		s.logger.Error("cant collect new rates: %v", err)
-		return
+		return since
+	}
+
+	if len(out) == 0 {
+		return since
 	}
 
 	in <- out
+
+	return out[len(out)-1].Time
 }
 
 func (s *service) storeOHLC(in <-chan model.OHLC, batchPeriod time.Duration, batchSize int) {
@@ -22,7 +22,7 @@ func TestStart2(t *testing.T) {
 
 	analyzers := make([]analyzer.Analyzer, len(curPair))
 	for i := range analyzers {
-		analyzers[i] = analyzer.NewCurrencyPairAnalyzer(curPair[i], timeFrames, l)
+		analyzers[i] = analyzer.NewCurrencyPairAnalyzer(curPair[i], timeFrames, nil, l)
 	}
 
 	r := repo.NewInmemoryRepo(l)
@@ -234,6 +234,7 @@ func Test_service_collectNewRates(t *testing.T) {
 		ctx          context.Context
 		in           chan<- []model.ExchangeRate
 		currencyPair string
+		since        time.Time
 	}
 	tests := []struct {
 		name   string
@@ -255,7 +256,7 @@ func Test_service_collectNewRates(t *testing.T) {
 				repo:                  tt.fields.repo,
 				logger:                tt.fields.logger,
 			}
-			s.collectNewRates(tt.args.ctx, tt.args.in, tt.args.currencyPair)
+			s.collectNewRates(tt.args.ctx, tt.args.in, tt.args.currencyPair, tt.args.since)
 		})
 	}
 }
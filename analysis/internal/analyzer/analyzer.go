@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/mazitovt/logger"
 	"mtsbank/analysis/internal/model"
+	"mtsbank/pkg/calendar"
 	"sync"
 	"time"
 )
@@ -21,15 +22,17 @@ type TimeFrameChannel struct {
 type CurrencyPairAnalyzer struct {
 	timeFrames   []time.Duration
 	currencyPair string
+	calendar     *calendar.Calendar
 	logger       logger.Logger
 	rateFilter   rateFilter
 }
 
-// NewCurrencyPairAnalyzer creates new CurrencyPairAnalyzer
-func NewCurrencyPairAnalyzer(currencyPair string, timeFrames []time.Duration, logger logger.Logger) *CurrencyPairAnalyzer {
+// NewCurrencyPairAnalyzer creates new CurrencyPairAnalyzer. Candles follow trading sessions of calendar, nil calendar is ignored.
+func NewCurrencyPairAnalyzer(currencyPair string, timeFrames []time.Duration, calendar *calendar.Calendar, logger logger.Logger) *CurrencyPairAnalyzer {
 	return &CurrencyPairAnalyzer{
 		timeFrames:   timeFrames,
 		currencyPair: currencyPair,
+		calendar:     calendar,
 		logger:       logger,
 	}
 }
@@ -112,6 +115,9 @@ func (c *CurrencyPairAnalyzer) analyze(in <-chan []model.ExchangeRate, out chan<
 	defer log(c.logger.Debug, "%s", "end")
 
 	ohlc := model.NewOHLC(c.currencyPair, timeFrame)
+	if c.calendar != nil {
+		ohlc = model.NewSessionOHLC(c.currencyPair, timeFrame, c.calendar)
+	}
 
 	for {
 		rates, ok := <-in
@@ -127,6 +133,10 @@ func (c *CurrencyPairAnalyzer) analyze(in <-chan []model.ExchangeRate, out chan<
 		for i := range rates {
 			if ohlc.UpdateOrReady(rates[i]) {
 				log(c.logger.Debug, "%v", *ohlc)
+				// closed market isn't a gap
+				if gap := ohlc.Gap(rates[i].Time); gap > timeFrame {
+					log(c.logger.Warn, "no rates for %v of open market after %v", gap, ohlc.CloseTime)
+				}
 				out <- *ohlc
 				ohlc.Reset()
 				ohlc.UpdateOrReady(rates[i])
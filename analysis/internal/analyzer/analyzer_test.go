@@ -3,6 +3,7 @@ package analyzer
 import (
 	"github.com/mazitovt/logger"
 	"mtsbank/analysis/internal/model"
+	"mtsbank/pkg/calendar"
 	"reflect"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ func TestNewCurrencyPairAnalyzer(t *testing.T) {
 	type args struct {
 		currencyPair string
 		timeFrames   []time.Duration
+		calendar     *calendar.Calendar
 		logger       logger.Logger
 	}
 	tests := []struct {
@@ -23,7 +25,7 @@ func TestNewCurrencyPairAnalyzer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewCurrencyPairAnalyzer(tt.args.currencyPair, tt.args.timeFrames, tt.args.logger); !reflect.DeepEqual(got, tt.want) {
+			if got := NewCurrencyPairAnalyzer(tt.args.currencyPair, tt.args.timeFrames, tt.args.calendar, tt.args.logger); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewCurrencyPairAnalyzer() = %v, want %v", got, tt.want)
 			}
 		})
@@ -28,14 +28,20 @@ type Error struct {
 	Message string `json:"message"`
 }
 
-// OHLC defines model for OHLC.
+// Prices are decimal strings, scale of candle is the greatest scale of its rates.
 type OHLC struct {
-	Close     int64     `json:"close"`
+	Close     string    `json:"close"`
 	CloseTime time.Time `json:"close_time"`
-	High      int64     `json:"high"`
-	Low       int64     `json:"low"`
-	Open      int64     `json:"open"`
+	High      string    `json:"high"`
+	Low       string    `json:"low"`
+	Open      string    `json:"open"`
 	OpenTime  time.Time `json:"open_time"`
+
+	// Trading day of the candle in trading calendar of the currency pair
+	Session *string `json:"session,omitempty"`
+
+	// Total volume of rates within time frame
+	Volume int64 `json:"volume"`
 }
 
 // GetRatesCurrencyPairTimeFrameParams defines parameters for GetRatesCurrencyPairTimeFrame.
@@ -554,18 +560,20 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/5RVTU/kOBD9K1btHrOkgRVa5bZC8yUxGjTDnBBCxqkkRvEH5QrTrVb++8hOoEM6MPQp",
-	"jvOq3ntVKXsLyhnvLFoOUGwhqAaNTMsPRI7iwpPzSKwxbStXYnxWjoxkKEBbPj2BDHjjcXjFGgn6DAyG",
-	"IOuEHj8GJm1r6PsMCB86TVhCcT3k3OFvnpO5u3tUHHN9+3xxviCmdWFPzdm/i2oS9pa1eRlQSsZ/0m42",
-	"V5lBo+vmnelb9+udSOfRHgA9SPKssIlqtDFIHOsA09wvarNf/JhU28pFDSUGRdqzdhYK+N/KdhN0EAHp",
-	"USsUyrUtKg4C16qRtkZBkjGIypFQHRFatRFeagpC2lIo2aquTYjYX+ElSYOMFKIzzS0ukEAGj0hhUHB8",
-	"tDpaPVVKeg0FnKatDLzkJv0kedKQb58E3EYBfb6Ndm+ryNhHWI287/AT8iBtz0EyEFOIlGIoKMkY96Uc",
-	"Ir9H3vMx6FJqutIGP47oidfiek57PmWCWH4okiHIwMb4Al64gWnbmTrMxklenL0529XUxQLVrlBv8ryW",
-	"VHgk7UqBa08YApZCxvYLHdx/Z6tjUXZD2SADXEvjU9cvr46/QjaZkQG89MfPmS+00SxcJbhBYTtzhxTf",
-	"HmXbYRDaCkLuyEYdRHLz5PmhQ9rsTLcyMEzt/XFc95X8YEmsbS2805ZfIarImWWiN+d8zvXTeyRx5zpb",
-	"vkLE7nCam9jw4J0Nw4F7sloNl4BltGlepPetVqmB+X1wdneLxJVmNCnwb8IKCvgr3903+XjZ5Ols75/J",
-	"h66kY2fe2JD66ppWQfpaya7lgwS9pWO48RaIO4trj4qxFDhiMgidMZI2Bx0Sfd/3vwMAAP//tXAcmXMH",
-	"AAA=",
+	"H4sIAAAAAAAC/5RWTW/jNhD9KwTboxLL3nab+FYE/QK2aNCmp+0iYKixzQVFcmdG2RiB/nsxpPytZOOT",
+	"ZXKG7715M5SetY1tigECk54/a7IraE1+/AUxojwkjAmQHeRlGxuQ30XE1rCeaxf43UxXmtcJyl9YAuq+",
+	"0i0QmWWOHjaJ0YWl7vtKI3zpHEKj5x/Lmbv4T9vD4sNnsCxn/fX7hxs5qAGy6BK7GPRc36KzQMogqAas",
+	"a41XBYIqRdZ4UHGhrAmNB+VI8QrUEsEwEO/2HZNCWbv8L+jqWK6PlBXAk2mTF1LTy7qu6590tavBAL6r",
+	"wkZoVQ64Z9ceVq0xDBd5dSRn5ZarEczp7I2YPn49TK8vr6+vr6/emB4ThDHJP56Rf6ZiAqJs6bHDd2ga",
+	"F5aqMWvxShzc+BkUD5viZGgMbiM6RAh2rZJxqKs9IbN6Nruory7q6RiLx+i7FkZIRDZelV3ByN2ivjpe",
+	"CQnXglqgycL2p+L9DyNTcdT5udSD4cW3oWP0fhkPumhL83RM5HQXFvFUwc/B+DU5UgT46CwoG70Hy6Tg",
+	"ya5MWMIgahHxsHykTGikwrbzOUImUSUjghmQRKNjDyMgQhWw+CotdFlvusMkp+f6XV6qdDK8ysM2yRwm",
+	"zxsC90KgnzyL7vtc4l7ClsCnCn8DLtROFGQBBy7JgBvJ+6MpmX8L7s2QdGsc3rkWfh2i97TOPx7D3hy1",
+	"mpNFEaQrHSR/rg/U6H3/GTuohjt39JY8acR9FSNQu0K9ivPSoSoButgoeEoIRNAoI/YrR/HqfT1VTVfK",
+	"djBSt3fTPw86vwTr6ttyPrjW8WZoQ9c+QB7hR+M7IBlwBO4wCA9Es95o/tIBrneivSHW+/LeMITHTP5h",
+	"gyxXSYou8AtAC4ztONArd9sp1r8pAaqH2IXmBSCO58N8EsMpxUDlxTWr6/K6Dgwhz4tJyTubDZx8pnLZ",
+	"7kAcQ5sTv0dY6Ln+brL7MpiUMJrkt3C/BS+u5Gvn2FjKvsaVtzrvLkzn+SxCr/Eo3yYjwF2ApwSWoVEw",
+	"xFSaurY1uD7rkuj7vv9/ANnAPpsdCQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
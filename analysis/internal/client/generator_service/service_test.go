@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+
+// newBatchServer serves GET /rates as generator does: USDJPY rates after cursor are lost, GBPUSD isn't generated
+func newBatchServer(t *testing.T, cursors *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rates", r.URL.Path)
+		*cursors = append(*cursors, r.URL.Query().Get("cursor"))
+
+		w.Header().Set("Content-Type", "application/json")
+		pairs := strings.Join(r.URL.Query()["pairs"], ",")
+		if strings.Contains(pairs, "GBPUSD") {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(Error{Code: http.StatusNotFound, Message: "service doesn't generate values for 'GBPUSD'"})
+			return
+		}
+
+		if strings.Contains(r.URL.Query().Get("cursor"), "USDJPY") {
+			w.Header().Set(headerRatesGapPairs, "USDJPY")
+		}
+		rate := ExchangeRate{Time: epoch.Add(2 * time.Second), Rate: "1.00005", Bid: "1.00004", Ask: "1.00006", Mid: "1.00005", Volume: 1}
+		_ = json.NewEncoder(w).Encode(map[string][]ExchangeRate{"EURUSD": {rate}, "USDJPY": {}})
+	}))
+}
+
+func TestClientWithResponses_GetPairsRates(t *testing.T) {
+	var cursors []string
+	server := newBatchServer(t, &cursors)
+	defer server.Close()
+
+	c, err := NewClientWithResponses(server.URL)
+	require.Nil(t, err)
+
+	batch, err := c.GetPairsRates(context.Background(), []string{"EURUSD", "USDJPY"}, nil)
+	require.Nil(t, err)
+	require.Len(t, batch["EURUSD"], 1)
+	require.Equal(t, int64(100004), batch["EURUSD"][0].Bid)
+	require.Equal(t, int32(5), batch["EURUSD"][0].Scale)
+	require.True(t, batch["EURUSD"][0].Time.Equal(epoch.Add(2*time.Second)))
+	require.Empty(t, batch["USDJPY"])
+
+	// cursor keeps times of pairs in alphabetical order, rates are returned along with gap
+	since := map[string]time.Time{"USDJPY": epoch.Add(1500 * time.Millisecond), "EURUSD": epoch}
+	batch, err = c.GetPairsRates(context.Background(), []string{AllCurrencyPairs}, since)
+	require.ErrorIs(t, err, ErrRatesGap)
+	require.Contains(t, err.Error(), "USDJPY")
+	require.Len(t, batch["EURUSD"], 1)
+
+	require.Equal(t, []string{"", "EURUSD=2022-08-01T00:00:00Z,USDJPY=2022-08-01T00:00:01.5Z"}, cursors)
+
+	_, err = c.GetPairsRates(context.Background(), []string{"EURUSD", "GBPUSD"}, nil)
+	require.ErrorIs(t, err, ErrUnknownCurrencyPair)
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -28,10 +29,19 @@ type Error struct {
 	Message string `json:"message"`
 }
 
-// ExchangeRate defines model for ExchangeRate.
+// Prices are decimal strings with exactly `scale` digits after decimal point, scale is set per currency pair.
 type ExchangeRate struct {
-	Rate int64     `json:"rate"`
-	Time time.Time `json:"time"`
+	Ask string `json:"ask"`
+	Bid string `json:"bid"`
+	Mid string `json:"mid"`
+
+	// Mid price, kept for compatibility
+	Rate string `json:"rate"`
+
+	// Trading day of the rate in trading calendar of the currency pair, it isn't stored
+	Session *string   `json:"session,omitempty"`
+	Time    time.Time `json:"time"`
+	Volume  int64     `json:"volume"`
 }
 
 // GetRatesCurrencyPairParams defines parameters for GetRatesCurrencyPair.
@@ -43,6 +53,12 @@ type GetRatesCurrencyPairParams struct {
 	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
 }
 
+// PostRatesCurrencyPairJSONBody defines parameters for PostRatesCurrencyPair.
+type PostRatesCurrencyPairJSONBody = []ExchangeRate
+
+// PostRatesCurrencyPairJSONRequestBody defines body for PostRatesCurrencyPair for application/json ContentType.
+type PostRatesCurrencyPairJSONRequestBody = PostRatesCurrencyPairJSONBody
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -118,6 +134,11 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 type ClientInterface interface {
 	// GetRatesCurrencyPair request
 	GetRatesCurrencyPair(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRatesCurrencyPair request with any body
+	PostRatesCurrencyPairWithBody(ctx context.Context, currencyPair string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostRatesCurrencyPair(ctx context.Context, currencyPair string, body PostRatesCurrencyPairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetRatesCurrencyPair(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -132,6 +153,30 @@ func (c *Client) GetRatesCurrencyPair(ctx context.Context, currencyPair string,
 	return c.Client.Do(req)
 }
 
+func (c *Client) PostRatesCurrencyPairWithBody(ctx context.Context, currencyPair string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRatesCurrencyPairRequestWithBody(c.Server, currencyPair, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostRatesCurrencyPair(ctx context.Context, currencyPair string, body PostRatesCurrencyPairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRatesCurrencyPairRequest(c.Server, currencyPair, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewGetRatesCurrencyPairRequest generates requests for GetRatesCurrencyPair
 func NewGetRatesCurrencyPairRequest(server string, currencyPair string, params *GetRatesCurrencyPairParams) (*http.Request, error) {
 	var err error
@@ -202,6 +247,53 @@ func NewGetRatesCurrencyPairRequest(server string, currencyPair string, params *
 	return req, nil
 }
 
+// NewPostRatesCurrencyPairRequest calls the generic PostRatesCurrencyPair builder with application/json body
+func NewPostRatesCurrencyPairRequest(server string, currencyPair string, body PostRatesCurrencyPairJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostRatesCurrencyPairRequestWithBody(server, currencyPair, "application/json", bodyReader)
+}
+
+// NewPostRatesCurrencyPairRequestWithBody generates requests for PostRatesCurrencyPair with any type of body
+func NewPostRatesCurrencyPairRequestWithBody(server string, currencyPair string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rates/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -247,6 +339,11 @@ func WithBaseURL(baseURL string) ClientOption {
 type ClientWithResponsesInterface interface {
 	// GetRatesCurrencyPair request
 	GetRatesCurrencyPairWithResponse(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, reqEditors ...RequestEditorFn) (*GetRatesCurrencyPairResponse, error)
+
+	// PostRatesCurrencyPair request with any body
+	PostRatesCurrencyPairWithBodyWithResponse(ctx context.Context, currencyPair string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRatesCurrencyPairResponse, error)
+
+	PostRatesCurrencyPairWithResponse(ctx context.Context, currencyPair string, body PostRatesCurrencyPairJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRatesCurrencyPairResponse, error)
 }
 
 type GetRatesCurrencyPairResponse struct {
@@ -272,6 +369,29 @@ func (r GetRatesCurrencyPairResponse) StatusCode() int {
 	return 0
 }
 
+type PostRatesCurrencyPairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRatesCurrencyPairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRatesCurrencyPairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetRatesCurrencyPairWithResponse request returning *GetRatesCurrencyPairResponse
 func (c *ClientWithResponses) GetRatesCurrencyPairWithResponse(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, reqEditors ...RequestEditorFn) (*GetRatesCurrencyPairResponse, error) {
 	rsp, err := c.GetRatesCurrencyPair(ctx, currencyPair, params, reqEditors...)
@@ -281,6 +401,23 @@ func (c *ClientWithResponses) GetRatesCurrencyPairWithResponse(ctx context.Conte
 	return ParseGetRatesCurrencyPairResponse(rsp)
 }
 
+// PostRatesCurrencyPairWithBodyWithResponse request with arbitrary body returning *PostRatesCurrencyPairResponse
+func (c *ClientWithResponses) PostRatesCurrencyPairWithBodyWithResponse(ctx context.Context, currencyPair string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRatesCurrencyPairResponse, error) {
+	rsp, err := c.PostRatesCurrencyPairWithBody(ctx, currencyPair, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRatesCurrencyPairResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostRatesCurrencyPairWithResponse(ctx context.Context, currencyPair string, body PostRatesCurrencyPairJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRatesCurrencyPairResponse, error) {
+	rsp, err := c.PostRatesCurrencyPair(ctx, currencyPair, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRatesCurrencyPairResponse(rsp)
+}
+
 // ParseGetRatesCurrencyPairResponse parses an HTTP response from a GetRatesCurrencyPairWithResponse call
 func ParseGetRatesCurrencyPairResponse(rsp *http.Response) (*GetRatesCurrencyPairResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -314,11 +451,47 @@ func ParseGetRatesCurrencyPairResponse(rsp *http.Response) (*GetRatesCurrencyPai
 	return response, nil
 }
 
+// ParsePostRatesCurrencyPairResponse parses an HTTP response from a PostRatesCurrencyPairWithResponse call
+func ParsePostRatesCurrencyPairResponse(rsp *http.Response) (*PostRatesCurrencyPairResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRatesCurrencyPairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Get rates for currency from start to end
 	// (GET /rates/{currency_pair})
 	GetRatesCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string, params GetRatesCurrencyPairParams)
+	// Ingest rates for currency pair
+	// (POST /rates/{currency_pair})
+	PostRatesCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -381,6 +554,32 @@ func (siw *ServerInterfaceWrapper) GetRatesCurrencyPair(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
+// PostRatesCurrencyPair operation middleware
+func (siw *ServerInterfaceWrapper) PostRatesCurrencyPair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "currency_pair" -------------
+	var currencyPair string
+
+	err = runtime.BindStyledParameter("simple", false, "currency_pair", chi.URLParam(r, "currency_pair"), &currencyPair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency_pair", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRatesCurrencyPair(w, r, currencyPair)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -497,6 +696,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/rates/{currency_pair}", wrapper.GetRatesCurrencyPair)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/rates/{currency_pair}", wrapper.PostRatesCurrencyPair)
+	})
 
 	return r
 }
@@ -504,15 +706,20 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/6xUS2/bTAz8Kwt+31GN3KToQdcgKAr0UPRxCoKCWVHyBt5HuFQRw9B/L7hSYst2X0BP",
-	"krjkzCw51A5s9CkGCpKh2UG2a/JYXm+YI+tL4piIxVEJ29iSPrvIHgUacEGuLqEC2SaaPqknhrECTzlj",
-	"X7LnwyzsQg/jWAHT4+CYWmhuJ8x9/t0LWLx/ICuKdfNk1xh6+oRCp6J4jh6KevvmrChxfpnaotCrEq1+",
-	"I3NOKmSnGjXbhS4qeEvZskviYoAGvjjZFPTy3H9/J85TxuuL1cVK1cVEAZODBq5KqIKEsi53rJU31zs7",
-	"MFOw228JHY960pOckr4jMaXCdJHNc5HRIuOCYW2m6Th6kwVZjERDoYUigVFB3rcTjLY8X88AH9FxUcXo",
-	"SYgzNLfH1J8V0IXepOiCgLYFGngciLdQQUAdACg1VLPh/nwgx1xfUyI293Eo2s8RSfwHNNeH/Xsm0tHs",
-	"eRZzWVAeo9+prXKKIU/uvVytps0KQqGMElPaOFumUD9kVbA7wHNCvhT+z9RBA//V+yWu5w2uFwszvlwR",
-	"mXE7mXV5ww8ui4nd5Bkoxx0OG/krZb8UVP4nZ5iHQE+JrFBraM6pIA/eI29/buRT647jOP4IAAD//4Qp",
-	"7eLQBAAA",
+	"H4sIAAAAAAAC/8xWYW/bNhD9KwduQL8wtpJmxeCPC4ohwAYE6/apK1ZaPMlsJJLhnVILgf77cJQSx7ay",
+	"JcEG9JMskXzvdO+9k+9UGdoYPHomtbpTVG6wNfnn+5RCkh8xhYiJHebHZbAo1yqk1rBaKef57ZnSivuI",
+	"4y3WmNSgVYtEps67p0Xi5HythkGrhDedS2jV6uOIudv/6QEsrL9gyYL1fltujK/xN8MZ0CKVyUV2wauV",
+	"ukquRAKTECyWrjUNjFQEXx1vALem5KaHz1SaBj+DdbVjAlMxpocTMTjPGvIWcASEDBETlF1K6MseonFp",
+	"8adX+qAlhq7lglvTxkbKPl0URVG8U3rXpYlk16f7Vmi1dnbu+Pkzj7fzx3945vE029BfnYUoTdVwjZGh",
+	"CgnEKYbd2jWOe6VfzUhIlFkOSX9PxjpfgzU9hAp4gyDVgfPA05Jo461J9+t72mhwDI78GwbiIN56XORZ",
+	"cXZ2Uvx4UpzOFcWu3be1NYwn+enM7tvQdO1RDN6dz8TgwOoTYu76KL3O/hl1fEA+joAAOV+FmbY5bnKV",
+	"+bq7v8U09ln0WRRSd4joTXRqpd7mR1pFw5ts4qWURMu7+47+JR0dZKVGPib9GTmLQ6M1HssgeiXJKlQp",
+	"tEBsEgMHQC8vKMExAnJpRxhJNF1MAFfGpVxVMi0yJlKrj4fUHwRQzJADq6QtaqVuOkziSm9EGCXUSk/z",
+	"7LnCDvqQ648oE2AdOm+fIOLwH9BcPO7fPZFIs+PZ02WP8hD9kziOYvA0jqezohgHt2f0WUoTY+PKrMLy",
+	"C41R3OE5xjYf/D5hpVbqu+XuG7Ect9Fybx4PD69oUjL9aNb9N/zFEUtos2dUXq5M1/CLKvvHgvLnaoa5",
+	"87iNWDJawGmPVtS1rUn900Y+tu6gVQw0E4UPMmtoAgnV4UzCRb2YFtemvK5c06CFdQ81eolCSAu42M8P",
+	"gbEWrTBvnIyyHlwFjt8QePy6OErRVaCXx+gVntsNMk4d/qsHbzok/inY/v+3X2u2l+O5U/kUzflxv/jh",
+	"KCTnx8rmnuZ/FdP3ZNDq/IVpepVnL/2taZz99tJy6WukJye/AA5/DwALzfMwUwoAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1,8 +1,42 @@
 package model
 
-import "time"
+import (
+	"mtsbank/pkg/decimal"
+	"time"
+)
 
+// ExchangeRate is a tick of currency pair, Rate is a mid price.
+// Prices are fixed-point values: Rate * 10^-Scale is a decimal price
 type ExchangeRate struct {
-	Time time.Time
-	Rate int64
+	Time   time.Time
+	Rate   int64
+	Bid    int64
+	Ask    int64
+	Volume int64
+	Scale  int32
+}
+
+// ParseExchangeRate parses decimal prices and brings them to the greatest of their scales
+func ParseExchangeRate(t time.Time, mid, bid, ask string, volume int64) (ExchangeRate, error) {
+	var (
+		values [3]int64
+		scales [3]int32
+		scale  int32
+		err    error
+	)
+	for i, s := range [3]string{mid, bid, ask} {
+		if values[i], scales[i], err = decimal.Parse(s); err != nil {
+			return ExchangeRate{}, err
+		}
+		if scales[i] > scale {
+			scale = scales[i]
+		}
+	}
+	for i := range values {
+		if values[i], err = decimal.Rescale(values[i], scales[i], scale); err != nil {
+			return ExchangeRate{}, err
+		}
+	}
+
+	return ExchangeRate{Time: t, Rate: values[0], Bid: values[1], Ask: values[2], Volume: volume, Scale: scale}, nil
 }
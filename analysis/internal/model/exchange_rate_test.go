@@ -0,0 +1,44 @@
+package model
+
+import (
+	"github.com/stretchr/testify/require"
+	"mtsbank/pkg/decimal"
+	"testing"
+)
+
+func TestParseExchangeRate(t *testing.T) {
+	tests := []struct {
+		name          string
+		mid, bid, ask string
+		er            ExchangeRate
+		err           error
+	}{
+		{
+			name: "same scale",
+			mid:  "1.00005", bid: "1.00004", ask: "1.00006",
+			er: ExchangeRate{Time: defaultTime, Rate: 100005, Bid: 100004, Ask: 100006, Volume: 10, Scale: 5},
+		},
+		{
+			name: "different scales",
+			mid:  "135.12", bid: "135.1", ask: "135.125",
+			er: ExchangeRate{Time: defaultTime, Rate: 135120, Bid: 135100, Ask: 135125, Volume: 10, Scale: 3},
+		},
+		{
+			name: "invalid price",
+			mid:  "1.00005", bid: "1,00004", ask: "1.00006",
+			err: decimal.ErrSyntax,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := ParseExchangeRate(defaultTime, tc.mid, tc.bid, tc.ask, 10)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tc.er, r)
+		})
+	}
+}
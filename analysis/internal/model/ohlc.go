@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"mtsbank/pkg/calendar"
+	"mtsbank/pkg/decimal"
+	"time"
+)
 
 var (
 	emptyTime = time.Time{}
@@ -16,13 +20,31 @@ type OHLC struct {
 	High         int64
 	Low          int64
 	Close        int64
+	// Scale is a number of digits after decimal point in Open, High, Low and Close
+	Scale int32
+	// Volume is a total volume of rates within time frame
+	Volume int64
+	// Session is a trading day of candle, it's empty without calendar
+	Session string
+
+	calendar *calendar.Calendar
 }
 
 func NewOHLC(currencyPair string, timeFrame time.Duration) *OHLC {
 	return &OHLC{CurrencyPair: currencyPair, TimeFrame: timeFrame}
 }
 
+// NewSessionOHLC creates candle of trading calendar: rates of closed market are skipped, candles don't span trading days
+// and time frames of a day or longer count open trading days only, e.g. daily candle of Friday is followed by Monday one.
+func NewSessionOHLC(currencyPair string, timeFrame time.Duration, calendar *calendar.Calendar) *OHLC {
+	return &OHLC{CurrencyPair: currencyPair, TimeFrame: timeFrame, calendar: calendar}
+}
+
 func (o *OHLC) UpdateOrReady(r ExchangeRate) bool {
+	if o.calendar != nil && !o.calendar.Open(r.Time) {
+		return false
+	}
+
 	if o.empty() {
 		o.OpenTime = r.Time
 		o.CloseTime = r.Time
@@ -30,22 +52,33 @@ func (o *OHLC) UpdateOrReady(r ExchangeRate) bool {
 		o.High = r.Rate
 		o.Low = r.Rate
 		o.Close = r.Rate
+		o.Scale = r.Scale
+		o.Volume = r.Volume
+		if o.calendar != nil {
+			o.Session = o.calendar.Session(r.Time)
+		}
 		return false
 	}
 
-	if r.Time.Sub(o.OpenTime) > o.TimeFrame {
+	if o.ready(r.Time) {
 		return true
 	}
 
-	if r.Rate < o.Low {
-		o.Low = r.Rate
+	rate, ok := o.align(r)
+	if !ok {
+		return false
 	}
-	if r.Rate > o.High {
-		o.High = r.Rate
+
+	if rate < o.Low {
+		o.Low = rate
+	}
+	if rate > o.High {
+		o.High = rate
 	}
 
-	o.Close = r.Rate
+	o.Close = rate
 	o.CloseTime = r.Time
+	o.Volume += r.Volume
 
 	return false
 }
@@ -61,6 +94,47 @@ func (o *OHLC) Reset() {
 	o.High = emptyRate
 	o.Low = emptyRate
 	o.Close = emptyRate
+	o.Scale = 0
+	o.Volume = 0
+	o.Session = ""
+}
+
+// ready reports whether rate of time t is out of candle
+func (o *OHLC) ready(t time.Time) bool {
+	if o.calendar == nil {
+		return t.Sub(o.OpenTime) > o.TimeFrame
+	}
+
+	if days := int(o.TimeFrame / (24 * time.Hour)); days > 0 {
+		return o.calendar.TradingDays(o.OpenTime, t) >= days
+	}
+	return o.calendar.Session(t) != o.Session || t.Sub(o.OpenTime) > o.TimeFrame
+}
+
+// Gap returns time of open market between close of candle and t
+func (o *OHLC) Gap(t time.Time) time.Duration {
+	if o.calendar == nil {
+		return t.Sub(o.CloseTime)
+	}
+	return o.calendar.OpenDuration(o.CloseTime, t)
+}
+
+// align brings candle and rate to the greatest of their scales and returns rate price in candle scale.
+// Rate is skipped, if its price doesn't fit int64 in candle scale.
+func (o *OHLC) align(r ExchangeRate) (int64, bool) {
+	if r.Scale > o.Scale {
+		var prices [4]int64
+		for i, p := range [4]int64{o.Open, o.High, o.Low, o.Close} {
+			var err error
+			if prices[i], err = decimal.Rescale(p, o.Scale, r.Scale); err != nil {
+				return 0, false
+			}
+		}
+		o.Open, o.High, o.Low, o.Close, o.Scale = prices[0], prices[1], prices[2], prices[3], r.Scale
+	}
+
+	rate, err := decimal.Rescale(r.Rate, r.Scale, o.Scale)
+	return rate, err == nil
 }
 
 func (o *OHLC) empty() bool {
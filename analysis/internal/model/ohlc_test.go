@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/stretchr/testify/require"
+	"mtsbank/pkg/calendar"
 	"testing"
 	"time"
 )
@@ -32,10 +33,10 @@ func TestOHLC_UpdateOrReady(t *testing.T) {
 				TimeFrame: 5 * time.Second,
 			},
 			args: []ExchangeRate{
-				{defaultTime, 5},
-				{defaultTime.Add(2 * time.Second), 5},
-				{defaultTime.Add(4 * time.Second), 10},
-				{defaultTime.Add(6 * time.Second), 15},
+				{Time: defaultTime, Rate: 5},
+				{Time: defaultTime.Add(2 * time.Second), Rate: 5},
+				{Time: defaultTime.Add(4 * time.Second), Rate: 10},
+				{Time: defaultTime.Add(6 * time.Second), Rate: 15},
 			},
 			want: []bool{false, false, false, true},
 		},
@@ -45,10 +46,10 @@ func TestOHLC_UpdateOrReady(t *testing.T) {
 				TimeFrame: 5 * time.Second,
 			},
 			args: []ExchangeRate{
-				{defaultTime, 5},
-				{defaultTime.Add(2 * time.Second), 5},
-				{defaultTime.Add(4 * time.Second), 10},
-				{defaultTime.Add(5001 * time.Millisecond), 15},
+				{Time: defaultTime, Rate: 5},
+				{Time: defaultTime.Add(2 * time.Second), Rate: 5},
+				{Time: defaultTime.Add(4 * time.Second), Rate: 10},
+				{Time: defaultTime.Add(5001 * time.Millisecond), Rate: 15},
 			},
 			want: []bool{false, false, false, true},
 		},
@@ -58,10 +59,10 @@ func TestOHLC_UpdateOrReady(t *testing.T) {
 				TimeFrame: 5 * time.Second,
 			},
 			args: []ExchangeRate{
-				{defaultTime, 5},
-				{defaultTime.Add(2 * time.Second), 3},
-				{defaultTime.Add(4 * time.Second), 10},
-				{defaultTime.Add(5 * time.Second), 7},
+				{Time: defaultTime, Rate: 5},
+				{Time: defaultTime.Add(2 * time.Second), Rate: 3},
+				{Time: defaultTime.Add(4 * time.Second), Rate: 10},
+				{Time: defaultTime.Add(5 * time.Second), Rate: 7},
 			},
 			want: []bool{false, false, false, false},
 		},
@@ -145,6 +146,8 @@ func TestOHLC_Reset(t *testing.T) {
 		High         int64
 		Low          int64
 		Close        int64
+		Scale        int32
+		Volume       int64
 	}
 	tests := []struct {
 		name   string
@@ -160,6 +163,8 @@ func TestOHLC_Reset(t *testing.T) {
 				High:         2,
 				Low:          3,
 				Close:        4,
+				Scale:        5,
+				Volume:       5,
 			}},
 	}
 	for _, tt := range tests {
@@ -173,6 +178,8 @@ func TestOHLC_Reset(t *testing.T) {
 				High:         tt.fields.High,
 				Low:          tt.fields.Low,
 				Close:        tt.fields.Close,
+				Scale:        tt.fields.Scale,
+				Volume:       tt.fields.Volume,
 			}
 			o.Reset()
 			require.Equal(t, o.CurrencyPair, tt.fields.CurrencyPair)
@@ -183,6 +190,75 @@ func TestOHLC_Reset(t *testing.T) {
 			require.Equal(t, o.High, emptyRate)
 			require.Equal(t, o.Low, emptyRate)
 			require.Equal(t, o.Close, emptyRate)
+			require.Zero(t, o.Scale)
+			require.Zero(t, o.Volume)
 		})
 	}
 }
+
+func TestOHLC_UpdateOrReady_Volume(t *testing.T) {
+	o := NewOHLC("EURUSD", 5*time.Second)
+	rates := []ExchangeRate{
+		{Time: defaultTime, Rate: 5, Bid: 4, Ask: 6, Volume: 100},
+		{Time: defaultTime.Add(2 * time.Second), Rate: 7, Bid: 6, Ask: 8, Volume: 50},
+		{Time: defaultTime.Add(4 * time.Second), Rate: 3, Bid: 2, Ask: 4, Volume: 25},
+	}
+	for _, r := range rates {
+		require.False(t, o.UpdateOrReady(r))
+	}
+	require.Equal(t, int64(175), o.Volume)
+	require.Equal(t, int64(7), o.High)
+	require.Equal(t, int64(3), o.Low)
+
+	// rate out of time frame isn't counted
+	require.True(t, o.UpdateOrReady(ExchangeRate{Time: defaultTime.Add(6 * time.Second), Rate: 1, Volume: 10}))
+	require.Equal(t, int64(175), o.Volume)
+}
+
+func TestOHLC_UpdateOrReady_Scale(t *testing.T) {
+	o := NewOHLC("USDJPY", 5*time.Second)
+	rates := []ExchangeRate{
+		{Time: defaultTime, Rate: 13512, Scale: 3},
+		{Time: defaultTime.Add(1 * time.Second), Rate: 1351175, Scale: 5},
+		{Time: defaultTime.Add(2 * time.Second), Rate: 1352, Scale: 2},
+		{Time: defaultTime.Add(3 * time.Second), Rate: 135125, Scale: 4},
+	}
+	for _, r := range rates {
+		require.False(t, o.UpdateOrReady(r))
+	}
+
+	// candle takes the greatest scale, no digits are lost
+	require.Equal(t, int32(5), o.Scale)
+	require.Equal(t, int64(1351200), o.Open)
+	require.Equal(t, int64(1352000), o.High)
+	require.Equal(t, int64(1351175), o.Low)
+	require.Equal(t, int64(1351250), o.Close)
+}
+
+func TestOHLC_UpdateOrReady_Session(t *testing.T) {
+	fx, err := calendar.Builtin(calendar.FX, nil)
+	require.Nil(t, err)
+	// Friday 2022-08-05
+	friday := time.Date(2022, 8, 5, 0, 0, 0, 0, time.UTC)
+
+	// intraday candle doesn't span trading days
+	o := NewSessionOHLC("EURUSD", time.Hour, fx)
+	require.False(t, o.UpdateOrReady(ExchangeRate{Time: friday.Add(21*time.Hour + 30*time.Minute), Rate: 5}))
+	require.Equal(t, "2022-08-05", o.Session)
+	// closed market is skipped
+	require.False(t, o.UpdateOrReady(ExchangeRate{Time: friday.Add(22*time.Hour + 10*time.Minute), Rate: 7}))
+	require.Equal(t, int64(5), o.High)
+	require.True(t, o.UpdateOrReady(ExchangeRate{Time: friday.Add(2*24*time.Hour + 22*time.Hour), Rate: 7}))
+	require.Equal(t, 30*time.Minute+time.Second, o.Gap(friday.Add(2*24*time.Hour+22*time.Hour+time.Second)))
+
+	// daily candle of Friday is followed by Monday one
+	o = NewSessionOHLC("EURUSD", 24*time.Hour, fx)
+	require.False(t, o.UpdateOrReady(ExchangeRate{Time: friday.Add(time.Hour), Rate: 5}))
+	require.False(t, o.UpdateOrReady(ExchangeRate{Time: friday.Add(21 * time.Hour), Rate: 6}))
+	require.True(t, o.UpdateOrReady(ExchangeRate{Time: friday.Add(2*24*time.Hour + 23*time.Hour), Rate: 7}))
+	require.Equal(t, int64(6), o.Close)
+
+	o.Reset()
+	require.False(t, o.UpdateOrReady(ExchangeRate{Time: friday.Add(2*24*time.Hour + 23*time.Hour), Rate: 7}))
+	require.Equal(t, "2022-08-08", o.Session)
+}
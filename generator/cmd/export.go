@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"generator/internal"
+	"generator/internal/api/http/v1"
+	"generator/internal/config"
+	"github.com/mazitovt/logger"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// pairPlaceholder in output path writes rates of each currency pair to its own file
+const pairPlaceholder = "{pair}"
+
+var ErrExportRange = errors.New("-from and -to or -duration must be set, -to must not be before -from")
+
+// export writes rates generated by configured models to tick files without waiting, e.g.
+//
+//	app export -from 2022-08-01T00:00:00Z -duration 24h -out fixtures/{pair}.csv
+func export(cfg *config.Config, args []string, l logger.Logger) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	pairs := fs.String("pairs", strings.Join(cfg.CurrencyPairs, ","), "comma separated currency pairs")
+	from := fs.String("from", "", "time of the first rate, RFC 3339 (default CLOCK_EPOCH)")
+	to := fs.String("to", "", "time after which rates aren't generated, RFC 3339")
+	duration := fs.Duration("duration", 0, "duration of generated rates, used when -to isn't set")
+	format := fs.String("format", "", "csv, ndjson or parquet (default by extension of -out, csv for stdout)")
+	out := fs.String("out", "-", "output file, - is stdout, "+pairPlaceholder+" is replaced with currency pair")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	start, end, err := exportRange(cfg, *from, *to, *duration)
+	if err != nil {
+		return err
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*out), ".")
+		if *out == "-" || *format == "" {
+			*format = internal.TickFormatCSV
+		}
+	}
+	switch *format {
+	case internal.TickFormatCSV, internal.TickFormatNDJSON, internal.TickFormatParquet:
+	default:
+		return fmt.Errorf("%w: %s", internal.ErrTickFormat, *format)
+	}
+
+	currencyPairs := strings.Split(*pairs, ",")
+	cfg.CurrencyPairs = currencyPairs
+	instruments, err := config.GetInstruments(cfg)
+	if err != nil {
+		return err
+	}
+
+	f, err := config.GetGeneratorFunc(cfg)
+	if err != nil {
+		return err
+	}
+	schedule, err := config.GetScheduleFunc(cfg)
+	if err != nil {
+		return err
+	}
+	quote, err := config.GetQuoteFunc(cfg)
+	if err != nil {
+		return err
+	}
+	faults, err := config.GetFaultFunc(cfg)
+	if err != nil {
+		return err
+	}
+	scenario, err := config.GetScenario(cfg)
+	if err != nil {
+		return err
+	}
+
+	e := internal.NewTickExporter(instruments, f, schedule, quote, faults)
+	if scenario != nil {
+		if err = e.PlayScenario(*scenario, start); err != nil {
+			return err
+		}
+	}
+
+	w := newTickFiles(*out, *format)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	n, err := e.Export(ctx, currencyPairs, start, end, w)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	l.Info("Exported: rates=%v, from=%v, to=%v, out=%v", n, start, end, *out)
+	return nil
+}
+
+func exportRange(cfg *config.Config, from, to string, duration time.Duration) (time.Time, time.Time, error) {
+	start := cfg.Clock.Epoch
+	if r := cfg.Replay.Recording; r != nil {
+		start = r.Start()
+	}
+	if from != "" {
+		var err error
+		if start, err = time.Parse(time.RFC3339Nano, from); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	end := start.Add(duration)
+	if to != "" {
+		var err error
+		if end, err = time.Parse(time.RFC3339Nano, to); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	if start.IsZero() || (to == "" && duration <= 0) || end.Before(start) {
+		return time.Time{}, time.Time{}, ErrExportRange
+	}
+	return start, end, nil
+}
+
+// tickFiles writes rates to output path, each currency pair gets its own file if path has pair placeholder
+type tickFiles struct {
+	path    string
+	format  string
+	writers map[string]internal.TickWriter
+	files   []*os.File
+}
+
+func newTickFiles(path, format string) *tickFiles {
+	return &tickFiles{path: path, format: format, writers: map[string]internal.TickWriter{}}
+}
+
+func (t *tickFiles) Write(pair string, rate v1.ExchangeRate) error {
+	name := strings.ReplaceAll(t.path, pairPlaceholder, pair)
+	w, ok := t.writers[name]
+	if !ok {
+		var out io.Writer = os.Stdout
+		if name != "-" {
+			f, err := os.Create(name)
+			if err != nil {
+				return err
+			}
+			t.files = append(t.files, f)
+			out = f
+		}
+
+		var err error
+		if w, err = internal.NewTickWriter(out, t.format); err != nil {
+			return err
+		}
+		t.writers[name] = w
+	}
+	return w.Write(pair, rate)
+}
+
+func (t *tickFiles) Close() error {
+	var errs []string
+	for name, w := range t.writers {
+		if err := w.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	for _, f := range t.files {
+		if err := f.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) != 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -3,20 +3,28 @@ package main
 import (
 	"context"
 	"generator/internal"
+	pb "generator/internal/api/grpc/v1"
 	"generator/internal/api/http/v1"
 	"generator/internal/config"
 	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	"github.com/mazitovt/logger"
+	"google.golang.org/grpc"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
-const defaultLogLevel = logger.Info
+const (
+	defaultLogLevel = logger.Info
+	// backfillPushTimeout limits each request pushing backfilled rates to history
+	backfillPushTimeout = 30 * time.Second
+)
 
 func main() {
 
@@ -32,29 +40,93 @@ func main() {
 		l = logger.New(level)
 	}
 
+	// offline mode writes tick files and exits
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		checkErr(export(cfg, os.Args[2:], l))
+		return
+	}
+
 	f, err := config.GetGeneratorFunc(cfg)
 	checkErr(err)
 
-	g := internal.NewSimplePriceGenerator(cfg.CurrencyPairs, f, uint64(cfg.CacheSize), l)
+	schedule, err := config.GetScheduleFunc(cfg)
+	checkErr(err)
+
+	quote, err := config.GetQuoteFunc(cfg)
+	checkErr(err)
+
+	faults, err := config.GetFaultFunc(cfg)
+	checkErr(err)
+
+	instruments, err := config.GetInstruments(cfg)
+	checkErr(err)
+
+	scenario, err := config.GetScenario(cfg)
+	checkErr(err)
+
+	clock := config.GetClock(cfg)
+	// rates of snapshots are encoded by requests, Put of cache only bumps its version
+	g := internal.NewSimplePriceGenerator(cfg.CurrencyPairs, internal.GeneratorOptions{
+		Instruments: instruments,
+		Prices:      f,
+		Quote:       quote,
+		Faults:      faults,
+		Clock:       clock,
+		NewCache:    internal.NewSnapshotCacheFunc(config.GetCacheFunc(cfg, clock), cfg.Snapshot.Gzip),
+		Logger:      l,
+	})
+	if scenario != nil {
+		checkErr(g.PlayScenario(*scenario))
+	}
+	checkErr(g.HTTPFaults().SetRules(config.GetHTTPFaultRules(cfg)))
+
+	backfill, err := config.GetBackfillFunc(cfg)
+	checkErr(err)
+	if backfill != nil {
+		g.SetBackfill(internal.NewBackfill(backfill, cfg.Backfill.IngestURL, cfg.Backfill.MaxRange, &http.Client{Timeout: backfillPushTimeout}))
+	}
 
 	// configure router
 	swagger, err := v1.GetSwagger()
 	checkErr(err)
 
 	swagger.Servers = nil
+	openapi3filter.RegisterBodyDecoder("application/yaml", internal.YAMLBodyDecoder)
 
 	r := chi.NewRouter()
-	r.Use(middleware.OapiRequestValidator(swagger))
-	v1.HandlerFromMux(g, r)
+	r.Use(g.HTTPFaults().Middleware)
+	r.Handle("/ws", internal.NewWebSocketGateway(g, cfg.WebSocket.SendBuffer, cfg.WebSocket.PingPeriod, l))
+	r.Group(func(r chi.Router) {
+		// admin tokens are checked by AdminAuthenticator, it passes names of admins to audit log
+		r.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+			Options: openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		}))
+		v1.HandlerWithOptions(g, v1.ChiServerOptions{
+			BaseRouter: r,
+			// the last middleware runs first, unauthorized requests aren't validated further
+			Middlewares: []v1.MiddlewareFunc{
+				internal.InstrumentValidator(instruments, l),
+				internal.AdminAuthenticator(cfg.Admin.Tokens, l),
+			},
+		})
+	})
+
+	gs := grpc.NewServer()
+	pb.RegisterGeneratorServer(gs, internal.NewGRPCServer(g, l))
+
+	lis, err := net.Listen("tcp", net.JoinHostPort(cfg.Host, cfg.GrpcPort))
+	checkErr(err)
+
+	// shutdown gracefully
+	ctx, cancel := context.WithCancel(context.Background())
 
 	s := &http.Server{
 		Handler: r,
 		Addr:    net.JoinHostPort(cfg.Host, cfg.Port),
+		// streams end when service is stopped
+		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
 
-	// shutdown gracefully
-	ctx, cancel := context.WithCancel(context.Background())
-
 	idleConnsClosed := make(chan struct{})
 
 	exit := make(chan os.Signal, 1)
@@ -66,14 +138,22 @@ func main() {
 		if err := s.Shutdown(context.Background()); err != nil {
 			log.Printf("HTTP server Shutdown: %v", err)
 		}
+		// streams never end by themselves
+		gs.Stop()
 		close(idleConnsClosed)
 	}()
 
 	// Start service
-	go g.Start(ctx, cfg.Period)
+	go g.Start(ctx, schedule)
 	l.Info("Service started")
 
-	// Start server
+	// Start servers
+	go func() {
+		if err := gs.Serve(lis); err != nil {
+			checkErr(err)
+		}
+	}()
+
 	if err = s.ListenAndServe(); err != http.ErrServerClosed {
 		checkErr(err)
 	}
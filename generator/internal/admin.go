@@ -0,0 +1,315 @@
+package internal
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrShock      = errors.New("shock must have pips or change greater than -1")
+	ErrShockPrice = errors.New("shock would make price zero or negative")
+)
+
+// auditLogSize is a number of the latest admin actions kept by generator
+const auditLogSize = 1000
+
+// bearerPrefix starts Authorization header of admin operations
+const bearerPrefix = "Bearer "
+
+// adminActorKey is a context key of authenticated admin name
+type adminActorKey struct{}
+
+// pairControl keeps live changes of currency pair made by admins
+type pairControl struct {
+	mu     sync.Mutex
+	frozen bool
+	// factor and offset are accumulated shocks, offset is in price units
+	factor float64
+	offset int64
+	// price is the latest price before shocks, priced is false until the first one
+	price  int64
+	priced bool
+}
+
+// apply returns price with shocks. False means that the pair is frozen and rate isn't generated.
+func (c *pairControl) apply(price int64) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.price, c.priced = price, true
+	return shocked(price, c.factor, c.offset), !c.frozen
+}
+
+// shock adds offset and change to following prices. False means that the latest price would be zero or negative,
+// shock isn't added then.
+func (c *pairControl) shock(offset int64, change float64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	factor, offset := c.factor*(1+change), c.offset+offset
+	if c.priced && shocked(c.price, factor, offset) <= 0 {
+		return false
+	}
+	c.factor, c.offset = factor, offset
+	return true
+}
+
+func shocked(price int64, factor float64, offset int64) int64 {
+	return int64(math.Round(float64(price)*factor)) + offset
+}
+
+func (c *pairControl) freeze(frozen bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frozen = frozen
+}
+
+// AuditLog keeps the latest admin actions and logs each of them
+type AuditLog struct {
+	size   int
+	logger logger.Logger
+
+	mu      sync.Mutex
+	entries []v1.AuditEntry
+}
+
+func NewAuditLog(size int, logger logger.Logger) *AuditLog {
+	return &AuditLog{size: size, logger: logger}
+}
+
+func (a *AuditLog) Add(e v1.AuditEntry) {
+	details := ""
+	if e.Shock != nil {
+		details = fmt.Sprintf(", pips=%v, change=%v", valueOf(e.Shock.Pips), valueOf(e.Shock.Change))
+	}
+	if e.HttpFaults != nil {
+		details = fmt.Sprintf(", rules=%v", len(e.HttpFaults.Rules))
+	}
+	if e.ScenarioRun != nil {
+		details = fmt.Sprintf(", run=%v", *e.ScenarioRun)
+	}
+	if e.Backfill != nil {
+		details = fmt.Sprintf(", pairs=%v, from=%v, push=%v", e.Backfill.Pairs, e.Backfill.From, valueOf(e.Backfill.Push))
+	}
+	a.logger.Info("audit: actor=%v, action=%v, currency=%v%v", e.Actor, e.Action, valueOf(e.CurrencyPair), details)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.entries) == a.size {
+		a.entries = append(a.entries[:0], a.entries[1:]...)
+	}
+	a.entries = append(a.entries, e)
+}
+
+// Entries returns entries, the oldest first
+func (a *AuditLog) Entries() []v1.AuditEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]v1.AuditEntry{}, a.entries...)
+}
+
+// AdminAuthenticator responds 401 to requests of operations with bearerAuth security, if bearer token isn't one of tokens.
+// Tokens map admin names to their tokens, no tokens disable admin operations.
+func AdminAuthenticator(tokens map[string]string, logger logger.Logger) v1.MiddlewareFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			if req.Context().Value(v1.BearerAuthScopes) == nil {
+				next(w, req)
+				return
+			}
+
+			// header without Bearer scheme is rejected even if it is a valid token
+			header := req.Header.Get("Authorization")
+			if token := strings.TrimPrefix(header, bearerPrefix); token != header && token != "" {
+				for actor, t := range tokens {
+					if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+						next(w, req.WithContext(context.WithValue(req.Context(), adminActorKey{}, actor)))
+						return
+					}
+				}
+			}
+
+			logger.Warn("admin request rejected: %v %v", req.Method, req.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			if err := json.NewEncoder(w).Encode(v1.Error{Code: http.StatusUnauthorized, Message: "invalid admin token"}); err != nil {
+				logger.Error("Encode.Err: %v", err)
+			}
+		}
+	}
+}
+
+// adminActor returns name of admin authenticated by AdminAuthenticator
+func adminActor(ctx context.Context) string {
+	actor, _ := ctx.Value(adminActorKey{}).(string)
+	return actor
+}
+
+// pairControl returns live changes of generated currency pair
+func (s *SimplePriceGenerator) pairControl(currencyPair string) (*pairControl, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.pairs[currencyPair]
+	if !ok {
+		return nil, false
+	}
+	return &p.control, true
+}
+
+// Shock adds jump of pips and relative change to all following prices of currency pair.
+// Shock that would make the latest price zero or negative is rejected.
+func (s *SimplePriceGenerator) Shock(currencyPair string, pips int64, change float64) error {
+	if (pips == 0 && change == 0) || change <= -1 {
+		return ErrShock
+	}
+	c, ok := s.pairControl(currencyPair)
+	if !ok {
+		return ErrUnknownCurrencyPair
+	}
+	if !c.shock(pips*s.instruments.PipUnits(currencyPair), change) {
+		return fmt.Errorf("%s: %w", currencyPair, ErrShockPrice)
+	}
+	return nil
+}
+
+// Freeze stops new rates of currency pair until Resume, its model keeps running
+func (s *SimplePriceGenerator) Freeze(currencyPair string) error {
+	c, ok := s.pairControl(currencyPair)
+	if !ok {
+		return ErrUnknownCurrencyPair
+	}
+	c.freeze(true)
+	return nil
+}
+
+// Resume generates rates of frozen currency pair again
+func (s *SimplePriceGenerator) Resume(currencyPair string) error {
+	c, ok := s.pairControl(currencyPair)
+	if !ok {
+		return ErrUnknownCurrencyPair
+	}
+	c.freeze(false)
+	return nil
+}
+
+func (s *SimplePriceGenerator) PostAdminPairsCurrencyPairShock(w http.ResponseWriter, r *http.Request, currencyPair string) {
+	var body v1.PostAdminPairsCurrencyPairShockJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	err := s.Shock(currencyPair, valueOf(body.Pips), valueOf(body.Change))
+	if errors.Is(err, ErrShock) || errors.Is(err, ErrShockPrice) {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.writeAdminAction(w, r, currencyPair, v1.AuditEntryActionShock, &body, err)
+}
+
+func (s *SimplePriceGenerator) PostAdminPairsCurrencyPairFreeze(w http.ResponseWriter, r *http.Request, currencyPair string) {
+	s.writeAdminAction(w, r, currencyPair, v1.AuditEntryActionFreeze, nil, s.Freeze(currencyPair))
+}
+
+func (s *SimplePriceGenerator) PostAdminPairsCurrencyPairResume(w http.ResponseWriter, r *http.Request, currencyPair string) {
+	s.writeAdminAction(w, r, currencyPair, v1.AuditEntryActionResume, nil, s.Resume(currencyPair))
+}
+
+func (s *SimplePriceGenerator) GetAdminAudit(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(s.audit.Entries()); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+// writeAdminAction writes audit entry of successful action, err of action is written as 404
+func (s *SimplePriceGenerator) writeAdminAction(w http.ResponseWriter, r *http.Request, currencyPair string, action v1.AuditEntryAction, shock *v1.Shock, err error) {
+	if err != nil {
+		s.writeError(w, http.StatusNotFound, fmt.Sprintf("service doesn't generate values for '%s'", currencyPair))
+		return
+	}
+
+	e := v1.AuditEntry{
+		Time:         time.Now().Round(time.Microsecond),
+		Actor:        adminActor(r.Context()),
+		Action:       action,
+		CurrencyPair: &currencyPair,
+		Shock:        shock,
+	}
+	s.writeAuditEntry(w, e)
+}
+
+// addAuditEntry adds entry of admin action made by request to audit log
+func (s *SimplePriceGenerator) addAuditEntry(r *http.Request, e v1.AuditEntry) {
+	e.Time = time.Now().Round(time.Microsecond)
+	e.Actor = adminActor(r.Context())
+	s.audit.Add(e)
+}
+
+// writeAuditEntry adds entry to audit log and writes it
+func (s *SimplePriceGenerator) writeAuditEntry(w http.ResponseWriter, e v1.AuditEntry) {
+	s.audit.Add(e)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(e); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+// HTTPFaults returns fault injector of HTTP API, its middleware must be used by router
+func (s *SimplePriceGenerator) HTTPFaults() *HTTPFaults {
+	return s.httpFaults
+}
+
+func (s *SimplePriceGenerator) GetAdminHttpFaults(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(v1.HTTPFaults{Rules: s.httpFaults.Rules()}); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+func (s *SimplePriceGenerator) PutAdminHttpFaults(w http.ResponseWriter, r *http.Request) {
+	var body v1.PutAdminHttpFaultsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	if err := s.httpFaults.SetRules(body.Rules); err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.writeAuditEntry(w, v1.AuditEntry{
+		Time:       time.Now().Round(time.Microsecond),
+		Actor:      adminActor(r.Context()),
+		Action:     v1.AuditEntryActionHttpFaults,
+		HttpFaults: &body,
+	})
+}
+
+// valueOf returns value of optional field, zero if it isn't set
+func valueOf[T any](p *T) T {
+	var v T
+	if p != nil {
+		v = *p
+	}
+	return v
+}
@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"encoding/json"
+	"generator/internal/api/http/v1"
+	"github.com/go-chi/chi/v5"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSimplePriceGenerator_Shock(t *testing.T) {
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Clock:       RealClock{},
+		NewCache:    NewLimitedCacheFunc(10),
+		Logger:      logger.New(logger.Info),
+	})
+	c, _ := g.pairControl("EURUSD")
+
+	require.ErrorIs(t, g.Shock("EURUSD", 0, 0), ErrShock)
+	require.ErrorIs(t, g.Shock("EURUSD", 0, -1), ErrShock)
+	require.ErrorIs(t, g.Shock("USDJPY", 10, 0), ErrUnknownCurrencyPair)
+
+	// pip of EURUSD is 10 units at precision 5
+	require.Nil(t, g.Shock("EURUSD", -50, 0))
+	price, ok := c.apply(100000)
+	require.Equal(t, int64(99500), price)
+	require.True(t, ok)
+
+	require.Nil(t, g.Shock("EURUSD", 0, 0.01))
+	price, _ = c.apply(100000)
+	require.Equal(t, int64(100500), price)
+
+	require.Nil(t, g.Freeze("EURUSD"))
+	_, ok = c.apply(100000)
+	require.False(t, ok)
+
+	require.Nil(t, g.Resume("EURUSD"))
+	_, ok = c.apply(100000)
+	require.True(t, ok)
+
+	// the latest price 1.00500 can't fall to zero, rejected shock isn't added
+	require.ErrorIs(t, g.Shock("EURUSD", -10050, 0), ErrShockPrice)
+	require.ErrorIs(t, g.Shock("EURUSD", 0, -0.999999), ErrShockPrice)
+	require.Nil(t, g.Shock("EURUSD", -10049, 0))
+	price, _ = c.apply(100000)
+	require.Equal(t, int64(10), price)
+
+	require.ErrorIs(t, g.Freeze("USDJPY"), ErrUnknownCurrencyPair)
+	require.ErrorIs(t, g.Resume("USDJPY"), ErrUnknownCurrencyPair)
+}
+
+func TestAdminAuthenticator(t *testing.T) {
+	l := logger.New(logger.Info)
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Clock:       RealClock{},
+		NewCache:    NewLimitedCacheFunc(10),
+		Logger:      l,
+	})
+
+	r := chi.NewRouter()
+	v1.HandlerWithOptions(g, v1.ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: []v1.MiddlewareFunc{AdminAuthenticator(map[string]string{"oncall": "secret"}, l)},
+	})
+
+	do := func(method, path, authorization, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   string
+		code   int
+	}{
+		{name: "public operation", method: http.MethodGet, path: "/pairs", code: http.StatusOK},
+		{name: "missing token", method: http.MethodPost, path: "/admin/pairs/EURUSD/freeze", code: http.StatusUnauthorized},
+		{name: "unknown token", method: http.MethodPost, path: "/admin/pairs/EURUSD/freeze", token: "Bearer oncall", code: http.StatusUnauthorized},
+		{name: "audit without token", method: http.MethodGet, path: "/admin/audit", code: http.StatusUnauthorized},
+		{name: "token without bearer scheme", method: http.MethodPost, path: "/admin/pairs/EURUSD/freeze", token: "secret", code: http.StatusUnauthorized},
+		{name: "empty bearer token", method: http.MethodPost, path: "/admin/pairs/EURUSD/freeze", token: "Bearer ", code: http.StatusUnauthorized},
+		{name: "add pair without token", method: http.MethodPost, path: "/pairs", body: `{"currency_pair":"USDJPY"}`, code: http.StatusUnauthorized},
+		{name: "delete pair without token", method: http.MethodDelete, path: "/pairs/EURUSD", code: http.StatusUnauthorized},
+		{name: "play scenario without token", method: http.MethodPut, path: "/scenario", body: flashCrash, code: http.StatusUnauthorized},
+		{name: "stop scenario without token", method: http.MethodDelete, path: "/scenario", code: http.StatusUnauthorized},
+		{name: "backfill without token", method: http.MethodPost, path: "/backfill", body: `{"pairs":["EURUSD"],"from":"2022-08-01T00:00:00Z"}`, code: http.StatusUnauthorized},
+		{name: "freeze", method: http.MethodPost, path: "/admin/pairs/EURUSD/freeze", token: "Bearer secret", code: http.StatusOK},
+		{name: "freeze not generated pair", method: http.MethodPost, path: "/admin/pairs/USDJPY/freeze", token: "Bearer secret", code: http.StatusNotFound},
+		{name: "empty shock", method: http.MethodPost, path: "/admin/pairs/EURUSD/shock", token: "Bearer secret", body: `{}`, code: http.StatusBadRequest},
+		{name: "shock", method: http.MethodPost, path: "/admin/pairs/EURUSD/shock", token: "Bearer secret", body: `{"pips":-50}`, code: http.StatusOK},
+		{name: "resume", method: http.MethodPost, path: "/admin/pairs/EURUSD/resume", token: "Bearer secret", code: http.StatusOK},
+		{name: "invalid http faults", method: http.MethodPut, path: "/admin/http-faults", token: "Bearer secret", body: `{"rules":[{"route":"rates"}]}`, code: http.StatusBadRequest},
+		{name: "http faults", method: http.MethodPut, path: "/admin/http-faults", token: "Bearer secret", body: `{"rules":[{"route":"/rates/*","error_probability":0.1}]}`, code: http.StatusOK},
+		{name: "play scenario", method: http.MethodPut, path: "/scenario", token: "Bearer secret", body: flashCrash, code: http.StatusOK},
+		{name: "stop scenario", method: http.MethodDelete, path: "/scenario", token: "Bearer secret", code: http.StatusNoContent},
+		{name: "delete pair", method: http.MethodDelete, path: "/pairs/EURUSD", token: "Bearer secret", code: http.StatusNoContent},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := do(tc.method, tc.path, tc.token, tc.body)
+			require.Equal(t, tc.code, w.Code)
+			if tc.code == http.StatusUnauthorized {
+				require.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+
+	w := do(http.MethodGet, "/admin/audit", "Bearer secret", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var entries []v1.AuditEntry
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&entries))
+	require.Len(t, entries, 7)
+	for i, action := range []v1.AuditEntryAction{v1.AuditEntryActionFreeze, v1.AuditEntryActionShock, v1.AuditEntryActionResume} {
+		require.Equal(t, action, entries[i].Action)
+		require.Equal(t, "oncall", entries[i].Actor)
+		require.Equal(t, "EURUSD", *entries[i].CurrencyPair)
+	}
+	require.Equal(t, int64(-50), *entries[1].Shock.Pips)
+	require.Equal(t, v1.AuditEntryActionHttpFaults, entries[3].Action)
+	require.Nil(t, entries[3].CurrencyPair)
+	require.Equal(t, v1.AuditEntryActionPutScenario, entries[4].Action)
+	require.Equal(t, "qa", *entries[4].ScenarioRun)
+	require.Equal(t, v1.AuditEntryActionDeleteScenario, entries[5].Action)
+	require.Equal(t, v1.AuditEntryActionDeletePair, entries[6].Action)
+	require.Equal(t, "EURUSD", *entries[6].CurrencyPair)
+	require.Equal(t, "oncall", entries[6].Actor)
+	require.Equal(t, "/rates/*", entries[3].HttpFaults.Rules[0].Route)
+
+	w = do(http.MethodGet, "/admin/http-faults", "Bearer secret", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var faults v1.HTTPFaults
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&faults))
+	require.Equal(t, entries[3].HttpFaults, &faults)
+}
+
+func TestAuditLog(t *testing.T) {
+	a := NewAuditLog(2, logger.New(logger.Info))
+	for _, pair := range []string{"EURUSD", "USDJPY", "GBPUSD"} {
+		pair := pair
+		a.Add(v1.AuditEntry{Time: time.Now(), Actor: "oncall", Action: v1.AuditEntryActionFreeze, CurrencyPair: &pair})
+	}
+
+	entries := a.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "USDJPY", *entries[0].CurrencyPair)
+	require.Equal(t, "GBPUSD", *entries[1].CurrencyPair)
+}
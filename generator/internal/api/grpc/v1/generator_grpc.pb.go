@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: generator.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GeneratorClient is the client API for Generator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GeneratorClient interface {
+	// GetRates returns cached rates for the currency pair. Rates are order by the time of creation (from old to new)
+	GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error)
+	// StreamRates sends every new rate of the currency pairs until the call is cancelled.
+	// Deleted currency pair gets response with end and isn't sent anymore, other pairs are still sent
+	StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (Generator_StreamRatesClient, error)
+}
+
+type generatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGeneratorClient(cc grpc.ClientConnInterface) GeneratorClient {
+	return &generatorClient{cc}
+}
+
+func (c *generatorClient) GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error) {
+	out := new(GetRatesResponse)
+	err := c.cc.Invoke(ctx, "/generator.v1.Generator/GetRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *generatorClient) StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (Generator_StreamRatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Generator_ServiceDesc.Streams[0], "/generator.v1.Generator/StreamRates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &generatorStreamRatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Generator_StreamRatesClient interface {
+	Recv() (*StreamRatesResponse, error)
+	grpc.ClientStream
+}
+
+type generatorStreamRatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *generatorStreamRatesClient) Recv() (*StreamRatesResponse, error) {
+	m := new(StreamRatesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GeneratorServer is the server API for Generator service.
+// All implementations must embed UnimplementedGeneratorServer
+// for forward compatibility
+type GeneratorServer interface {
+	// GetRates returns cached rates for the currency pair. Rates are order by the time of creation (from old to new)
+	GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error)
+	// StreamRates sends every new rate of the currency pairs until the call is cancelled.
+	// Deleted currency pair gets response with end and isn't sent anymore, other pairs are still sent
+	StreamRates(*StreamRatesRequest, Generator_StreamRatesServer) error
+	mustEmbedUnimplementedGeneratorServer()
+}
+
+// UnimplementedGeneratorServer must be embedded to have forward compatible implementations.
+type UnimplementedGeneratorServer struct {
+}
+
+func (UnimplementedGeneratorServer) GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRates not implemented")
+}
+func (UnimplementedGeneratorServer) StreamRates(*StreamRatesRequest, Generator_StreamRatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRates not implemented")
+}
+func (UnimplementedGeneratorServer) mustEmbedUnimplementedGeneratorServer() {}
+
+// UnsafeGeneratorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GeneratorServer will
+// result in compilation errors.
+type UnsafeGeneratorServer interface {
+	mustEmbedUnimplementedGeneratorServer()
+}
+
+func RegisterGeneratorServer(s grpc.ServiceRegistrar, srv GeneratorServer) {
+	s.RegisterService(&Generator_ServiceDesc, srv)
+}
+
+func _Generator_GetRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeneratorServer).GetRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generator.v1.Generator/GetRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeneratorServer).GetRates(ctx, req.(*GetRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Generator_StreamRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeneratorServer).StreamRates(m, &generatorStreamRatesServer{stream})
+}
+
+type Generator_StreamRatesServer interface {
+	Send(*StreamRatesResponse) error
+	grpc.ServerStream
+}
+
+type generatorStreamRatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *generatorStreamRatesServer) Send(m *StreamRatesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Generator_ServiceDesc is the grpc.ServiceDesc for Generator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Generator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "generator.v1.Generator",
+	HandlerType: (*GeneratorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRates",
+			Handler:    _Generator_GetRates_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRates",
+			Handler:       _Generator_StreamRates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "generator.proto",
+}
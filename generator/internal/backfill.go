@@ -0,0 +1,427 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"math"
+	"mtsbank/pkg/decimal"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrBackfillOff    = errors.New("backfill isn't configured")
+	ErrBackfillRange  = errors.New("backfill range must start before its end and the first live rate")
+	ErrBackfillLong   = errors.New("backfill range is longer than maximal range")
+	ErrBackfillCursor = errors.New("invalid backfill cursor")
+	ErrBackfillIngest = errors.New("history ingest URL isn't configured")
+	ErrBackfillPush   = errors.New("history rejected backfilled rates")
+)
+
+const (
+	defaultBackfillPageSize = 1000
+	defaultBackfillMaxRange = 7 * 24 * time.Hour
+	// backfillPushBatch is a maximal number of rates of one push request
+	backfillPushBatch = 1000
+	// backfillSessions is a maximal number of paused exports kept for the next pages, the oldest is dropped first
+	backfillSessions = 16
+	// backfillSessionTTL is a time a paused export waits for the next page
+	backfillSessionTTL = 5 * time.Minute
+)
+
+// BackfillFunc creates fresh models of backfill, models of every call generate the same rates
+type BackfillFunc func() (GeneratorFunc, ScheduleFunc, QuoteFunc)
+
+// Backfill synthesises rates before live rates and pushes them to history ingest URL
+type Backfill struct {
+	models    BackfillFunc
+	ingestURL string
+	maxRange  time.Duration
+	client    *http.Client
+
+	// mu guards sessions, exports paused after pages by their cursors
+	mu       sync.Mutex
+	sessions map[string]backfillSession
+}
+
+// backfillSession is an export paused after a page
+type backfillSession struct {
+	it      *tickIterator
+	expires time.Time
+}
+
+// NewBackfill creates backfill of models, empty ingestURL disables push.
+// Range of backfill is limited by maxRange, zero maxRange is replaced with default.
+func NewBackfill(models BackfillFunc, ingestURL string, maxRange time.Duration, client *http.Client) *Backfill {
+	if maxRange <= 0 {
+		maxRange = defaultBackfillMaxRange
+	}
+	return &Backfill{
+		models:    models,
+		ingestURL: strings.TrimSuffix(ingestURL, "/"),
+		maxRange:  maxRange,
+		client:    client,
+		sessions:  map[string]backfillSession{},
+	}
+}
+
+// backfillKey identifies paused export of request by its cursor
+func backfillKey(currencyPairs []string, from, to time.Time, cursor streamCursor) string {
+	return fmt.Sprintf("%s|%d|%d|%s", strings.Join(currencyPairs, ","), from.UnixNano(), to.UnixNano(), cursor.String())
+}
+
+// pause keeps export of request until the next page is requested by cursor
+func (b *Backfill) pause(key string, it *tickIterator) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	oldest := ""
+	for k, session := range b.sessions {
+		if now.After(session.expires) {
+			delete(b.sessions, k)
+			continue
+		}
+		if oldest == "" || session.expires.Before(b.sessions[oldest].expires) {
+			oldest = k
+		}
+	}
+	if len(b.sessions) >= backfillSessions {
+		delete(b.sessions, oldest)
+	}
+	b.sessions[key] = backfillSession{it: it, expires: now.Add(backfillSessionTTL)}
+}
+
+// resume takes export paused by pause, false means that it expired or was dropped
+func (b *Backfill) resume(key string) (*tickIterator, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	session, ok := b.sessions[key]
+	delete(b.sessions, key)
+	if !ok || time.Now().After(session.expires) {
+		return nil, false
+	}
+	return session.it, true
+}
+
+// pathStart is the first live price of currency pair, backfilled prices end at it
+type pathStart struct {
+	mu   sync.Mutex
+	ok   bool
+	time time.Time
+	mid  int64
+}
+
+// set keeps the first price only
+func (p *pathStart) set(t time.Time, mid int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.ok {
+		p.ok, p.time, p.mid = true, t, mid
+	}
+}
+
+func (p *pathStart) get() (time.Time, int64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.time, p.mid, p.ok
+}
+
+// SetBackfill enables POST /backfill
+func (s *SimplePriceGenerator) SetBackfill(b *Backfill) {
+	s.backfill = b
+}
+
+// Backfill writes rates of currency pairs created from from until to and after positions of cursor.
+// Rates of pair are never created after its first live rate, zero to means the first live rate.
+// Prices are scaled to end at the first live price, so live rates continue backfilled ones.
+// Writing stops after limit rates, zero limit writes all rates. Cursor of the next page is returned if rates remain.
+//
+// Rates of every pair are created from from, so export paused after a page is kept for its cursor,
+// and the next page continues it. Export is repeated only when it has expired.
+func (s *SimplePriceGenerator) Backfill(ctx context.Context, currencyPairs []string, from, to time.Time, cursor streamCursor, limit int, w TickWriter) (int, streamCursor, error) {
+	if s.backfill == nil {
+		return 0, nil, ErrBackfillOff
+	}
+
+	ends := make(map[string]time.Time, len(currencyPairs))
+	anchors := &backfillAnchors{starts: map[string]backfillStart{}, factors: map[string]float64{}}
+	var last, anchor time.Time
+	for _, pair := range currencyPairs {
+		s.mu.RLock()
+		p, ok := s.pairs[pair]
+		s.mu.RUnlock()
+		if !ok {
+			return 0, nil, fmt.Errorf("%s: %w", pair, ErrUnknownCurrencyPair)
+		}
+
+		end := to
+		if t, mid, ok := p.start.get(); ok {
+			if end.IsZero() || t.Before(end) {
+				end = t
+			}
+			anchors.starts[pair] = backfillStart{time: t, mid: mid}
+			if t.After(anchor) {
+				anchor = t
+			}
+		}
+		if end.IsZero() || !from.Before(end) {
+			return 0, nil, fmt.Errorf("%s: %w", pair, ErrBackfillRange)
+		}
+		if end.Sub(from) > s.backfill.maxRange {
+			return 0, nil, fmt.Errorf("%s: %w %v", pair, ErrBackfillLong, s.backfill.maxRange)
+		}
+		ends[pair] = end
+		if end.After(last) {
+			last = end
+		}
+	}
+	for pair := range cursor {
+		if _, ok := ends[pair]; !ok {
+			return 0, nil, fmt.Errorf("%s: %w", pair, ErrBackfillCursor)
+		}
+	}
+
+	it, ok := s.backfill.resume(backfillKey(currencyPairs, from, to, cursor))
+	if !ok {
+		var err error
+		if it, err = s.backfillExport(ctx, currencyPairs, from, last, anchor, anchors); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	next := streamCursor{}
+	for pair, t := range cursor {
+		next[pair] = t
+	}
+	n := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return n, nil, err
+		}
+		pair, rate, ok := it.next()
+		if !ok {
+			return n, nil, nil
+		}
+		// rates of repeated export up to cursor were written by previous pages
+		if pos, ok := next[pair]; (ok && !rate.Time.After(pos)) || !rate.Time.Before(ends[pair]) {
+			continue
+		}
+		if limit > 0 && n == limit {
+			it.unread()
+			s.backfill.pause(backfillKey(currencyPairs, from, to, next), it)
+			return n, next, nil
+		}
+		if err := w.Write(pair, rate); err != nil {
+			return n, nil, err
+		}
+		next[pair] = rate.Time
+		n++
+	}
+}
+
+// backfillExport starts export of rates from from until last, prices of which are scaled to end at the first live prices
+func (s *SimplePriceGenerator) backfillExport(ctx context.Context, currencyPairs []string, from, last, anchor time.Time, anchors *backfillAnchors) (*tickIterator, error) {
+	// prices of models at the first live rates give factors of pairs,
+	// all pairs are generated since models of correlated pairs depend on each other
+	if len(anchors.starts) != 0 {
+		f, schedule, _ := s.backfill.models()
+		e := NewTickExporter(s.instruments, f, schedule, nil, nil)
+		if _, err := e.Export(ctx, currencyPairs, from, anchor, anchors); err != nil {
+			return nil, err
+		}
+	}
+
+	f, schedule, quote := s.backfill.models()
+	scaled := func(pair string) int64 {
+		price := f(pair)
+		if factor, ok := anchors.factors[pair]; ok {
+			return int64(math.Round(float64(price) * factor))
+		}
+		return price
+	}
+
+	return NewTickExporter(s.instruments, scaled, schedule, quote, nil).iterate(currencyPairs, from, last), nil
+}
+
+// backfillStart is time and price of the first live rate of currency pair
+type backfillStart struct {
+	time time.Time
+	mid  int64
+}
+
+// backfillAnchors finds factors of pairs: the first live price divided by price of model at time of the first live rate
+type backfillAnchors struct {
+	starts  map[string]backfillStart
+	factors map[string]float64
+}
+
+func (a *backfillAnchors) Write(pair string, rate v1.ExchangeRate) error {
+	start, ok := a.starts[pair]
+	if !ok || rate.Time.After(start.time) {
+		return nil
+	}
+
+	price, _, err := decimal.Parse(rate.Mid)
+	if err != nil || price == 0 {
+		delete(a.factors, pair)
+		return nil
+	}
+	a.factors[pair] = float64(start.mid) / float64(price)
+	return nil
+}
+
+func (a *backfillAnchors) Close() error {
+	return nil
+}
+
+// ticksWriter keeps rates of page
+type ticksWriter struct {
+	ticks []v1.BackfillTick
+}
+
+func (t *ticksWriter) Write(pair string, rate v1.ExchangeRate) error {
+	t.ticks = append(t.ticks, v1.BackfillTick{CurrencyPair: pair, Rate: rate})
+	return nil
+}
+
+func (t *ticksWriter) Close() error {
+	return nil
+}
+
+// ingestWriter pushes rates of each currency pair to history by batches
+type ingestWriter struct {
+	ctx     context.Context
+	b       *Backfill
+	batches map[string][]v1.ExchangeRate
+}
+
+func (i *ingestWriter) Write(pair string, rate v1.ExchangeRate) error {
+	i.batches[pair] = append(i.batches[pair], rate)
+	if len(i.batches[pair]) < backfillPushBatch {
+		return nil
+	}
+	return i.push(pair)
+}
+
+func (i *ingestWriter) Close() error {
+	for pair := range i.batches {
+		if err := i.push(pair); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *ingestWriter) push(pair string) error {
+	rates := i.batches[pair]
+	if len(rates) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(i.ctx, http.MethodPost, i.b.ingestURL+"/"+pair, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := i.b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBackfillPush, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: %w: %s", pair, ErrBackfillPush, resp.Status)
+	}
+
+	i.batches[pair] = rates[:0]
+	return nil
+}
+
+func (s *SimplePriceGenerator) PostBackfill(w http.ResponseWriter, r *http.Request) {
+	var body v1.PostBackfillJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	var cursor streamCursor
+	if body.Cursor != nil {
+		var err error
+		if cursor, err = parseStreamCursor(*body.Cursor); err != nil {
+			s.writeError(w, http.StatusBadRequest, fmt.Sprintf("%v: %v", ErrBackfillCursor, err))
+			return
+		}
+	}
+	limit := defaultBackfillPageSize
+	if body.PageSize != nil {
+		limit = int(*body.PageSize)
+	}
+	var to time.Time
+	if body.To != nil {
+		to = *body.To
+	}
+
+	page := v1.BackfillPage{Ticks: []v1.BackfillTick{}}
+	var err error
+	if valueOf(body.Push) {
+		if s.backfill != nil && s.backfill.ingestURL == "" {
+			s.writeError(w, http.StatusBadRequest, ErrBackfillIngest.Error())
+			return
+		}
+		ingest := &ingestWriter{ctx: r.Context(), b: s.backfill, batches: map[string][]v1.ExchangeRate{}}
+		var n int
+		if n, _, err = s.Backfill(r.Context(), body.Pairs, body.From, to, cursor, 0, ingest); err == nil {
+			err = ingest.Close()
+		}
+		pushed := int64(n)
+		page.Pushed = &pushed
+	} else {
+		ticks := &ticksWriter{ticks: page.Ticks}
+		var next streamCursor
+		_, next, err = s.Backfill(r.Context(), body.Pairs, body.From, to, cursor, limit, ticks)
+		page.Ticks = ticks.ticks
+		if next != nil {
+			c := next.String()
+			page.NextCursor = &c
+		}
+	}
+
+	switch {
+	case errors.Is(err, ErrUnknownCurrencyPair):
+		s.writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, ErrBackfillRange), errors.Is(err, ErrBackfillLong), errors.Is(err, ErrBackfillCursor),
+		errors.Is(err, ErrBackfillIngest), errors.Is(err, ErrBackfillOff):
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, ErrBackfillPush):
+		s.writeError(w, http.StatusBadGateway, err.Error())
+		return
+	case err != nil:
+		s.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.addAuditEntry(r, v1.AuditEntry{Action: v1.AuditEntryActionBackfill, Backfill: &body})
+	if page.Pushed != nil {
+		s.logger.Info("backfill pushed: rates=%v, pairs=%v, from=%v", *page.Pushed, body.Pairs, body.From)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err = json.NewEncoder(w).Encode(page); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
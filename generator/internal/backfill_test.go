@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newBackfillGenerator returns generator which rates of EURUSD and USDJPY started at epoch and lasted 5 seconds
+func newBackfillGenerator(t *testing.T, models BackfillFunc, ingestURL string) *SimplePriceGenerator {
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Prices:      NewExchangeRateFromSeed(123),
+		Clock:       NewVirtualClock(epoch, 0, 5*time.Second),
+		NewCache:    NewLimitedCacheFunc(100),
+		Logger:      logger.New(logger.Info),
+	})
+	g.SetBackfill(NewBackfill(models, ingestURL, 0, http.DefaultClient))
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+	return g
+}
+
+func seedBackfill(seed int64) BackfillFunc {
+	return func() (GeneratorFunc, ScheduleFunc, QuoteFunc) {
+		return NewExchangeRateFromSeed(seed), NewFixedScheduleFunc(time.Second), nil
+	}
+}
+
+func TestSimplePriceGenerator_Backfill(t *testing.T) {
+	g := newBackfillGenerator(t, seedBackfill(7), "")
+	pairs := []string{"EURUSD", "USDJPY"}
+
+	all := &sliceTickWriter{}
+	n, next, err := g.Backfill(context.Background(), pairs, epoch.Add(-time.Hour), time.Time{}, nil, 0, all)
+	require.Nil(t, err)
+	require.Nil(t, next)
+	require.Equal(t, 7200, n)
+	// backfill ends before the first live rate
+	require.True(t, all.rates[len(all.rates)-1].Time.Equal(epoch.Add(-time.Second)))
+
+	// rates are the same on every call, pages follow each other whether paused export is kept or expired
+	for _, expire := range []bool{false, true} {
+		var paged sliceTickWriter
+		var cursor streamCursor
+		for {
+			if expire {
+				g.backfill.sessions = map[string]backfillSession{}
+			}
+			_, cursor, err = g.Backfill(context.Background(), pairs, epoch.Add(-time.Hour), time.Time{}, cursor, 1000, &paged)
+			require.Nil(t, err)
+			if cursor == nil {
+				break
+			}
+		}
+		require.Equal(t, all.pairs, paged.pairs)
+		require.Equal(t, all.rates, paged.rates)
+	}
+
+	// to limits backfill
+	w := &sliceTickWriter{}
+	n, _, err = g.Backfill(context.Background(), []string{"EURUSD"}, epoch.Add(-time.Minute), epoch.Add(-30*time.Second), nil, 0, w)
+	require.Nil(t, err)
+	require.Equal(t, 30, n)
+}
+
+func TestSimplePriceGenerator_Backfill_Continuous(t *testing.T) {
+	// constant model is scaled to the first live price
+	models := func() (GeneratorFunc, ScheduleFunc, QuoteFunc) {
+		return func(string) int64 { return 100000 }, NewFixedScheduleFunc(time.Second), nil
+	}
+	g := newBackfillGenerator(t, models, "")
+
+	for _, pair := range []string{"EURUSD", "USDJPY"} {
+		rec := httptest.NewRecorder()
+		g.GetRatesCurrencyPair(rec, httptest.NewRequest(http.MethodGet, "/rates/"+pair, nil), pair, v1.GetRatesCurrencyPairParams{})
+		var live []v1.ExchangeRate
+		require.Nil(t, json.NewDecoder(rec.Body).Decode(&live))
+		require.True(t, live[0].Time.Equal(epoch))
+
+		w := &sliceTickWriter{}
+		_, _, err := g.Backfill(context.Background(), []string{pair}, epoch.Add(-10*time.Second), time.Time{}, nil, 0, w)
+		require.Nil(t, err)
+		require.Len(t, w.rates, 10)
+		for _, r := range w.rates {
+			require.Equal(t, live[0].Mid, r.Mid)
+		}
+	}
+}
+
+func TestSimplePriceGenerator_Backfill_Err(t *testing.T) {
+	g := newBackfillGenerator(t, seedBackfill(7), "")
+
+	_, _, err := g.Backfill(context.Background(), []string{"GBPUSD"}, epoch.Add(-time.Hour), time.Time{}, nil, 0, &sliceTickWriter{})
+	require.ErrorIs(t, err, ErrUnknownCurrencyPair)
+
+	_, _, err = g.Backfill(context.Background(), []string{"EURUSD"}, epoch, time.Time{}, nil, 0, &sliceTickWriter{})
+	require.ErrorIs(t, err, ErrBackfillRange)
+
+	_, _, err = g.Backfill(context.Background(), []string{"EURUSD"}, epoch.Add(-time.Minute), epoch.Add(-time.Hour), nil, 0, &sliceTickWriter{})
+	require.ErrorIs(t, err, ErrBackfillRange)
+
+	_, _, err = g.Backfill(context.Background(), []string{"EURUSD"}, epoch.Add(-time.Hour), time.Time{}, streamCursor{"USDJPY": epoch}, 0, &sliceTickWriter{})
+	require.ErrorIs(t, err, ErrBackfillCursor)
+
+	g.SetBackfill(NewBackfill(seedBackfill(7), "", time.Minute, http.DefaultClient))
+	_, _, err = g.Backfill(context.Background(), []string{"EURUSD"}, epoch.Add(-time.Hour), time.Time{}, nil, 0, &sliceTickWriter{})
+	require.ErrorIs(t, err, ErrBackfillLong)
+
+	g.SetBackfill(nil)
+	_, _, err = g.Backfill(context.Background(), []string{"EURUSD"}, epoch.Add(-time.Hour), time.Time{}, nil, 0, &sliceTickWriter{})
+	require.ErrorIs(t, err, ErrBackfillOff)
+}
+
+func TestSimplePriceGenerator_PostBackfill(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		ingested = map[string][]v1.ExchangeRate{}
+		status   = http.StatusNoContent
+	)
+	history := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rates []v1.ExchangeRate
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&rates))
+
+		mu.Lock()
+		defer mu.Unlock()
+		pair := strings.TrimPrefix(r.URL.Path, "/rates/")
+		ingested[pair] = append(ingested[pair], rates...)
+		w.WriteHeader(status)
+	}))
+	defer history.Close()
+
+	g := newBackfillGenerator(t, seedBackfill(7), history.URL+"/rates/")
+
+	post := func(body string) (*httptest.ResponseRecorder, v1.BackfillPage) {
+		rec := httptest.NewRecorder()
+		g.PostBackfill(rec, httptest.NewRequest(http.MethodPost, "/backfill", bytes.NewBufferString(body)))
+		var page v1.BackfillPage
+		if rec.Code == http.StatusOK {
+			require.Nil(t, json.NewDecoder(rec.Body).Decode(&page))
+		}
+		return rec, page
+	}
+
+	rec, page := post(`{"pairs":["EURUSD"],"from":"2022-07-31T23:00:00Z","page_size":2500}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, page.Ticks, 2500)
+	require.Equal(t, "EURUSD=2022-07-31T23:41:39Z", *page.NextCursor)
+
+	rec, page = post(`{"pairs":["EURUSD"],"from":"2022-07-31T23:00:00Z","page_size":2500,"cursor":"EURUSD=2022-07-31T23:41:39Z"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, page.Ticks, 1100)
+	require.Nil(t, page.NextCursor)
+	require.True(t, page.Ticks[len(page.Ticks)-1].Rate.Time.Equal(epoch.Add(-time.Second)))
+
+	rec, page = post(`{"pairs":["EURUSD","USDJPY"],"from":"2022-07-31T23:00:00Z","push":true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, int64(7200), *page.Pushed)
+	require.Len(t, ingested["EURUSD"], 3600)
+	require.Len(t, ingested["USDJPY"], 3600)
+
+	status = http.StatusInternalServerError
+	rec, _ = post(`{"pairs":["EURUSD"],"from":"2022-07-31T23:00:00Z","push":true}`)
+	require.Equal(t, http.StatusBadGateway, rec.Code)
+
+	rec, _ = post(`{"pairs":["EURUSD"],"from":"2022-07-31T23:00:00Z","cursor":"next"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = post(`{"pairs":["EURUSD"],"from":"2022-07-31T23:00:00Z","cursor":"USDJPY=2022-07-31T23:41:39Z"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = post(`{"pairs":["EURUSD"],"from":"2022-07-01T00:00:00Z","push":true}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = post(`{"pairs":["GBPUSD"],"from":"2022-07-31T23:00:00Z"}`)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	// push needs ingest URL
+	g.SetBackfill(NewBackfill(seedBackfill(7), "", 0, http.DefaultClient))
+	rec, _ = post(`{"pairs":["EURUSD"],"from":"2022-07-31T23:00:00Z","push":true}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
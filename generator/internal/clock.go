@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var ErrClockStopped = errors.New("clock reached its end")
+
+// Clock is a source of time for generated rates.
+type Clock interface {
+	Now() time.Time
+	// WaitUntil blocks until the clock shows t or ctx is done
+	WaitUntil(ctx context.Context, t time.Time) error
+}
+
+var (
+	_ Clock = RealClock{}
+	_ Clock = (*VirtualClock)(nil)
+)
+
+// RealClock is a wall clock.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (RealClock) WaitUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// VirtualClock starts at epoch and runs scale times faster than wall clock.
+//
+// Zero scale means that clock doesn't wait at all: WaitUntil returns immediately, and clock shows the latest time waited for.
+// Non-zero duration stops clock after epoch + duration.
+type VirtualClock struct {
+	epoch    time.Time
+	start    time.Time
+	scale    float64
+	duration time.Duration
+	// reached is the latest time waited for in nanoseconds since Unix epoch, clock of zero scale shows it
+	reached int64
+}
+
+func NewVirtualClock(epoch time.Time, scale float64, duration time.Duration) *VirtualClock {
+	return &VirtualClock{
+		epoch:    epoch,
+		start:    time.Now(),
+		scale:    scale,
+		duration: duration,
+		reached:  epoch.UnixNano(),
+	}
+}
+
+// Epoch returns the configured start of clock
+func (c *VirtualClock) Epoch() time.Time {
+	return c.epoch
+}
+
+func (c *VirtualClock) Now() time.Time {
+	if c.scale == 0 {
+		return time.Unix(0, atomic.LoadInt64(&c.reached)).In(c.epoch.Location())
+	}
+	return c.epoch.Add(time.Duration(float64(time.Since(c.start)) * c.scale))
+}
+
+func (c *VirtualClock) WaitUntil(ctx context.Context, t time.Time) error {
+	if c.duration != 0 && t.After(c.epoch.Add(c.duration)) {
+		return ErrClockStopped
+	}
+
+	if c.scale == 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.reach(t)
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(float64(t.Sub(c.Now())) / c.scale))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reach moves clock of zero scale to t, clock never goes back
+func (c *VirtualClock) reach(t time.Time) {
+	for {
+		reached := atomic.LoadInt64(&c.reached)
+		if t.UnixNano() <= reached || atomic.CompareAndSwapInt64(&c.reached, reached, t.UnixNano()) {
+			return
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+
+func TestVirtualClock_NoWaiting(t *testing.T) {
+	c := NewVirtualClock(epoch, 0, time.Hour)
+	require.Equal(t, epoch, c.Now())
+
+	start := time.Now()
+	require.Nil(t, c.WaitUntil(context.Background(), epoch.Add(time.Hour)))
+	require.Less(t, time.Since(start), 10*time.Millisecond)
+
+	// clock shows the latest time waited for and never goes back
+	require.Equal(t, epoch.Add(time.Hour), c.Now())
+	require.Nil(t, c.WaitUntil(context.Background(), epoch.Add(time.Minute)))
+	require.Equal(t, epoch.Add(time.Hour), c.Now())
+
+	require.Equal(t, ErrClockStopped, c.WaitUntil(context.Background(), epoch.Add(time.Hour+time.Nanosecond)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(t, context.Canceled, c.WaitUntil(ctx, epoch.Add(time.Minute)))
+}
+
+func TestVirtualClock_Accelerated(t *testing.T) {
+	// 1 simulated hour per real second
+	c := NewVirtualClock(epoch, 3600, 0)
+
+	start := time.Now()
+	require.Nil(t, c.WaitUntil(context.Background(), epoch.Add(6*time.Minute)))
+	elapsed := time.Since(start)
+
+	require.GreaterOrEqual(t, elapsed, 90*time.Millisecond)
+	require.Less(t, elapsed, 500*time.Millisecond)
+	require.False(t, c.Now().Before(epoch.Add(6*time.Minute)))
+}
@@ -4,6 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"generator/internal"
+	"generator/internal/api/http/v1"
+	"math/rand"
+	"mtsbank/pkg/calendar"
+	"mtsbank/pkg/decimal"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 import "github.com/kelseyhightower/envconfig"
@@ -14,18 +22,222 @@ const (
 
 var (
 	ErrMinimalCacheSize = errors.New("CACHE_SIZE must be equal or greater than zero")
-	ErrMinimalPeriod    = errors.New("PERIOD must be equal or greater than 1 second (1s)")
+	ErrCacheWindow      = errors.New("CACHE_WINDOW must be equal or greater than zero")
+	ErrGrpcPort         = errors.New("GRPC_PORT must be a port number between 1 and 65535")
+	ErrCacheRing        = errors.New("CACHE_RING can't be used with CACHE_WINDOW")
+	ErrMinimalPeriod    = errors.New("PERIOD must be equal or greater than 1 millisecond (1ms)")
+	ErrModelStart       = errors.New("MODEL_START must be greater than zero")
+	ErrUnknownModel     = errors.New("unknown model")
+	ErrClockDuration    = errors.New("CLOCK_DURATION must be set when CLOCK_SCALE is zero")
+	ErrUnknownSchedule  = errors.New("unknown schedule")
+	ErrScheduleJitter   = errors.New("SCHEDULE_JITTER must be between zero and period")
+	ErrScheduleRate     = errors.New("SCHEDULE_RATE must be greater than zero and not greater than 1000")
+	ErrCorrelationPair  = errors.New("CORRELATION_MATRIX keys must be in form EURUSD/USDJPY")
+	ErrCorrelationModel = errors.New("correlated currency pair must have MODEL_KIND")
+	ErrCrossLegs        = errors.New("CORRELATION_CROSS legs must be in form EURUSD*USDJPY or EURUSD/GBPUSD")
+	ErrQuote            = errors.New("QUOTE values must be equal or greater than zero")
+	ErrScale            = errors.New("SCALE must be between 0 and 18")
+	ErrFault            = errors.New("FAULT probabilities must be equal or greater than zero and not greater than 1 in sum")
+	ErrFaultSkew        = errors.New("FAULT_SKEW_MAX must be at least 1 microsecond (1us) for skewed currency pair")
+	ErrReplayFile       = errors.New("REPLAY_FILE must be set for REPLAY pattern")
+	ErrReplayPair       = errors.New("currency pair has no ticks in REPLAY_FILE")
+)
+
+// MinimalPeriod is the smallest period between rates of currency pair
+const MinimalPeriod = time.Millisecond
+
+const (
+	ModelRandomWalk        = "RANDOM_WALK"
+	ModelGBM               = "GBM"
+	ModelOrnsteinUhlenbeck = "OU"
+)
+
+const (
+	ScheduleFixed   = "FIXED"
+	ScheduleJitter  = "JITTER"
+	SchedulePoisson = "POISSON"
 )
 
 type Config struct {
 	LogLevel      string        `envconfig:"LOG_LEVEL"`
 	Host          string        `envconfig:"HOST"`
 	Port          string        `envconfig:"PORT"`
+	GrpcPort      string        `envconfig:"GRPC_PORT" default:"9090"`
 	CurrencyPairs []string      `envconfig:"CURRENCY_PAIRS"`
 	Pattern       string        `envconfig:"PATTERN"`
 	Seed          int64         `envconfig:"SEED"`
 	Period        time.Duration `envconfig:"PERIOD"`
 	CacheSize     int64         `envconfig:"CACHE_SIZE"`
+	Model         Model         `envconfig:"MODEL"`
+	Schedule      Schedule      `envconfig:"SCHEDULE"`
+	Correlation   Correlation   `envconfig:"CORRELATION"`
+	Quote         Quote         `envconfig:"QUOTE"`
+	Clock         Clock         `envconfig:"CLOCK"`
+	Instrument    Instrument    `envconfig:"INSTRUMENT"`
+	Calendar      Calendar      `envconfig:"CALENDAR"`
+	WebSocket     WebSocket     `envconfig:"WS"`
+	Admin         Admin         `envconfig:"ADMIN"`
+	Fault         Fault         `envconfig:"FAULT"`
+	HTTPFault     HTTPFault     `envconfig:"HTTP_FAULT"`
+	Replay        Replay        `envconfig:"REPLAY"`
+	Backfill      Backfill      `envconfig:"BACKFILL"`
+	Snapshot      Snapshot      `envconfig:"SNAPSHOT"`
+	// Scale is a number of digits after decimal point in prices per currency pair, e.g. "EURUSD:5,USDJPY:3"
+	Scale map[string]int32 `envconfig:"SCALE"`
+	// ScenarioFile is a path to YAML or JSON scenario played at startup
+	ScenarioFile string `envconfig:"SCENARIO_FILE"`
+	// CacheWindow keeps rates created within window before now of clock, CACHE_SIZE limits their number then
+	CacheWindow time.Duration `envconfig:"CACHE_WINDOW"`
+	// CacheRing keeps the last CACHE_SIZE rates in lock-free ring, readers of which never block generation
+	CacheRing bool `envconfig:"CACHE_RING"`
+}
+
+// Admin configures admin operations, they are disabled without tokens
+type Admin struct {
+	// Tokens map admin names to bearer tokens, e.g. "oncall:secret". Names are written to audit log.
+	Tokens map[string]string `envconfig:"TOKENS"`
+}
+
+// WebSocket configures WebSocket gateway. Zero values are replaced with defaults.
+type WebSocket struct {
+	// SendBuffer is a number of messages buffered per connection, slow consumer is disconnected on overflow
+	SendBuffer int           `envconfig:"SEND_BUFFER"`
+	PingPeriod time.Duration `envconfig:"PING_PERIOD"`
+}
+
+// Clock configures virtual clock. Zero Epoch means wall clock.
+type Clock struct {
+	Epoch time.Time `envconfig:"EPOCH"`
+	// Scale is number of simulated seconds per real second, zero means no waiting and clock shows time of the latest rate
+	Scale    float64       `envconfig:"SCALE"`
+	Duration time.Duration `envconfig:"DURATION"`
+}
+
+// Replay configures REPLAY pattern: rates of currency pairs are ticks of FILE. Clock starts at the first tick,
+// CLOCK_SCALE is a speed of replay and CLOCK_EPOCH moves the first tick to epoch.
+type Replay struct {
+	// File is a CSV or NDJSON file of ticks with pair, time and rate
+	File string `envconfig:"FILE"`
+	// Shift moves the first tick to start of service when CLOCK_EPOCH isn't set
+	Shift bool `envconfig:"SHIFT"`
+	// Loop repeats recording after its last tick, otherwise currency pairs get no rates after their last ticks
+	Loop bool `envconfig:"LOOP"`
+	// Recording is loaded from FILE by Init
+	Recording *internal.Replay `ignored:"true"`
+}
+
+// Backfill configures POST /backfill, backfilled rates are created by models of SEED pattern whatever PATTERN is
+type Backfill struct {
+	// IngestURL is a URL of history rates, backfilled rates of pair are pushed to IngestURL/{pair}
+	IngestURL string `envconfig:"INGEST_URL"`
+	// MaxRange limits range of currency pair, longer ranges are rejected with 400. Zero is replaced with 7 days
+	MaxRange time.Duration `envconfig:"MAX_RANGE"`
+}
+
+// Snapshot configures encoded responses of GET /rates/{currency_pair} without since and limit
+type Snapshot struct {
+	// Gzip keeps gzipped response too, it is sent to clients accepting gzip
+	Gzip bool `envconfig:"GZIP"`
+}
+
+// Model configures price models per currency pair.
+// Each field is a map from currency pair to value, e.g. "EURUSD:GBM,USDJPY:OU".
+type Model struct {
+	Kind       map[string]string  `envconfig:"KIND"`
+	Start      map[string]int64   `envconfig:"START"`
+	Drift      map[string]float64 `envconfig:"DRIFT"`
+	Volatility map[string]float64 `envconfig:"VOLATILITY"`
+	Reversion  map[string]float64 `envconfig:"REVERSION"`
+	Mean       map[string]int64   `envconfig:"MEAN"`
+}
+
+// Schedule configures arrival of rates per currency pair.
+// Each field is a map from currency pair to value, e.g. "EURUSD:POISSON,USDJPY:JITTER".
+// Currency pairs without schedule get rates every PERIOD.
+type Schedule struct {
+	Kind   map[string]string        `envconfig:"KIND"`
+	Period map[string]time.Duration `envconfig:"PERIOD"`
+	Jitter map[string]time.Duration `envconfig:"JITTER"`
+	// Rate is a mean number of rates per second of Poisson schedule
+	Rate map[string]float64 `envconfig:"RATE"`
+}
+
+// Correlation configures currency pairs driven by correlated shocks.
+// Pairs of matrix and legs of cross pairs are generated by their models with correlated shocks.
+type Correlation struct {
+	// Matrix is a correlation between pairs, e.g. "EURUSD/USDJPY:-0.3,EURUSD/GBPUSD:0.8". Missing values are zero.
+	Matrix map[string]float64 `envconfig:"MATRIX"`
+	// Cross derives currency pair from legs, e.g. "EURJPY:EURUSD*USDJPY,EURGBP:EURUSD/GBPUSD".
+	// Start price of cross pair is taken from MODEL_START.
+	Cross map[string]string `envconfig:"CROSS"`
+	// Spread is a maximal relative deviation of cross pair from its legs, e.g. "EURJPY:0.0002"
+	Spread map[string]float64 `envconfig:"SPREAD"`
+}
+
+// Quote configures bid, ask and volume of currency pairs around mid price.
+// Each field is a map from currency pair to value, e.g. "EURUSD:10,USDJPY:2".
+// Currency pairs without quote have bid and ask equal to mid price and zero volume.
+type Quote struct {
+	// SpreadMin is a minimal spread between ask and bid
+	SpreadMin map[string]int64 `envconfig:"SPREAD_MIN"`
+	// Spread is a spread relative to mid price, e.g. 0.0001
+	Spread map[string]float64 `envconfig:"SPREAD"`
+	// SpreadWidening randomly widens spread by widening * |Z|
+	SpreadWidening map[string]float64 `envconfig:"SPREAD_WIDENING"`
+	// Volume is a mean trade size of the tick
+	Volume map[string]int64 `envconfig:"VOLUME"`
+}
+
+// Fault configures anomalies injected into rates per currency pair, e.g. "EURUSD:0.01".
+// Each rate gets at most one anomaly, so sum of probabilities of the pair must not be greater than 1.
+type Fault struct {
+	// Skew is a probability of rate with time moved back by up to SKEW_MAX, the rate arrives late and out of order
+	Skew    map[string]float64       `envconfig:"SKEW"`
+	SkewMax map[string]time.Duration `envconfig:"SKEW_MAX"`
+	// Duplicate is a probability of rate put twice
+	Duplicate map[string]float64 `envconfig:"DUPLICATE"`
+	// Gap is a probability of dropped rate
+	Gap map[string]float64 `envconfig:"GAP"`
+}
+
+// HTTPFault configures faults of HTTP API per route, route is a path pattern of path.Match, e.g. "/rates/*:0.1".
+// Request gets at most one of ERROR, TRUNCATE, MALFORMED and RESET, latency is added independently.
+type HTTPFault struct {
+	Latency map[string]time.Duration `envconfig:"LATENCY"`
+	// LatencyProbability is a probability of latency, 1 by default
+	LatencyProbability map[string]float64 `envconfig:"LATENCY_PROBABILITY"`
+	// Error is a probability of response with ERROR_CODE, 503 by default
+	Error     map[string]float64 `envconfig:"ERROR"`
+	ErrorCode map[string]int32   `envconfig:"ERROR_CODE"`
+	// Truncate is a probability of body cut in half
+	Truncate map[string]float64 `envconfig:"TRUNCATE"`
+	// Malformed is a probability of invalid JSON body
+	Malformed map[string]float64 `envconfig:"MALFORMED"`
+	// Reset is a probability of connection reset without response
+	Reset map[string]float64 `envconfig:"RESET"`
+}
+
+// Instrument configures instrument registry. CURRENCY_PAIRS are always in registry with precision of SCALE.
+type Instrument struct {
+	// Pairs are instruments in addition to CURRENCY_PAIRS, they can be generated after POST /pairs
+	Pairs []string `envconfig:"PAIRS"`
+	// PipSize replaces default pip size of instrument, e.g. "USDRUB:0.01"
+	PipSize map[string]string `envconfig:"PIP_SIZE"`
+	// Calendar is a name of trading calendar of instrument, e.g. "EURUSD:24x7"
+	Calendar map[string]string `envconfig:"CALENDAR"`
+	// Disabled instruments can't be generated
+	Disabled []string `envconfig:"DISABLED"`
+}
+
+// Calendar configures trading calendars of INSTRUMENT_CALENDAR per calendar name, e.g. "MOEX:7h".
+// Built-in calendars "24x7" and "FX" can be changed, other calendars must have DAYS.
+type Calendar struct {
+	// Days are trading days separated by spaces, e.g. "MOEX:MON TUE WED THU FRI"
+	Days map[string]string `envconfig:"DAYS"`
+	// Rollover is start of trading day relative to its midnight UTC, e.g. "FX:-2h"
+	Rollover map[string]time.Duration `envconfig:"ROLLOVER"`
+	// Holidays are closed trading days separated by spaces, e.g. "FX:2022-12-26 2023-01-02"
+	Holidays map[string]string `envconfig:"HOLIDAYS"`
 }
 
 func Init() (*Config, error) {
@@ -35,24 +247,584 @@ func Init() (*Config, error) {
 		return nil, err
 	}
 
+	if port, err := strconv.Atoi(cfg.GrpcPort); err != nil || port < 1 || port > 65535 {
+		return nil, ErrGrpcPort
+	}
+
 	if cfg.CacheSize < 0 {
 		return nil, ErrMinimalCacheSize
 	}
 
-	//TODO: dont return error, continue execution with period = 1s
-	if cfg.Period < time.Second {
+	if cfg.CacheWindow < 0 {
+		return nil, ErrCacheWindow
+	}
+
+	if cfg.CacheRing && cfg.CacheWindow != 0 {
+		return nil, ErrCacheRing
+	}
+
+	if cfg.Period < MinimalPeriod {
 		return nil, ErrMinimalPeriod
 	}
 
+	for _, scale := range cfg.Scale {
+		if scale < 0 || scale > decimal.MaxScale {
+			return nil, ErrScale
+		}
+	}
+
+	// replay without loop ends at its last tick
+	endless := !cfg.Clock.Epoch.IsZero()
+	if cfg.Pattern == "REPLAY" {
+		endless = cfg.Replay.Loop
+	}
+	if endless && cfg.Clock.Scale == 0 && cfg.Clock.Duration == 0 {
+		return nil, ErrClockDuration
+	}
+
+	if cfg.Pattern == "REPLAY" {
+		r, err := newReplay(cfg)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Replay.Recording = r
+	}
+
 	return cfg, nil
 }
 
+func newReplay(cfg *Config) (*internal.Replay, error) {
+	if cfg.Replay.File == "" {
+		return nil, ErrReplayFile
+	}
+	ticks, err := internal.ReadTicksFile(cfg.Replay.File)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := cfg.Clock.Epoch
+	if origin.IsZero() && cfg.Replay.Shift {
+		origin = time.Now()
+	}
+	r, err := internal.NewReplay(ticks, func(pair string) int32 { return cfg.Scale[pair] }, origin, cfg.Replay.Loop)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Replay.File, err)
+	}
+
+	replayed := map[string]bool{}
+	for _, p := range r.Pairs() {
+		replayed[p] = true
+	}
+	for _, p := range cfg.CurrencyPairs {
+		if !replayed[p] {
+			return nil, fmt.Errorf("%s: %w", p, ErrReplayPair)
+		}
+	}
+
+	return r, nil
+}
+
+// GetInstruments returns instrument registry of CURRENCY_PAIRS and INSTRUMENT_PAIRS
+func GetInstruments(cfg *Config) (*internal.InstrumentRegistry, error) {
+	disabled := map[string]bool{}
+	for _, p := range cfg.Instrument.Disabled {
+		disabled[p] = true
+	}
+
+	seen := map[string]bool{}
+	instruments := make([]v1.Instrument, 0, len(cfg.CurrencyPairs)+len(cfg.Instrument.Pairs))
+	for _, p := range append(append([]string{}, cfg.CurrencyPairs...), cfg.Instrument.Pairs...) {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		in := internal.NewInstrument(p, cfg.Scale[p])
+		if pip, ok := cfg.Instrument.PipSize[p]; ok {
+			in.PipSize = pip
+		}
+		if calendar, ok := cfg.Instrument.Calendar[p]; ok {
+			in.Calendar = calendar
+		}
+		in.Enabled = !disabled[p]
+		instruments = append(instruments, in)
+	}
+
+	calendars, err := GetCalendars(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := internal.NewInstrumentRegistry(instruments, calendars...)
+	if err != nil {
+		return nil, err
+	}
+
+	// generated currency pairs must be enabled
+	for _, p := range cfg.CurrencyPairs {
+		if err = r.Check(p); err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+	}
+
+	return r, nil
+}
+
+// GetCalendars returns trading calendars of CALENDAR in alphabetical order of names
+func GetCalendars(cfg *Config) ([]*calendar.Calendar, error) {
+	c := cfg.Calendar
+	names := map[string]struct{}{}
+	for _, m := range []map[string]string{c.Days, c.Holidays} {
+		for name := range m {
+			names[name] = struct{}{}
+		}
+	}
+	for name := range c.Rollover {
+		names[name] = struct{}{}
+	}
+
+	out := make([]*calendar.Calendar, 0, len(names))
+	for name := range names {
+		var (
+			days     []string
+			rollover time.Duration
+		)
+		if builtin, err := calendar.Builtin(name, nil); err == nil {
+			days, rollover = builtin.Days(), builtin.Rollover()
+		}
+		if d, ok := c.Days[name]; ok {
+			days = strings.Fields(d)
+		}
+		if r, ok := c.Rollover[name]; ok {
+			rollover = r
+		}
+
+		cal, err := calendar.New(name, days, rollover, strings.Fields(c.Holidays[name]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		out = append(out, cal)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+
+	return out, nil
+}
+
+// GetScenario returns scenario of SCENARIO_FILE, nil if file isn't set
+func GetScenario(cfg *Config) (*v1.ScenarioSet, error) {
+	if cfg.ScenarioFile == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(cfg.ScenarioFile)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := internal.ParseScenario(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.ScenarioFile, err)
+	}
+	return &set, nil
+}
+
+// GetHTTPFaultRules returns rules of HTTP_FAULT in alphabetical order of routes
+func GetHTTPFaultRules(cfg *Config) []v1.HTTPFaultRule {
+	f := cfg.HTTPFault
+
+	routes := map[string]bool{}
+	for _, m := range []map[string]float64{f.LatencyProbability, f.Error, f.Truncate, f.Malformed, f.Reset} {
+		for r := range m {
+			routes[r] = true
+		}
+	}
+	for r := range f.Latency {
+		routes[r] = true
+	}
+	for r := range f.ErrorCode {
+		routes[r] = true
+	}
+
+	rules := make([]v1.HTTPFaultRule, 0, len(routes))
+	for route := range routes {
+		rule := v1.HTTPFaultRule{Route: route}
+		if v, ok := f.Latency[route]; ok {
+			latency := v.String()
+			rule.Latency = &latency
+		}
+		if v, ok := f.ErrorCode[route]; ok {
+			rule.ErrorCode = &v
+		}
+		for _, p := range []struct {
+			m   map[string]float64
+			out **float64
+		}{
+			{f.LatencyProbability, &rule.LatencyProbability},
+			{f.Error, &rule.ErrorProbability},
+			{f.Truncate, &rule.TruncateProbability},
+			{f.Malformed, &rule.MalformedProbability},
+			{f.Reset, &rule.ResetProbability},
+		} {
+			if v, ok := p.m[route]; ok {
+				*p.out = &v
+			}
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Route < rules[j].Route })
+
+	return rules
+}
+
+// GetCacheFunc returns caches of the last CACHE_SIZE rates, or caches of rates within CACHE_WINDOW before now of clock if it is set.
+// Zero CACHE_SIZE doesn't limit number of rates within window.
+func GetCacheFunc(cfg *Config, clock internal.Clock) internal.CacheFunc {
+	if cfg.CacheWindow != 0 {
+		return internal.NewWindowCacheFunc(cfg.CacheWindow, uint64(cfg.CacheSize), clock)
+	}
+	if cfg.CacheRing {
+		return internal.NewRingCacheFunc(uint64(cfg.CacheSize))
+	}
+	return internal.NewLimitedCacheFunc(uint64(cfg.CacheSize))
+}
+
+// GetClock returns clock of CLOCK, clock of REPLAY pattern starts at the first tick of recording
+func GetClock(cfg *Config) internal.Clock {
+	if r := cfg.Replay.Recording; r != nil {
+		return internal.NewVirtualClock(r.Start(), cfg.Clock.Scale, cfg.Clock.Duration)
+	}
+	if cfg.Clock.Epoch.IsZero() {
+		return internal.RealClock{}
+	}
+	return internal.NewVirtualClock(cfg.Clock.Epoch, cfg.Clock.Scale, cfg.Clock.Duration)
+}
+
+// GetScheduleFunc returns schedules of currency pairs. Under SEED pattern schedules are reproducible.
+// Under REPLAY pattern rates follow ticks of recording.
+func GetScheduleFunc(cfg *Config) (internal.ScheduleFunc, error) {
+	if cfg.Pattern == "REPLAY" {
+		if cfg.Replay.Recording == nil {
+			return nil, ErrReplayFile
+		}
+		return cfg.Replay.Recording.Schedule, nil
+	}
+
+	seed := func(string) int64 { return time.Now().UnixNano() }
+	if cfg.Pattern == "SEED" {
+		// schedule doesn't share random numbers with price model of the pair
+		seed = func(pair string) int64 { return internal.PairSeed(cfg.Seed, pair+"/SCHEDULE") }
+	}
+
+	configured := map[string]struct{}{}
+	for _, m := range []map[string]time.Duration{cfg.Schedule.Period, cfg.Schedule.Jitter} {
+		for pair := range m {
+			configured[pair] = struct{}{}
+		}
+	}
+	for pair := range cfg.Schedule.Kind {
+		configured[pair] = struct{}{}
+	}
+	for pair := range cfg.Schedule.Rate {
+		configured[pair] = struct{}{}
+	}
+
+	for pair := range configured {
+		if _, err := newSchedule(cfg, pair, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(pair string) internal.Schedule {
+		// schedules are validated above
+		s, _ := newSchedule(cfg, pair, rand.New(rand.NewSource(seed(pair))))
+		return s
+	}, nil
+}
+
+func newSchedule(cfg *Config, pair string, r *rand.Rand) (internal.Schedule, error) {
+	kind, ok := cfg.Schedule.Kind[pair]
+	if !ok {
+		kind = ScheduleFixed
+	}
+	period, ok := cfg.Schedule.Period[pair]
+	if !ok {
+		period = cfg.Period
+	}
+
+	switch kind {
+	case ScheduleFixed, ScheduleJitter:
+		if period < MinimalPeriod {
+			return nil, fmt.Errorf("%s: %w", pair, ErrMinimalPeriod)
+		}
+		jitter := cfg.Schedule.Jitter[pair]
+		if kind == ScheduleFixed || jitter == 0 {
+			return internal.FixedSchedule(period), nil
+		}
+		if jitter < 0 || jitter > period {
+			return nil, fmt.Errorf("%s: %w", pair, ErrScheduleJitter)
+		}
+		return internal.NewJitterSchedule(period, jitter, r), nil
+	case SchedulePoisson:
+		rate := cfg.Schedule.Rate[pair]
+		if rate <= 0 || rate > float64(time.Second/MinimalPeriod) {
+			return nil, fmt.Errorf("%s: %w", pair, ErrScheduleRate)
+		}
+		return internal.NewPoissonSchedule(rate, r), nil
+	}
+	return nil, fmt.Errorf("%s: %w: %s", pair, ErrUnknownSchedule, kind)
+}
+
+// GetQuoteFunc returns quote models of currency pairs. Under SEED pattern quotes are reproducible.
+func GetQuoteFunc(cfg *Config) (internal.QuoteFunc, error) {
+	seed := func(string) int64 { return time.Now().UnixNano() }
+	if cfg.Pattern == "SEED" {
+		// quote doesn't share random numbers with price model of the pair
+		seed = func(pair string) int64 { return internal.PairSeed(cfg.Seed, pair+"/QUOTE") }
+	}
+
+	q := cfg.Quote
+	for _, v := range q.SpreadMin {
+		if v < 0 {
+			return nil, ErrQuote
+		}
+	}
+	for _, m := range []map[string]float64{q.Spread, q.SpreadWidening} {
+		for _, v := range m {
+			if v < 0 {
+				return nil, ErrQuote
+			}
+		}
+	}
+	for _, v := range q.Volume {
+		if v < 0 {
+			return nil, ErrQuote
+		}
+	}
+
+	return func(pair string) internal.QuoteModel {
+		_, min := q.SpreadMin[pair]
+		_, spread := q.Spread[pair]
+		_, volume := q.Volume[pair]
+		if !min && !spread && !volume {
+			return internal.MidQuote{}
+		}
+		return internal.NewSyntheticQuote(q.SpreadMin[pair], q.Spread[pair], q.SpreadWidening[pair], q.Volume[pair], rand.New(rand.NewSource(seed(pair))))
+	}, nil
+}
+
+// GetFaultFunc returns fault models of FAULT, currency pairs without probabilities get no faults
+func GetFaultFunc(cfg *Config) (internal.FaultFunc, error) {
+	seed := func(string) int64 { return time.Now().UnixNano() }
+	if cfg.Pattern == "SEED" {
+		// faults don't share random numbers with price and quote models of the pair
+		seed = func(pair string) int64 { return internal.PairSeed(cfg.Seed, pair+"/FAULT") }
+	}
+
+	f := cfg.Fault
+	pairs := map[string]bool{}
+	for _, m := range []map[string]float64{f.Skew, f.Duplicate, f.Gap} {
+		for p, v := range m {
+			if v < 0 {
+				return nil, fmt.Errorf("%s: %w", p, ErrFault)
+			}
+			pairs[p] = true
+		}
+	}
+	for p := range pairs {
+		if f.Skew[p]+f.Duplicate[p]+f.Gap[p] > 1 {
+			return nil, fmt.Errorf("%s: %w", p, ErrFault)
+		}
+		if f.Skew[p] > 0 && f.SkewMax[p] < time.Microsecond {
+			return nil, fmt.Errorf("%s: %w", p, ErrFaultSkew)
+		}
+	}
+
+	return func(pair string) internal.FaultModel {
+		if !pairs[pair] {
+			return internal.NoFaults{}
+		}
+		return internal.NewRandomFaults(f.Skew[pair], f.SkewMax[pair], f.Duplicate[pair], f.Gap[pair], rand.New(rand.NewSource(seed(pair))))
+	}, nil
+}
+
+// GetGeneratorFunc returns prices of PATTERN with models and correlations. Prices of REPLAY pattern are recorded ticks as they are.
 func GetGeneratorFunc(cfg *Config) (internal.GeneratorFunc, error) {
+	var (
+		f    internal.GeneratorFunc
+		seed func(pair string) int64
+	)
 	switch cfg.Pattern {
 	case "TIME":
-		return internal.ExchangeRateFromTime, nil
+		f = internal.ExchangeRateFromTime
+		seed = func(string) int64 { return time.Now().UnixNano() }
 	case "SEED":
-		return internal.NewExchangeRateFromSeed(cfg.Seed), nil
+		f = internal.NewExchangeRateFromSeed(cfg.Seed)
+		seed = func(pair string) int64 { return internal.PairSeed(cfg.Seed, pair) }
+	case "REPLAY":
+		if cfg.Replay.Recording == nil {
+			return nil, ErrReplayFile
+		}
+		return cfg.Replay.Recording.Price, nil
+	default:
+		return nil, fmt.Errorf("unknown pattern: %s", cfg.Pattern)
+	}
+
+	market, legs, err := newCorrelatedMarket(cfg, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Model.Kind) != 0 {
+		models := make(map[string]internal.PriceModel, len(cfg.Model.Kind))
+		for pair, kind := range cfg.Model.Kind {
+			if _, ok := legs[pair]; ok {
+				continue
+			}
+			m, err := newPriceModel(cfg.Model, pair, kind, rand.New(rand.NewSource(seed(pair))))
+			if err != nil {
+				return nil, err
+			}
+			models[pair] = m
+		}
+		f = internal.NewModelGeneratorFunc(models, f)
+	}
+
+	if market != nil {
+		f = market.GeneratorFunc(f)
+	}
+
+	return f, nil
+}
+
+// GetBackfillFunc returns models of backfill: price, schedule and quote models of SEED pattern.
+// Each call of result creates fresh models, so backfill of the same range always gets the same rates.
+// TIME pattern is backfilled by the same models of SEED, since its random prices can't be repeated page by page.
+// REPLAY has no prices before the first quote of recording, so nil is returned and backfill is off.
+func GetBackfillFunc(cfg *Config) (internal.BackfillFunc, error) {
+	if cfg.Pattern == "REPLAY" {
+		return nil, nil
+	}
+
+	seeded := *cfg
+	seeded.Pattern = "SEED"
+
+	if _, err := GetGeneratorFunc(&seeded); err != nil {
+		return nil, err
+	}
+	if _, err := GetScheduleFunc(&seeded); err != nil {
+		return nil, err
+	}
+	if _, err := GetQuoteFunc(&seeded); err != nil {
+		return nil, err
+	}
+
+	return func() (internal.GeneratorFunc, internal.ScheduleFunc, internal.QuoteFunc) {
+		// models are validated above
+		f, _ := GetGeneratorFunc(&seeded)
+		schedule, _ := GetScheduleFunc(&seeded)
+		quote, _ := GetQuoteFunc(&seeded)
+		return f, schedule, quote
+	}, nil
+}
+
+// newCorrelatedMarket returns nil market when correlation isn't configured
+func newCorrelatedMarket(cfg *Config, seed func(pair string) int64) (*internal.CorrelatedMarket, map[string]struct{}, error) {
+	if len(cfg.Correlation.Matrix) == 0 && len(cfg.Correlation.Cross) == 0 {
+		return nil, nil, nil
+	}
+
+	legs := map[string]struct{}{}
+	correlations := map[[2]string]float64{}
+	for key, v := range cfg.Correlation.Matrix {
+		a, b, ok := strings.Cut(key, "/")
+		if !ok || a == "" || b == "" {
+			return nil, nil, fmt.Errorf("%s: %w", key, ErrCorrelationPair)
+		}
+		legs[a], legs[b] = struct{}{}, struct{}{}
+		correlations[[2]string{a, b}], correlations[[2]string{b, a}] = v, v
+	}
+
+	crosses := make(map[string]internal.CrossPair, len(cfg.Correlation.Cross))
+	for pair, expr := range cfg.Correlation.Cross {
+		c := internal.CrossPair{Start: cfg.Model.Start[pair], Spread: cfg.Correlation.Spread[pair]}
+		var ok bool
+		if c.LegA, c.LegB, ok = strings.Cut(expr, "*"); !ok {
+			c.LegA, c.LegB, ok = strings.Cut(expr, "/")
+			c.Inverse = true
+		}
+		if !ok || c.LegA == "" || c.LegB == "" {
+			return nil, nil, fmt.Errorf("%s: %w", pair, ErrCrossLegs)
+		}
+		if c.Start <= 0 {
+			return nil, nil, fmt.Errorf("%s: %w", pair, ErrModelStart)
+		}
+		legs[c.LegA], legs[c.LegB] = struct{}{}, struct{}{}
+		crosses[pair] = c
+	}
+
+	// order of legs is fixed: shocks of every leg are reproducible
+	names := make([]string, 0, len(legs))
+	for pair := range legs {
+		names = append(names, pair)
+	}
+	sort.Strings(names)
+
+	marketLegs := make([]internal.Leg, len(names))
+	matrix := make([][]float64, len(names))
+	for i, pair := range names {
+		kind, ok := cfg.Model.Kind[pair]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: %w", pair, ErrCorrelationModel)
+		}
+		// model is validated before it is created by market
+		if _, err := newPriceModel(cfg.Model, pair, kind, nil); err != nil {
+			return nil, nil, err
+		}
+
+		pair := pair
+		marketLegs[i] = internal.Leg{
+			Pair:  pair,
+			Start: cfg.Model.Start[pair],
+			NewModel: func(normal internal.NormalSource) internal.PriceModel {
+				m, _ := newPriceModel(cfg.Model, pair, kind, normal)
+				return m
+			},
+		}
+
+		matrix[i] = make([]float64, len(names))
+		for j, other := range names {
+			if i == j {
+				matrix[i][j] = 1
+				continue
+			}
+			matrix[i][j] = correlations[[2]string{pair, other}]
+		}
+	}
+
+	market, err := internal.NewCorrelatedMarket(marketLegs, matrix, crosses, seed("/CORRELATION"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return market, legs, nil
+}
+
+func newPriceModel(cfg Model, pair string, kind string, r internal.NormalSource) (internal.PriceModel, error) {
+	start := cfg.Start[pair]
+	if start <= 0 {
+		return nil, fmt.Errorf("%s: %w", pair, ErrModelStart)
+	}
+
+	switch kind {
+	case ModelRandomWalk:
+		return internal.NewRandomWalk(start, cfg.Volatility[pair], r), nil
+	case ModelGBM:
+		return internal.NewGeometricBrownianMotion(start, cfg.Drift[pair], cfg.Volatility[pair], r), nil
+	case ModelOrnsteinUhlenbeck:
+		mean, ok := cfg.Mean[pair]
+		if !ok {
+			mean = start
+		}
+		return internal.NewOrnsteinUhlenbeck(start, mean, cfg.Reversion[pair], cfg.Volatility[pair], r), nil
 	}
-	return nil, fmt.Errorf("unknown pattern: %s", cfg.Pattern)
+	return nil, fmt.Errorf("%s: %w: %s", pair, ErrUnknownModel, kind)
 }
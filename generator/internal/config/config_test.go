@@ -1,9 +1,21 @@
 package config
 
 import (
+	"context"
+	"encoding/json"
+	"generator/internal"
+	"generator/internal/api/http/v1"
+	"generator/pkg/cache"
 	"github.com/google/go-cmp/cmp"
+	"github.com/mazitovt/logger"
 	"github.com/stretchr/testify/require"
 	"gotest.tools/v3/env"
+	"mtsbank/pkg/calendar"
+	"mtsbank/pkg/decimal"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -21,6 +33,7 @@ func TestConfig_Init(t *testing.T) {
 				"RATE_GENERATOR_LOG_LEVEL":      "info",
 				"RATE_GENERATOR_HOST":           "localhost",
 				"RATE_GENERATOR_PORT":           "8080",
+				"RATE_GENERATOR_GRPC_PORT":      "9090",
 				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD,USDRUB,USDJPY",
 				"RATE_GENERATOR_PATTERN":        "TIME",
 				"RATE_GENERATOR_PERIOD":         "1s",
@@ -30,6 +43,7 @@ func TestConfig_Init(t *testing.T) {
 				LogLevel:      "info",
 				Host:          "localhost",
 				Port:          "8080",
+				GrpcPort:      "9090",
 				CurrencyPairs: []string{"EURUSD", "USDRUB", "USDJPY"},
 				Pattern:       "TIME",
 				Period:        1 * time.Second,
@@ -46,6 +60,7 @@ func TestConfig_Init(t *testing.T) {
 				"RATE_GENERATOR_CACHE_SIZE":     "8",
 			},
 			er: Config{
+				GrpcPort:      "9090",
 				CurrencyPairs: []string{"EURUSD", "USDRUB", "USDJPY"},
 				Pattern:       "SEED",
 				Seed:          123,
@@ -62,12 +77,80 @@ func TestConfig_Init(t *testing.T) {
 				"RATE_GENERATOR_CACHE_SIZE":     "8",
 			},
 			er: Config{
+				GrpcPort:      "9090",
 				CurrencyPairs: []string{"EURUSD", "USDRUB", "USDJPY"},
 				Pattern:       "SEED",
 				Period:        3 * time.Second,
 				CacheSize:     8,
 			},
 		},
+		{
+			name: "config with models",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS":   "EURUSD,USDRUB,USDJPY",
+				"RATE_GENERATOR_PATTERN":          "SEED",
+				"RATE_GENERATOR_SEED":             "123",
+				"RATE_GENERATOR_PERIOD":           "1s",
+				"RATE_GENERATOR_CACHE_SIZE":       "3",
+				"RATE_GENERATOR_MODEL_KIND":       "EURUSD:GBM,USDJPY:OU,USDRUB:RANDOM_WALK",
+				"RATE_GENERATOR_MODEL_START":      "EURUSD:100000,USDJPY:13500,USDRUB:6000",
+				"RATE_GENERATOR_MODEL_DRIFT":      "EURUSD:0.0001",
+				"RATE_GENERATOR_MODEL_VOLATILITY": "EURUSD:0.001,USDJPY:10,USDRUB:5",
+				"RATE_GENERATOR_MODEL_REVERSION":  "USDJPY:0.1",
+				"RATE_GENERATOR_MODEL_MEAN":       "USDJPY:13600",
+			},
+			er: Config{
+				GrpcPort:      "9090",
+				CurrencyPairs: []string{"EURUSD", "USDRUB", "USDJPY"},
+				Pattern:       "SEED",
+				Seed:          123,
+				Period:        1 * time.Second,
+				CacheSize:     3,
+				Model: Model{
+					Kind:       map[string]string{"EURUSD": "GBM", "USDJPY": "OU", "USDRUB": "RANDOM_WALK"},
+					Start:      map[string]int64{"EURUSD": 100000, "USDJPY": 13500, "USDRUB": 6000},
+					Drift:      map[string]float64{"EURUSD": 0.0001},
+					Volatility: map[string]float64{"EURUSD": 0.001, "USDJPY": 10, "USDRUB": 5},
+					Reversion:  map[string]float64{"USDJPY": 0.1},
+					Mean:       map[string]int64{"USDJPY": 13600},
+				},
+			},
+		},
+		{
+			name: "config with virtual clock",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+				"RATE_GENERATOR_PATTERN":        "SEED",
+				"RATE_GENERATOR_PERIOD":         "1s",
+				"RATE_GENERATOR_CACHE_SIZE":     "3",
+				"RATE_GENERATOR_CLOCK_EPOCH":    "2022-08-01T00:00:00Z",
+				"RATE_GENERATOR_CLOCK_SCALE":    "3600",
+				"RATE_GENERATOR_CLOCK_DURATION": "24h",
+			},
+			er: Config{
+				GrpcPort:      "9090",
+				CurrencyPairs: []string{"EURUSD"},
+				Pattern:       "SEED",
+				Period:        1 * time.Second,
+				CacheSize:     3,
+				Clock: Clock{
+					Epoch:    time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+					Scale:    3600,
+					Duration: 24 * time.Hour,
+				},
+			},
+		},
+		{
+			name: "config with endless virtual clock without waiting",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+				"RATE_GENERATOR_PATTERN":        "SEED",
+				"RATE_GENERATOR_PERIOD":         "1s",
+				"RATE_GENERATOR_CACHE_SIZE":     "3",
+				"RATE_GENERATOR_CLOCK_EPOCH":    "2022-08-01T00:00:00Z",
+			},
+			err: ErrClockDuration,
+		},
 		{
 			name: "config negative cached size",
 			inputEnv: map[string]string{
@@ -78,16 +161,166 @@ func TestConfig_Init(t *testing.T) {
 			},
 			err: ErrMinimalCacheSize,
 		},
+		{
+			name: "config negative cache window",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD,USDRUB,USDJPY",
+				"RATE_GENERATOR_PATTERN":        "TIME",
+				"RATE_GENERATOR_PERIOD":         "3s",
+				"RATE_GENERATOR_CACHE_WINDOW":   "-10m",
+			},
+			err: ErrCacheWindow,
+		},
+		{
+			name: "config with invalid grpc port",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_GRPC_PORT":      "grpc",
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+				"RATE_GENERATOR_PATTERN":        "TIME",
+				"RATE_GENERATOR_PERIOD":         "1s",
+			},
+			err: ErrGrpcPort,
+		},
+		{
+			name: "config with empty grpc port",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_GRPC_PORT":      "",
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+				"RATE_GENERATOR_PATTERN":        "TIME",
+				"RATE_GENERATOR_PERIOD":         "1s",
+			},
+			err: ErrGrpcPort,
+		},
+		{
+			name: "config ring cache with window",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD,USDRUB,USDJPY",
+				"RATE_GENERATOR_PATTERN":        "TIME",
+				"RATE_GENERATOR_PERIOD":         "3s",
+				"RATE_GENERATOR_CACHE_WINDOW":   "10m",
+				"RATE_GENERATOR_CACHE_RING":     "true",
+			},
+			err: ErrCacheRing,
+		},
 		{
 			name: "100 milisecond period",
 			inputEnv: map[string]string{
 				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD,USDRUB,USDJPY",
 				"RATE_GENERATOR_PATTERN":        "TIME",
-				"RATE_GENERATOR_PERIOD":         "0.5s",
+				"RATE_GENERATOR_PERIOD":         "100ms",
+				"RATE_GENERATOR_CACHE_SIZE":     "5",
+			},
+			er: Config{
+				GrpcPort:      "9090",
+				CurrencyPairs: []string{"EURUSD", "USDRUB", "USDJPY"},
+				Pattern:       "TIME",
+				Period:        100 * time.Millisecond,
+				CacheSize:     5,
+			},
+		},
+		{
+			name: "100 microsecond period",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD,USDRUB,USDJPY",
+				"RATE_GENERATOR_PATTERN":        "TIME",
+				"RATE_GENERATOR_PERIOD":         "100us",
 				"RATE_GENERATOR_CACHE_SIZE":     "5",
 			},
 			err: ErrMinimalPeriod,
 		},
+		{
+			name: "config with schedules",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS":  "EURUSD,USDRUB,USDJPY",
+				"RATE_GENERATOR_PATTERN":         "SEED",
+				"RATE_GENERATOR_PERIOD":          "1s",
+				"RATE_GENERATOR_CACHE_SIZE":      "5",
+				"RATE_GENERATOR_SCHEDULE_KIND":   "EURUSD:POISSON,USDJPY:JITTER",
+				"RATE_GENERATOR_SCHEDULE_PERIOD": "USDJPY:200ms,USDRUB:5s",
+				"RATE_GENERATOR_SCHEDULE_JITTER": "USDJPY:50ms",
+				"RATE_GENERATOR_SCHEDULE_RATE":   "EURUSD:20",
+			},
+			er: Config{
+				GrpcPort:      "9090",
+				CurrencyPairs: []string{"EURUSD", "USDRUB", "USDJPY"},
+				Pattern:       "SEED",
+				Period:        time.Second,
+				CacheSize:     5,
+				Schedule: Schedule{
+					Kind:   map[string]string{"EURUSD": SchedulePoisson, "USDJPY": ScheduleJitter},
+					Period: map[string]time.Duration{"USDJPY": 200 * time.Millisecond, "USDRUB": 5 * time.Second},
+					Jitter: map[string]time.Duration{"USDJPY": 50 * time.Millisecond},
+					Rate:   map[string]float64{"EURUSD": 20},
+				},
+			},
+		},
+		{
+			name: "config with scale",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD,USDJPY",
+				"RATE_GENERATOR_PATTERN":        "TIME",
+				"RATE_GENERATOR_PERIOD":         "1s",
+				"RATE_GENERATOR_CACHE_SIZE":     "5",
+				"RATE_GENERATOR_SCALE":          "EURUSD:5,USDJPY:3",
+			},
+			er: Config{
+				GrpcPort:      "9090",
+				CurrencyPairs: []string{"EURUSD", "USDJPY"},
+				Pattern:       "TIME",
+				Period:        time.Second,
+				CacheSize:     5,
+				Scale:         map[string]int32{"EURUSD": 5, "USDJPY": 3},
+			},
+		},
+		{
+			name: "config with too big scale",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+				"RATE_GENERATOR_PATTERN":        "TIME",
+				"RATE_GENERATOR_PERIOD":         "1s",
+				"RATE_GENERATOR_CACHE_SIZE":     "5",
+				"RATE_GENERATOR_SCALE":          "EURUSD:19",
+			},
+			err: ErrScale,
+		},
+		{
+			name: "config with admin tokens",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+				"RATE_GENERATOR_PATTERN":        "TIME",
+				"RATE_GENERATOR_PERIOD":         "1s",
+				"RATE_GENERATOR_CACHE_SIZE":     "5",
+				"RATE_GENERATOR_ADMIN_TOKENS":   "oncall:secret,qa:qa-secret",
+			},
+			er: Config{
+				GrpcPort:      "9090",
+				CurrencyPairs: []string{"EURUSD"},
+				Pattern:       "TIME",
+				Period:        time.Second,
+				CacheSize:     5,
+				Admin:         Admin{Tokens: map[string]string{"oncall": "secret", "qa": "qa-secret"}},
+			},
+		},
+		{
+			name: "replay without file",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+				"RATE_GENERATOR_PATTERN":        "REPLAY",
+				"RATE_GENERATOR_PERIOD":         "1s",
+			},
+			err: ErrReplayFile,
+		},
+		{
+			name: "replay loop without clock duration",
+			inputEnv: map[string]string{
+				"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+				"RATE_GENERATOR_PATTERN":        "REPLAY",
+				"RATE_GENERATOR_PERIOD":         "1s",
+				"RATE_GENERATOR_REPLAY_FILE":    "ticks.csv",
+				"RATE_GENERATOR_REPLAY_LOOP":    "true",
+			},
+			err: ErrClockDuration,
+		},
 	}
 
 	for _, tc := range tests {
@@ -105,3 +338,610 @@ func TestConfig_Init(t *testing.T) {
 		})
 	}
 }
+
+func TestGetGeneratorFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  error
+	}{
+		{
+			name: "time without models",
+			cfg:  Config{Pattern: "TIME"},
+		},
+		{
+			name: "seed with models",
+			cfg: Config{
+				Pattern: "SEED",
+				Seed:    123,
+				Model: Model{
+					Kind:  map[string]string{"EURUSD": ModelGBM, "USDJPY": ModelOrnsteinUhlenbeck, "USDRUB": ModelRandomWalk},
+					Start: map[string]int64{"EURUSD": 100000, "USDJPY": 13500, "USDRUB": 6000},
+				},
+			},
+		},
+		{
+			name: "model without start price",
+			cfg: Config{
+				Pattern: "SEED",
+				Model: Model{
+					Kind: map[string]string{"EURUSD": ModelGBM},
+				},
+			},
+			err: ErrModelStart,
+		},
+		{
+			name: "unknown model",
+			cfg: Config{
+				Pattern: "SEED",
+				Model: Model{
+					Kind:  map[string]string{"EURUSD": "LINEAR"},
+					Start: map[string]int64{"EURUSD": 100000},
+				},
+			},
+			err: ErrUnknownModel,
+		},
+		{
+			name: "seed with correlated pairs",
+			cfg: Config{
+				Pattern: "SEED",
+				Seed:    123,
+				Model: Model{
+					Kind:  map[string]string{"EURUSD": ModelGBM, "USDJPY": ModelGBM, "USDRUB": ModelRandomWalk},
+					Start: map[string]int64{"EURUSD": 100000, "USDJPY": 13500, "USDRUB": 6000, "EURJPY": 13500},
+				},
+				Correlation: Correlation{
+					Matrix: map[string]float64{"EURUSD/USDJPY": -0.3},
+					Cross:  map[string]string{"EURJPY": "EURUSD*USDJPY"},
+					Spread: map[string]float64{"EURJPY": 0.0002},
+				},
+			},
+		},
+		{
+			name: "invalid correlation key",
+			cfg: Config{
+				Pattern:     "SEED",
+				Correlation: Correlation{Matrix: map[string]float64{"EURUSD": 0.5}},
+			},
+			err: ErrCorrelationPair,
+		},
+		{
+			name: "invalid cross legs",
+			cfg: Config{
+				Pattern:     "SEED",
+				Model:       Model{Start: map[string]int64{"EURJPY": 13500}},
+				Correlation: Correlation{Cross: map[string]string{"EURJPY": "EURUSD+USDJPY"}},
+			},
+			err: ErrCrossLegs,
+		},
+		{
+			name: "cross without start price",
+			cfg: Config{
+				Pattern:     "SEED",
+				Correlation: Correlation{Cross: map[string]string{"EURJPY": "EURUSD*USDJPY"}},
+			},
+			err: ErrModelStart,
+		},
+		{
+			name: "correlated pair without model",
+			cfg: Config{
+				Pattern: "SEED",
+				Model: Model{
+					Kind:  map[string]string{"EURUSD": ModelGBM},
+					Start: map[string]int64{"EURUSD": 100000},
+				},
+				Correlation: Correlation{Matrix: map[string]float64{"EURUSD/USDJPY": 0.5}},
+			},
+			err: ErrCorrelationModel,
+		},
+		{
+			name: "invalid correlation",
+			cfg: Config{
+				Pattern: "SEED",
+				Model: Model{
+					Kind:  map[string]string{"EURUSD": ModelGBM, "USDJPY": ModelGBM},
+					Start: map[string]int64{"EURUSD": 100000, "USDJPY": 13500},
+				},
+				Correlation: Correlation{Matrix: map[string]float64{"EURUSD/USDJPY": 1.5}},
+			},
+			err: internal.ErrCorrelationMatrix,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := GetGeneratorFunc(&tc.cfg)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.Nil(t, err)
+			require.NotNil(t, f)
+		})
+	}
+}
+
+func TestGetScheduleFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		pair string
+		er   []time.Duration
+		err  error
+	}{
+		{
+			name: "default period",
+			cfg:  Config{Pattern: "SEED", Period: 2 * time.Second},
+			pair: "EURUSD",
+			er:   []time.Duration{2 * time.Second, 2 * time.Second, 2 * time.Second},
+		},
+		{
+			name: "fixed period of pair",
+			cfg: Config{
+				Pattern:  "SEED",
+				Period:   time.Second,
+				Schedule: Schedule{Period: map[string]time.Duration{"EURUSD": 10 * time.Millisecond}},
+			},
+			pair: "EURUSD",
+			er:   []time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond},
+		},
+		{
+			name: "jitter",
+			cfg: Config{
+				Pattern: "SEED",
+				Seed:    123,
+				Period:  time.Second,
+				Schedule: Schedule{
+					Kind:   map[string]string{"EURUSD": ScheduleJitter},
+					Jitter: map[string]time.Duration{"EURUSD": 100 * time.Millisecond},
+				},
+			},
+			pair: "EURUSD",
+		},
+		{
+			name: "poisson",
+			cfg: Config{
+				Pattern: "SEED",
+				Seed:    123,
+				Period:  time.Second,
+				Schedule: Schedule{
+					Kind: map[string]string{"EURUSD": SchedulePoisson},
+					Rate: map[string]float64{"EURUSD": 10},
+				},
+			},
+			pair: "EURUSD",
+		},
+		{
+			name: "period of pair is too small",
+			cfg: Config{
+				Pattern:  "SEED",
+				Period:   time.Second,
+				Schedule: Schedule{Period: map[string]time.Duration{"EURUSD": time.Microsecond}},
+			},
+			err: ErrMinimalPeriod,
+		},
+		{
+			name: "jitter greater than period",
+			cfg: Config{
+				Pattern: "SEED",
+				Period:  time.Second,
+				Schedule: Schedule{
+					Kind:   map[string]string{"EURUSD": ScheduleJitter},
+					Jitter: map[string]time.Duration{"EURUSD": 2 * time.Second},
+				},
+			},
+			err: ErrScheduleJitter,
+		},
+		{
+			name: "poisson without rate",
+			cfg: Config{
+				Pattern:  "SEED",
+				Period:   time.Second,
+				Schedule: Schedule{Kind: map[string]string{"EURUSD": SchedulePoisson}},
+			},
+			err: ErrScheduleRate,
+		},
+		{
+			name: "unknown schedule",
+			cfg: Config{
+				Pattern:  "SEED",
+				Period:   time.Second,
+				Schedule: Schedule{Kind: map[string]string{"EURUSD": "NORMAL"}},
+			},
+			err: ErrUnknownSchedule,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := GetScheduleFunc(&tc.cfg)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.Nil(t, err)
+
+			// schedules are reproducible under SEED
+			s1, s2 := f(tc.pair), f(tc.pair)
+			got := make([]time.Duration, 0, 3)
+			for i := 0; i < 3; i++ {
+				d := s1.Next()
+				require.Equal(t, d, s2.Next())
+				require.Greater(t, d, time.Duration(0))
+				got = append(got, d)
+			}
+			if tc.er != nil {
+				require.Equal(t, tc.er, got)
+			}
+		})
+	}
+}
+
+func TestGetQuoteFunc(t *testing.T) {
+	cfg := Config{
+		Pattern: "SEED",
+		Seed:    123,
+		Quote: Quote{
+			SpreadMin: map[string]int64{"EURUSD": 10},
+			Volume:    map[string]int64{"EURUSD": 1000},
+		},
+	}
+
+	f, err := GetQuoteFunc(&cfg)
+	require.Nil(t, err)
+	require.Equal(t, internal.MidQuote{}, f("USDJPY"))
+
+	// quotes are reproducible under SEED
+	q1, q2 := f("EURUSD"), f("EURUSD")
+	for i := 0; i < 5; i++ {
+		q := q1.Quote(100000)
+		require.Equal(t, q, q2.Quote(100000))
+		require.Equal(t, int64(10), q.Ask-q.Bid)
+	}
+
+	cfg.Quote.Spread = map[string]float64{"EURUSD": -0.1}
+	_, err = GetQuoteFunc(&cfg)
+	require.ErrorIs(t, err, ErrQuote)
+}
+
+func TestGetFaultFunc(t *testing.T) {
+	cfg := Config{
+		Pattern: "SEED",
+		Seed:    123,
+		Fault: Fault{
+			Skew:      map[string]float64{"EURUSD": 0.1},
+			SkewMax:   map[string]time.Duration{"EURUSD": time.Second},
+			Duplicate: map[string]float64{"EURUSD": 0.2},
+			Gap:       map[string]float64{"EURUSD": 0.3},
+		},
+	}
+
+	f, err := GetFaultFunc(&cfg)
+	require.Nil(t, err)
+	require.Equal(t, internal.NoFaults{}, f("USDJPY"))
+
+	// faults are reproducible under SEED
+	f1, f2 := f("EURUSD"), f("EURUSD")
+	rate := v1.ExchangeRate{Time: time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)}
+	for i := 0; i < 20; i++ {
+		out1, kind1 := f1.Inject(rate, nil)
+		out2, kind2 := f2.Inject(rate, nil)
+		require.Equal(t, kind1, kind2)
+		require.Equal(t, out1, out2)
+	}
+
+	tests := []struct {
+		name  string
+		fault Fault
+		err   error
+	}{
+		{
+			name:  "negative probability",
+			fault: Fault{Gap: map[string]float64{"EURUSD": -0.1}},
+			err:   ErrFault,
+		},
+		{
+			name:  "probabilities sum is greater than one",
+			fault: Fault{Gap: map[string]float64{"EURUSD": 0.6}, Duplicate: map[string]float64{"EURUSD": 0.6}},
+			err:   ErrFault,
+		},
+		{
+			name:  "skew without max",
+			fault: Fault{Skew: map[string]float64{"EURUSD": 0.1}},
+			err:   ErrFaultSkew,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := GetFaultFunc(&Config{Fault: tc.fault})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestGetBackfillFunc(t *testing.T) {
+	cfg := Config{
+		Pattern: "TIME",
+		Seed:    123,
+		Period:  time.Second,
+		Quote:   Quote{SpreadMin: map[string]int64{"EURUSD": 10}},
+	}
+
+	backfill, err := GetBackfillFunc(&cfg)
+	require.Nil(t, err)
+	require.Equal(t, "TIME", cfg.Pattern)
+
+	// models of backfill are reproducible whatever PATTERN is
+	f1, s1, q1 := backfill()
+	f2, s2, q2 := backfill()
+	schedule1, schedule2 := s1("EURUSD"), s2("EURUSD")
+	quote1, quote2 := q1("EURUSD"), q2("EURUSD")
+	for i := 0; i < 5; i++ {
+		require.Equal(t, f1("EURUSD"), f2("EURUSD"))
+		require.Equal(t, schedule1.Next(), schedule2.Next())
+		require.Equal(t, quote1.Quote(100000), quote2.Quote(100000))
+	}
+
+	cfg.Schedule.Kind = map[string]string{"EURUSD": "WEEKLY"}
+	_, err = GetBackfillFunc(&cfg)
+	require.ErrorIs(t, err, ErrUnknownSchedule)
+}
+
+// backfillTicks keeps backfilled rates
+type backfillTicks []v1.ExchangeRate
+
+func (b *backfillTicks) Write(_ string, rate v1.ExchangeRate) error {
+	*b = append(*b, rate)
+	return nil
+}
+
+func (b *backfillTicks) Close() error {
+	return nil
+}
+
+func TestGetBackfillFunc_Continuous(t *testing.T) {
+	start := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	instruments, err := internal.NewInstrumentRegistry([]v1.Instrument{internal.NewInstrument("EURUSD", 5)})
+	require.Nil(t, err)
+
+	for _, pattern := range []string{"SEED", "TIME"} {
+		t.Run(pattern, func(t *testing.T) {
+			cfg := Config{
+				Pattern: pattern,
+				Seed:    123,
+				Period:  time.Second,
+				Model: Model{
+					Kind:       map[string]string{"EURUSD": ModelGBM},
+					Start:      map[string]int64{"EURUSD": 100000},
+					Volatility: map[string]float64{"EURUSD": 0.0001},
+				},
+			}
+			f, err := GetGeneratorFunc(&cfg)
+			require.Nil(t, err)
+			backfill, err := GetBackfillFunc(&cfg)
+			require.Nil(t, err)
+
+			g := internal.NewSimplePriceGenerator([]string{"EURUSD"}, internal.GeneratorOptions{
+				Instruments: instruments,
+				Prices:      f,
+				Clock:       internal.NewVirtualClock(start, 0, 5*time.Second),
+				NewCache:    internal.NewLimitedCacheFunc(10),
+				Logger:      logger.New(logger.Error),
+			})
+			g.SetBackfill(internal.NewBackfill(backfill, "", 0, http.DefaultClient))
+			g.Start(context.Background(), internal.NewFixedScheduleFunc(time.Second))
+
+			rec := httptest.NewRecorder()
+			g.GetRatesCurrencyPair(rec, httptest.NewRequest(http.MethodGet, "/rates/EURUSD", nil), "EURUSD", v1.GetRatesCurrencyPairParams{})
+			var live []v1.ExchangeRate
+			require.Nil(t, json.NewDecoder(rec.Body).Decode(&live))
+
+			var ticks backfillTicks
+			_, _, err = g.Backfill(context.Background(), []string{"EURUSD"}, start.Add(-time.Hour), time.Time{}, nil, 0, &ticks)
+			require.Nil(t, err)
+			require.Len(t, ticks, 3600)
+
+			// the last backfilled price is one step of model away from the first live price
+			first, _, err := decimal.Parse(live[0].Mid)
+			require.Nil(t, err)
+			last, _, err := decimal.Parse(ticks[len(ticks)-1].Mid)
+			require.Nil(t, err)
+			require.InDelta(t, first, last, 100)
+		})
+	}
+
+	// recording has no prices before its first quote
+	backfill, err := GetBackfillFunc(&Config{Pattern: "REPLAY"})
+	require.Nil(t, err)
+	require.Nil(t, backfill)
+}
+
+func TestGetCacheFunc(t *testing.T) {
+	rates := func(c interface{ Put(v1.ExchangeRate) }) {
+		for i := 0; i < 10; i++ {
+			c.Put(v1.ExchangeRate{Time: time.Date(2022, 8, 1, 0, i, 0, 0, time.UTC)})
+		}
+	}
+
+	// clock shows the last rate
+	clock := internal.NewVirtualClock(time.Date(2022, 8, 1, 0, 9, 0, 0, time.UTC), 0, 0)
+
+	c := GetCacheFunc(&Config{CacheSize: 3}, clock)()
+	rates(c)
+	require.Equal(t, 3, c.Len())
+
+	// window keeps rates of the last 5 minutes
+	c = GetCacheFunc(&Config{CacheWindow: 5 * time.Minute}, clock)()
+	rates(c)
+	require.Equal(t, 6, c.Len())
+
+	c = GetCacheFunc(&Config{CacheSize: 4, CacheWindow: 5 * time.Minute}, clock)()
+	rates(c)
+	require.Equal(t, 4, c.Len())
+
+	c = GetCacheFunc(&Config{CacheSize: 3, CacheRing: true}, clock)()
+	rates(c)
+	require.Equal(t, 3, c.Len())
+	_, ok := c.(*cache.RingCache[v1.ExchangeRate])
+	require.True(t, ok)
+}
+
+func TestGetHTTPFaultRules(t *testing.T) {
+	require.Empty(t, GetHTTPFaultRules(&Config{}))
+
+	cfg := Config{HTTPFault: HTTPFault{
+		Latency:   map[string]time.Duration{"/rates/*": 200 * time.Millisecond},
+		Error:     map[string]float64{"/rates/*": 0.1, "/pairs": 0.5},
+		ErrorCode: map[string]int32{"/pairs": 500},
+		Reset:     map[string]float64{"/rates/*": 0.05},
+	}}
+
+	latency, code := "200ms", int32(500)
+	rates, pairs, reset := 0.1, 0.5, 0.05
+	require.Equal(t, []v1.HTTPFaultRule{
+		{Route: "/pairs", ErrorProbability: &pairs, ErrorCode: &code},
+		{Route: "/rates/*", Latency: &latency, ErrorProbability: &rates, ResetProbability: &reset},
+	}, GetHTTPFaultRules(&cfg))
+}
+
+func TestGetInstruments(t *testing.T) {
+	cfg := Config{
+		CurrencyPairs: []string{"EURUSD", "USDJPY"},
+		Scale:         map[string]int32{"EURUSD": 5, "USDJPY": 3, "USDRUB": 2},
+		Instrument: Instrument{
+			Pairs:    []string{"USDJPY", "USDRUB"},
+			PipSize:  map[string]string{"USDRUB": "0.01"},
+			Calendar: map[string]string{"EURUSD": "FX"},
+			Disabled: []string{"USDRUB"},
+		},
+	}
+
+	r, err := GetInstruments(&cfg)
+	require.Nil(t, err)
+
+	instruments := r.Instruments()
+	require.Len(t, instruments, 3)
+	require.Equal(t, "FX", instruments[0].Calendar)
+	require.Equal(t, "0.0001", instruments[0].PipSize)
+	require.Equal(t, int32(3), instruments[1].Precision)
+	require.Equal(t, "0.01", instruments[2].PipSize)
+	require.ErrorIs(t, r.Check("USDRUB"), internal.ErrDisabledInstrument)
+
+	cfg.Instrument.Disabled = []string{"EURUSD"}
+	_, err = GetInstruments(&cfg)
+	require.ErrorIs(t, err, internal.ErrDisabledInstrument)
+
+	cfg.Instrument.Disabled = nil
+	cfg.Instrument.Calendar = map[string]string{"EURUSD": "MOEX"}
+	_, err = GetInstruments(&cfg)
+	require.ErrorIs(t, err, internal.ErrInstrumentCalendar)
+
+	cfg.Calendar.Days = map[string]string{"MOEX": "MON TUE WED THU FRI"}
+	r, err = GetInstruments(&cfg)
+	require.Nil(t, err)
+	require.Equal(t, "MOEX", r.Calendar("EURUSD").Name())
+
+	cfg.CurrencyPairs = []string{"EURUSX"}
+	_, err = GetInstruments(&cfg)
+	require.ErrorIs(t, err, internal.ErrInstrumentCurrency)
+}
+
+func TestGetCalendars(t *testing.T) {
+	cfg := Config{Calendar: Calendar{
+		Days:     map[string]string{"MOEX": "MON TUE WED THU FRI"},
+		Rollover: map[string]time.Duration{"MOEX": 7 * time.Hour},
+		Holidays: map[string]string{"FX": "2022-12-26 2023-01-02"},
+	}}
+
+	calendars, err := GetCalendars(&cfg)
+	require.Nil(t, err)
+	require.Len(t, calendars, 2)
+
+	// built-in calendar keeps its days and rollover
+	require.Equal(t, "FX", calendars[0].Name())
+	require.Equal(t, []string{"MON", "TUE", "WED", "THU", "FRI"}, calendars[0].Days())
+	require.Equal(t, -2*time.Hour, calendars[0].Rollover())
+	require.Equal(t, []string{"2022-12-26", "2023-01-02"}, calendars[0].Holidays())
+	require.Equal(t, 7*time.Hour, calendars[1].Rollover())
+
+	// new calendar must have days
+	cfg.Calendar.Days = nil
+	_, err = GetCalendars(&cfg)
+	require.ErrorIs(t, err, calendar.ErrDays)
+
+	cfg.Calendar = Calendar{Holidays: map[string]string{"FX": "26.12.2022"}}
+	_, err = GetCalendars(&cfg)
+	require.ErrorIs(t, err, calendar.ErrHoliday)
+}
+
+func TestGetScenario(t *testing.T) {
+	set, err := GetScenario(&Config{})
+	require.Nil(t, err)
+	require.Nil(t, set)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "scenario.yaml")
+	require.Nil(t, os.WriteFile(file, []byte("run: qa\nscenarios:\n  - name: qa\n    pairs:\n      EURUSD:\n        - kind: halt\n          duration: 5m\n"), 0o600))
+
+	set, err = GetScenario(&Config{ScenarioFile: file})
+	require.Nil(t, err)
+	require.Equal(t, "qa", set.Run)
+
+	require.Nil(t, os.WriteFile(file, []byte(`{"run":"crash","scenarios":[]}`), 0o600))
+	_, err = GetScenario(&Config{ScenarioFile: file})
+	require.ErrorIs(t, err, internal.ErrUnknownScenario)
+
+	_, err = GetScenario(&Config{ScenarioFile: filepath.Join(dir, "missing.yaml")})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestInit_Replay(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "ticks.ndjson")
+	require.Nil(t, os.WriteFile(file, []byte(`{"pair":"EURUSD","time":"2022-08-01T10:00:00Z","rate":"1.0195"}
+{"pair":"EURUSD","time":"2022-08-01T10:00:02Z","rate":"1.0197"}
+`), 0o600))
+
+	vars := map[string]string{
+		"RATE_GENERATOR_CURRENCY_PAIRS": "EURUSD",
+		"RATE_GENERATOR_PATTERN":        "REPLAY",
+		"RATE_GENERATOR_PERIOD":         "1s",
+		"RATE_GENERATOR_SCALE":          "EURUSD:5",
+		"RATE_GENERATOR_CLOCK_SCALE":    "10",
+		"RATE_GENERATOR_REPLAY_FILE":    file,
+	}
+	defer env.PatchAll(t, vars)()
+
+	cfg, err := Init()
+	require.Nil(t, err)
+	start := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, start, cfg.Replay.Recording.Start())
+	require.Equal(t, start, GetClock(cfg).Now().Truncate(time.Second))
+
+	f, err := GetGeneratorFunc(cfg)
+	require.Nil(t, err)
+	schedule, err := GetScheduleFunc(cfg)
+	require.Nil(t, err)
+
+	s := schedule("EURUSD").(internal.TimedSchedule)
+	first, ok := s.First(start)
+	require.True(t, ok)
+	require.Equal(t, start, first)
+	require.Equal(t, int64(101950), f("EURUSD"))
+	require.Equal(t, 2*time.Second, s.Next())
+	require.Equal(t, int64(101970), f("EURUSD"))
+
+	// ticks are moved to epoch
+	defer env.Patch(t, "RATE_GENERATOR_CLOCK_EPOCH", "2023-01-01T00:00:00Z")()
+	cfg, err = Init()
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2023, 1, 1, 0, 0, 2, 0, time.UTC), cfg.Replay.Recording.End())
+
+	defer env.Patch(t, "RATE_GENERATOR_CURRENCY_PAIRS", "EURUSD,USDJPY")()
+	_, err = Init()
+	require.ErrorIs(t, err, ErrReplayPair)
+
+	_, err = GetGeneratorFunc(&Config{Pattern: "REPLAY"})
+	require.ErrorIs(t, err, ErrReplayFile)
+}
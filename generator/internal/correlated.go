@@ -0,0 +1,234 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+var (
+	ErrCorrelationMatrix = errors.New("correlation matrix must be symmetric positive definite with ones on diagonal")
+	ErrUnknownLeg        = errors.New("leg of cross pair isn't correlated")
+	ErrCrossSpread       = errors.New("spread of cross pair must be between 0 and 1")
+)
+
+// Leg is a currency pair driven by correlated shocks.
+type Leg struct {
+	Pair  string
+	Start int64
+	// NewModel creates price model of the leg that takes shocks from normal
+	NewModel func(normal NormalSource) PriceModel
+}
+
+// CrossPair derives price of currency pair from its legs:
+//
+//	p = start * (a / a0) * (b / b0) * (1 + spread * U)
+//
+// where U is uniform in [-1, 1]. With Inverse the second leg divides: EURGBP = EURUSD / GBPUSD.
+type CrossPair struct {
+	LegA    string
+	LegB    string
+	Inverse bool
+	Start   int64
+	Spread  float64
+}
+
+// CorrelatedMarket generates legs with correlated shocks and derives cross pairs from legs,
+// so triangular relationships hold within spread.
+//
+// The k-th shock of a leg depends on seed and k only, so the path of every pair is reproducible
+// regardless of the order in which goroutines of currency pairs call the market.
+type CorrelatedMarket struct {
+	seed int64
+	// chol is a lower triangular Cholesky factor of correlation matrix
+	chol [][]float64
+
+	// mu guards state of legs and crosses
+	mu      sync.Mutex
+	legs    map[string]*marketLeg
+	crosses map[string]*marketCross
+}
+
+type marketLeg struct {
+	index int
+	start float64
+	price float64
+	// step is a number of generated prices
+	step  uint64
+	shock *legShock
+	model PriceModel
+}
+
+type marketCross struct {
+	CrossPair
+	seed int64
+	step uint64
+}
+
+// legShock passes the current correlated shock to price model of the leg
+type legShock struct {
+	z float64
+}
+
+func (s *legShock) NormFloat64() float64 {
+	return s.z
+}
+
+// NewCorrelatedMarket creates market of legs with correlation matrix. Row i of correlation belongs to legs[i].
+func NewCorrelatedMarket(legs []Leg, correlation [][]float64, crosses map[string]CrossPair, seed int64) (*CorrelatedMarket, error) {
+	chol, err := cholesky(correlation, len(legs))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &CorrelatedMarket{
+		seed:    seed,
+		chol:    chol,
+		legs:    make(map[string]*marketLeg, len(legs)),
+		crosses: make(map[string]*marketCross, len(crosses)),
+	}
+
+	for i, l := range legs {
+		shock := &legShock{}
+		m.legs[l.Pair] = &marketLeg{
+			index: i,
+			start: float64(l.Start),
+			price: float64(l.Start),
+			shock: shock,
+			model: l.NewModel(shock),
+		}
+	}
+
+	for pair, c := range crosses {
+		if _, ok := m.legs[c.LegA]; !ok {
+			return nil, fmt.Errorf("%s: %w: %s", pair, ErrUnknownLeg, c.LegA)
+		}
+		if _, ok := m.legs[c.LegB]; !ok {
+			return nil, fmt.Errorf("%s: %w: %s", pair, ErrUnknownLeg, c.LegB)
+		}
+		if c.Spread < 0 || c.Spread >= 1 {
+			return nil, fmt.Errorf("%s: %w", pair, ErrCrossSpread)
+		}
+		m.crosses[pair] = &marketCross{CrossPair: c, seed: PairSeed(seed, pair)}
+	}
+
+	return m, nil
+}
+
+// GeneratorFunc returns prices of legs and cross pairs, other currency pairs are generated by fallback
+func (m *CorrelatedMarket) GeneratorFunc(fallback GeneratorFunc) GeneratorFunc {
+	return func(currencyPair string) int64 {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if l, ok := m.legs[currencyPair]; ok {
+			return m.nextLeg(l)
+		}
+		if c, ok := m.crosses[currencyPair]; ok {
+			return m.nextCross(c)
+		}
+		return fallback(currencyPair)
+	}
+}
+
+// nextLeg advances model of the leg by its next correlated shock. Must be called with mu held.
+func (m *CorrelatedMarket) nextLeg(l *marketLeg) int64 {
+	// shocks of step are the same for every leg: z = L * e
+	src := newSplitMix(m.seed, l.step)
+	z := 0.0
+	for j := 0; j <= l.index; j++ {
+		z += m.chol[l.index][j] * src.NormFloat64()
+	}
+	l.step++
+
+	l.shock.z = z
+	p := l.model.Next()
+	l.price = float64(p)
+	return p
+}
+
+// nextCross derives price of cross pair from the last prices of its legs. Must be called with mu held.
+func (m *CorrelatedMarket) nextCross(c *marketCross) int64 {
+	a, b := m.legs[c.LegA], m.legs[c.LegB]
+	if a.price == 0 || b.price == 0 {
+		return 0
+	}
+
+	ratio := b.price / b.start
+	if c.Inverse {
+		ratio = b.start / b.price
+	}
+
+	src := newSplitMix(c.seed, c.step)
+	c.step++
+	u := 2*src.Float64() - 1
+
+	return int64(math.Round(float64(c.Start) * a.price / a.start * ratio * (1 + c.Spread*u)))
+}
+
+// cholesky returns lower triangular L: L * L^T = correlation
+func cholesky(correlation [][]float64, n int) ([][]float64, error) {
+	if len(correlation) != n {
+		return nil, ErrCorrelationMatrix
+	}
+	for i := range correlation {
+		if len(correlation[i]) != n || correlation[i][i] != 1 {
+			return nil, ErrCorrelationMatrix
+		}
+		for j := range correlation[i] {
+			if correlation[i][j] != correlation[j][i] || math.Abs(correlation[i][j]) > 1 {
+				return nil, ErrCorrelationMatrix
+			}
+		}
+	}
+
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, i+1)
+		for j := 0; j <= i; j++ {
+			sum := correlation[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, ErrCorrelationMatrix
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, nil
+}
+
+// splitMix is a small deterministic generator: numbers of every market step are derived from seed and step
+type splitMix struct {
+	state uint64
+}
+
+func newSplitMix(seed int64, step uint64) *splitMix {
+	return &splitMix{state: uint64(seed) ^ step*0xd1b54a32d192ed03}
+}
+
+func (s *splitMix) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Float64 returns uniform number in [0, 1)
+func (s *splitMix) Float64() float64 {
+	return float64(s.Uint64()>>11) / (1 << 53)
+}
+
+// NormFloat64 returns standard normal number (Box-Muller transform)
+func (s *splitMix) NormFloat64() float64 {
+	u1 := 1 - s.Float64()
+	u2 := s.Float64()
+	return math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+}
@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func newTestMarket(t *testing.T, rho float64, spread float64) GeneratorFunc {
+	gbm := func(start int64) func(NormalSource) PriceModel {
+		return func(normal NormalSource) PriceModel {
+			return NewGeometricBrownianMotion(start, 0, 0.001, normal)
+		}
+	}
+	legs := []Leg{
+		{Pair: "EURUSD", Start: 100000, NewModel: gbm(100000)},
+		{Pair: "GBPUSD", Start: 125000, NewModel: gbm(125000)},
+		{Pair: "USDJPY", Start: 13500, NewModel: gbm(13500)},
+	}
+	correlation := [][]float64{
+		{1, rho, 0},
+		{rho, 1, 0},
+		{0, 0, 1},
+	}
+	crosses := map[string]CrossPair{
+		"EURJPY": {LegA: "EURUSD", LegB: "USDJPY", Start: 13500, Spread: spread},
+		"EURGBP": {LegA: "EURUSD", LegB: "GBPUSD", Inverse: true, Start: 80000, Spread: spread},
+	}
+
+	m, err := NewCorrelatedMarket(legs, correlation, crosses, 123)
+	require.Nil(t, err)
+	return m.GeneratorFunc(func(string) int64 { return -1 })
+}
+
+func TestCorrelatedMarket_Correlation(t *testing.T) {
+	f := newTestMarket(t, 0.8, 0)
+
+	const n = 20000
+	var (
+		prevA, prevB = 100000.0, 125000.0
+		a, b         = make([]float64, n), make([]float64, n)
+	)
+	for i := 0; i < n; i++ {
+		pa, pb := float64(f("EURUSD")), float64(f("GBPUSD"))
+		a[i], b[i] = math.Log(pa/prevA), math.Log(pb/prevB)
+		prevA, prevB = pa, pb
+	}
+
+	require.InDelta(t, 0.8, sampleCorrelation(a, b), 0.05)
+	require.Equal(t, int64(-1), f("USDRUB"))
+}
+
+func TestCorrelatedMarket_Cross(t *testing.T) {
+	const spread = 0.001
+	f := newTestMarket(t, 0.5, spread)
+
+	for i := 0; i < 1000; i++ {
+		eurusd, gbpusd, usdjpy := float64(f("EURUSD")), float64(f("GBPUSD")), float64(f("USDJPY"))
+
+		// triangular relationship holds within spread and rounding
+		eurjpy := 13500 * eurusd / 100000 * usdjpy / 13500
+		require.InDelta(t, eurjpy, float64(f("EURJPY")), eurjpy*spread+1)
+
+		eurgbp := 80000 * eurusd / 100000 * 125000 / gbpusd
+		require.InDelta(t, eurgbp, float64(f("EURGBP")), eurgbp*spread+1)
+	}
+}
+
+func TestCorrelatedMarket_Reproducible(t *testing.T) {
+	f1, f2 := newTestMarket(t, 0.8, 0), newTestMarket(t, 0.8, 0)
+
+	// legs are called in different order
+	var a1, b1, a2, b2 []int64
+	for i := 0; i < 10; i++ {
+		a1 = append(a1, f1("EURUSD"))
+		b1 = append(b1, f1("GBPUSD"))
+	}
+	for i := 0; i < 10; i++ {
+		b2 = append(b2, f2("GBPUSD"))
+	}
+	for i := 0; i < 10; i++ {
+		a2 = append(a2, f2("EURUSD"))
+	}
+
+	require.Equal(t, a1, a2)
+	require.Equal(t, b1, b2)
+}
+
+func TestNewCorrelatedMarket_Errors(t *testing.T) {
+	model := func(normal NormalSource) PriceModel { return NewRandomWalk(100, 1, normal) }
+	legs := []Leg{{Pair: "EURUSD", Start: 100, NewModel: model}, {Pair: "USDJPY", Start: 100, NewModel: model}}
+
+	tests := []struct {
+		name        string
+		correlation [][]float64
+		crosses     map[string]CrossPair
+		err         error
+	}{
+		{
+			name:        "not positive definite",
+			correlation: [][]float64{{1, 1}, {1, 1}},
+			err:         ErrCorrelationMatrix,
+		},
+		{
+			name:        "not symmetric",
+			correlation: [][]float64{{1, 0.5}, {0.2, 1}},
+			err:         ErrCorrelationMatrix,
+		},
+		{
+			name:        "wrong size",
+			correlation: [][]float64{{1}},
+			err:         ErrCorrelationMatrix,
+		},
+		{
+			name:        "unknown leg",
+			correlation: [][]float64{{1, 0}, {0, 1}},
+			crosses:     map[string]CrossPair{"EURGBP": {LegA: "EURUSD", LegB: "GBPUSD", Start: 100}},
+			err:         ErrUnknownLeg,
+		},
+		{
+			name:        "negative spread",
+			correlation: [][]float64{{1, 0}, {0, 1}},
+			crosses:     map[string]CrossPair{"EURJPY": {LegA: "EURUSD", LegB: "USDJPY", Start: 100, Spread: -0.1}},
+			err:         ErrCrossSpread,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewCorrelatedMarket(legs, tc.correlation, tc.crosses, 1)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func sampleCorrelation(a, b []float64) float64 {
+	var ma, mb float64
+	for i := range a {
+		ma += a[i]
+		mb += b[i]
+	}
+	ma /= float64(len(a))
+	mb /= float64(len(b))
+
+	var cov, va, vb float64
+	for i := range a {
+		cov += (a[i] - ma) * (b[i] - mb)
+		va += (a[i] - ma) * (a[i] - ma)
+		vb += (b[i] - mb) * (b[i] - mb)
+	}
+	return cov / math.Sqrt(va*vb)
+}
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"container/heap"
+	"context"
+	"generator/internal/api/http/v1"
+	"time"
+)
+
+// TickExporter generates rates of currency pairs over time range without waiting, the same way as SimplePriceGenerator:
+// prices of f are changed by scenario, quoted by quote and get anomalies of faults, closed markets get no rates.
+type TickExporter struct {
+	instruments *InstrumentRegistry
+	scenario    *ScenarioPlayer
+	f           GeneratorFunc
+	schedule    ScheduleFunc
+	quote       QuoteFunc
+	faults      FaultFunc
+}
+
+// NewTickExporter creates exporter of rates, nil quote quotes mid prices only and nil faults inject nothing
+func NewTickExporter(instruments *InstrumentRegistry, f GeneratorFunc, schedule ScheduleFunc, quote QuoteFunc, faults FaultFunc) *TickExporter {
+	if quote == nil {
+		quote = func(string) QuoteModel { return MidQuote{} }
+	}
+	if faults == nil {
+		faults = func(string) FaultModel { return NoFaults{} }
+	}
+
+	return &TickExporter{
+		instruments: instruments,
+		scenario:    NewScenarioPlayer(instruments),
+		f:           f,
+		schedule:    schedule,
+		quote:       quote,
+		faults:      faults,
+	}
+}
+
+// PlayScenario plays scenario set, its timelines start at start of set after from of Export
+func (e *TickExporter) PlayScenario(set v1.ScenarioSet, from time.Time) error {
+	return e.scenario.Play(set, from)
+}
+
+// exportQueue orders runs of currency pairs by time of their next rates
+type exportQueue []*pairRun
+
+func (q exportQueue) Len() int { return len(q) }
+
+func (q exportQueue) Less(i, j int) bool {
+	if q[i].next.Equal(q[j].next) {
+		return q[i].cur < q[j].cur
+	}
+	return q[i].next.Before(q[j].next)
+}
+
+func (q exportQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *exportQueue) Push(x any) { *q = append(*q, x.(*pairRun)) }
+
+func (q *exportQueue) Pop() any {
+	old := *q
+	p := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return p
+}
+
+// Export writes rates of currency pairs created between from and to inclusive to w and returns number of written rates.
+// Rates of all pairs are ordered by time unless faults skew them.
+func (e *TickExporter) Export(ctx context.Context, currencyPairs []string, from, to time.Time, w TickWriter) (int, error) {
+	it := e.iterate(currencyPairs, from, to)
+	n := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		pair, rate, ok := it.next()
+		if !ok {
+			return n, nil
+		}
+		if err := w.Write(pair, rate); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// tickIterator returns rates of Export one by one, so export can be paused between them
+type tickIterator struct {
+	e  *TickExporter
+	q  exportQueue
+	to time.Time
+	// rates are rates of the last step of pair, i is index of the next of them
+	pair  string
+	rates []v1.ExchangeRate
+	i     int
+}
+
+func (e *TickExporter) iterate(currencyPairs []string, from, to time.Time) *tickIterator {
+	q := make(exportQueue, 0, len(currencyPairs))
+	for _, pair := range currencyPairs {
+		in, _ := e.instruments.Get(pair)
+		r := &pairRun{
+			cur:      pair,
+			scale:    in.Precision,
+			calendar: e.instruments.Calendar(pair),
+			schedule: e.schedule(pair),
+			quote:    e.quote(pair),
+			faults:   e.faults(pair),
+		}
+		if r.start(from) {
+			q = append(q, r)
+		}
+	}
+	heap.Init(&q)
+
+	return &tickIterator{e: e, q: q, to: to, rates: make([]v1.ExchangeRate, 0, 2)}
+}
+
+// next returns the next rate and its currency pair, false means that export is over
+func (it *tickIterator) next() (string, v1.ExchangeRate, bool) {
+	for it.i == len(it.rates) {
+		if it.q.Len() == 0 {
+			return "", v1.ExchangeRate{}, false
+		}
+
+		r := it.q[0]
+		if r.next.After(it.to) {
+			heap.Pop(&it.q)
+			continue
+		}
+		// schedule continues when market opens
+		if !r.open() {
+			heap.Fix(&it.q, 0)
+			continue
+		}
+
+		it.rates, it.i = it.rates[:0], 0
+		if mid, ok := it.e.scenario.Apply(r.cur, r.next, it.e.f(r.cur)); ok {
+			it.pair = r.cur
+			_, _, it.rates = r.step(mid, it.rates)
+		}
+
+		if !r.advance() {
+			heap.Pop(&it.q)
+			continue
+		}
+		heap.Fix(&it.q, 0)
+	}
+
+	it.i++
+	return it.pair, it.rates[it.i-1], true
+}
+
+// unread returns the last rate of next back, it is returned by next again
+func (it *tickIterator) unread() {
+	it.i--
+}
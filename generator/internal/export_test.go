@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+	"mtsbank/pkg/calendar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// sliceTickWriter keeps written rates of currency pairs
+type sliceTickWriter struct {
+	pairs []string
+	rates []v1.ExchangeRate
+}
+
+func (s *sliceTickWriter) Write(pair string, rate v1.ExchangeRate) error {
+	s.pairs = append(s.pairs, pair)
+	s.rates = append(s.rates, rate)
+	return nil
+}
+
+func (s *sliceTickWriter) Close() error {
+	return nil
+}
+
+func TestTickExporter_Export(t *testing.T) {
+	pairs := []string{"EURUSD", "USDJPY"}
+	schedule := func(pair string) Schedule {
+		if pair == "USDJPY" {
+			return FixedSchedule(2 * time.Second)
+		}
+		return FixedSchedule(time.Second)
+	}
+
+	e := NewTickExporter(newScenarioRegistry(t), NewExchangeRateFromSeed(123), schedule, nil, nil)
+	w := &sliceTickWriter{}
+	n, err := e.Export(context.Background(), pairs, epoch, epoch.Add(9*time.Second), w)
+	require.Nil(t, err)
+	require.Equal(t, 15, n)
+	require.Equal(t, []string{"EURUSD", "USDJPY", "EURUSD", "EURUSD", "USDJPY"}, w.pairs[:5])
+	for i := 1; i < len(w.rates); i++ {
+		require.False(t, w.rates[i].Time.Before(w.rates[i-1].Time))
+	}
+
+	// live generator creates the same rates from the same models
+	g := NewSimplePriceGenerator(pairs, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Prices:      NewExchangeRateFromSeed(123),
+		Clock:       NewVirtualClock(epoch, 0, 9*time.Second),
+		NewCache:    NewLimitedCacheFunc(100),
+		Logger:      logger.New(logger.Info),
+	})
+	g.Start(context.Background(), schedule)
+
+	for _, pair := range pairs {
+		rec := httptest.NewRecorder()
+		g.GetRatesCurrencyPair(rec, httptest.NewRequest(http.MethodGet, "/rates/"+pair, nil), pair, v1.GetRatesCurrencyPairParams{})
+		var live []v1.ExchangeRate
+		require.Nil(t, json.NewDecoder(rec.Body).Decode(&live))
+
+		var exported []v1.ExchangeRate
+		for i, p := range w.pairs {
+			if p == pair {
+				exported = append(exported, w.rates[i])
+			}
+		}
+		require.Len(t, live, len(exported))
+		for i := range live {
+			require.True(t, live[i].Time.Equal(exported[i].Time))
+			require.Equal(t, live[i].Rate, exported[i].Rate)
+		}
+	}
+}
+
+func TestTickExporter_Export_Calendar(t *testing.T) {
+	eurusd := NewInstrument("EURUSD", 5)
+	eurusd.Calendar = calendar.FX
+	instruments, err := NewInstrumentRegistry([]v1.Instrument{eurusd})
+	require.Nil(t, err)
+
+	// market closes on Friday at 22:00 and opens on Sunday at 22:00
+	friday := time.Date(2022, 8, 5, 21, 59, 58, 0, time.UTC)
+	expected := []time.Time{friday, friday.Add(time.Second), friday.Add(48*time.Hour + 2*time.Second), friday.Add(48*time.Hour + 3*time.Second)}
+
+	e := NewTickExporter(instruments, NewExchangeRateFromSeed(123), NewFixedScheduleFunc(time.Second), nil, nil)
+	w := &sliceTickWriter{}
+	_, err = e.Export(context.Background(), []string{"EURUSD"}, friday, expected[3], w)
+	require.Nil(t, err)
+	require.Len(t, w.rates, len(expected))
+	for i := range expected {
+		require.True(t, expected[i].Equal(w.rates[i].Time))
+	}
+
+	// live generator doesn't generate rates while market is closed
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: instruments,
+		Prices:      NewExchangeRateFromSeed(123),
+		Clock:       NewVirtualClock(friday, 0, 48*time.Hour+3*time.Second),
+		NewCache:    NewLimitedCacheFunc(100),
+		Logger:      logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	rec := httptest.NewRecorder()
+	g.GetRatesCurrencyPair(rec, httptest.NewRequest(http.MethodGet, "/rates/EURUSD", nil), "EURUSD", v1.GetRatesCurrencyPairParams{})
+	var live []v1.ExchangeRate
+	require.Nil(t, json.NewDecoder(rec.Body).Decode(&live))
+	require.Equal(t, w.rates, live)
+}
+
+func TestTickExporter_Export_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	e := NewTickExporter(nil, NewExchangeRateFromSeed(123), NewFixedScheduleFunc(time.Second), nil, nil)
+	_, err := e.Export(ctx, []string{"EURUSD"}, epoch, epoch.Add(time.Hour), &sliceTickWriter{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTickWriter(t *testing.T) {
+	rates := []v1.ExchangeRate{
+		{Time: epoch, Rate: "1.01950", Bid: "1.01949", Ask: "1.01951", Mid: "1.01950", Volume: 1000},
+		{Time: epoch.Add(time.Second), Rate: "133.100", Bid: "133.090", Ask: "133.110", Mid: "133.100", Volume: 500},
+	}
+	pairs := []string{"EURUSD", "USDJPY"}
+
+	write := func(format string) []byte {
+		var b bytes.Buffer
+		w, err := NewTickWriter(&b, format)
+		require.Nil(t, err)
+		for i, r := range rates {
+			require.Nil(t, w.Write(pairs[i], r))
+		}
+		require.Nil(t, w.Close())
+		return b.Bytes()
+	}
+
+	expected := []Tick{
+		{Pair: "EURUSD", Time: epoch, Rate: "1.01950"},
+		{Pair: "USDJPY", Time: epoch.Add(time.Second), Rate: "133.100"},
+	}
+
+	// CSV and NDJSON files are replayed
+	ticks, err := ReadTicksCSV(bytes.NewReader(write(TickFormatCSV)))
+	require.Nil(t, err)
+	require.Equal(t, expected, ticks)
+
+	ticks, err = ReadTicksNDJSON(bytes.NewReader(write(TickFormatNDJSON)))
+	require.Nil(t, err)
+	require.Equal(t, expected, ticks)
+
+	file, err := buffer.NewBufferFile(write(TickFormatParquet))
+	require.Nil(t, err)
+	pr, err := reader.NewParquetReader(file, new(parquetTick), 1)
+	require.Nil(t, err)
+	rows := make([]parquetTick, pr.GetNumRows())
+	require.Nil(t, pr.Read(&rows))
+	pr.ReadStop()
+	require.Equal(t, []parquetTick{
+		{Pair: "EURUSD", Time: epoch.UnixMicro(), Rate: "1.01950", Bid: "1.01949", Ask: "1.01951", Mid: "1.01950", Volume: 1000},
+		{Pair: "USDJPY", Time: epoch.Add(time.Second).UnixMicro(), Rate: "133.100", Bid: "133.090", Ask: "133.110", Mid: "133.100", Volume: 500},
+	}, rows)
+
+	_, err = NewTickWriter(&bytes.Buffer{}, "xml")
+	require.ErrorIs(t, err, ErrTickFormat)
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"generator/internal/api/http/v1"
+	"math/rand"
+	"time"
+)
+
+// FaultKind is a kind of data anomaly injected into rates of currency pair
+type FaultKind string
+
+const (
+	// FaultSkew moves time of rate back, so rate arrives late and out of order
+	FaultSkew FaultKind = "skew"
+	// FaultDuplicate puts the same rate twice
+	FaultDuplicate FaultKind = "duplicate"
+	// FaultGap drops rate
+	FaultGap FaultKind = "gap"
+)
+
+// FaultModel injects anomalies into rates of currency pair.
+// FaultModel is used by one goroutine only.
+type FaultModel interface {
+	// Inject appends rates that are put in place of rate to out and returns kind of injected anomaly, empty if rate isn't changed
+	Inject(rate v1.ExchangeRate, out []v1.ExchangeRate) ([]v1.ExchangeRate, FaultKind)
+}
+
+// FaultFunc creates fault model of currency pair when its generation starts
+type FaultFunc func(pair string) FaultModel
+
+var (
+	_ FaultModel = NoFaults{}
+	_ FaultModel = (*RandomFaults)(nil)
+)
+
+// NoFaults puts rates as they are.
+type NoFaults struct{}
+
+func (NoFaults) Inject(rate v1.ExchangeRate, out []v1.ExchangeRate) ([]v1.ExchangeRate, FaultKind) {
+	return append(out, rate), ""
+}
+
+// RandomFaults injects at most one anomaly into each rate with given probabilities.
+// Skewed rate is moved back by uniformly distributed duration up to maxSkew, maxSkew must be at least a microsecond.
+type RandomFaults struct {
+	skew, duplicate, gap float64
+	maxSkew              time.Duration
+	r                    *rand.Rand
+}
+
+// NewRandomFaults creates fault model, sum of probabilities must not be greater than one
+func NewRandomFaults(skew float64, maxSkew time.Duration, duplicate, gap float64, r *rand.Rand) *RandomFaults {
+	return &RandomFaults{skew: skew, duplicate: duplicate, gap: gap, maxSkew: maxSkew, r: r}
+}
+
+func (f *RandomFaults) Inject(rate v1.ExchangeRate, out []v1.ExchangeRate) ([]v1.ExchangeRate, FaultKind) {
+	u := f.r.Float64()
+	switch {
+	case u < f.gap:
+		return out, FaultGap
+	case u < f.gap+f.duplicate:
+		return append(out, rate, rate), FaultDuplicate
+	case u < f.gap+f.duplicate+f.skew:
+		// times of rates are rounded to microseconds
+		skew := time.Duration(1+f.r.Int63n(int64(f.maxSkew/time.Microsecond))) * time.Microsecond
+		rate.Time = rate.Time.Add(-skew)
+		return append(out, rate), FaultSkew
+	}
+	return append(out, rate), ""
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"context"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRandomFaults(t *testing.T) {
+	rate := v1.ExchangeRate{Time: epoch, Rate: "1.00000", Mid: "1.00000"}
+
+	tests := []struct {
+		name   string
+		faults *RandomFaults
+		kind   FaultKind
+		rates  int
+	}{
+		{
+			name:   "no faults",
+			faults: NewRandomFaults(0, 0, 0, 0, rand.New(rand.NewSource(1))),
+			rates:  1,
+		},
+		{
+			name:   "gap",
+			faults: NewRandomFaults(0, 0, 0, 1, rand.New(rand.NewSource(1))),
+			kind:   FaultGap,
+		},
+		{
+			name:   "duplicate",
+			faults: NewRandomFaults(0, 0, 1, 0, rand.New(rand.NewSource(1))),
+			kind:   FaultDuplicate,
+			rates:  2,
+		},
+		{
+			name:   "skew",
+			faults: NewRandomFaults(1, time.Second, 0, 0, rand.New(rand.NewSource(1))),
+			kind:   FaultSkew,
+			rates:  1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				out, kind := tc.faults.Inject(rate, nil)
+				require.Equal(t, tc.kind, kind)
+				require.Len(t, out, tc.rates)
+
+				for _, r := range out {
+					require.Equal(t, rate.Mid, r.Mid)
+					if kind != FaultSkew {
+						require.Equal(t, rate.Time, r.Time)
+						continue
+					}
+					require.True(t, r.Time.Before(epoch))
+					require.False(t, r.Time.Before(epoch.Add(-time.Second)))
+					require.Equal(t, r.Time, r.Time.Round(time.Microsecond))
+				}
+			}
+		})
+	}
+}
+
+func TestRandomFaults_Probabilities(t *testing.T) {
+	f := NewRandomFaults(0.1, time.Second, 0.2, 0.3, rand.New(rand.NewSource(1)))
+
+	counts := map[FaultKind]int{}
+	for i := 0; i < 10000; i++ {
+		_, kind := f.Inject(v1.ExchangeRate{Time: epoch}, nil)
+		counts[kind]++
+	}
+
+	require.InDelta(t, 1000, counts[FaultSkew], 150)
+	require.InDelta(t, 2000, counts[FaultDuplicate], 150)
+	require.InDelta(t, 3000, counts[FaultGap], 150)
+	require.InDelta(t, 4000, counts[""], 150)
+}
+
+func TestSimplePriceGenerator_Faults(t *testing.T) {
+	// every rate is late by up to 1.5 periods, so some rates are out of order
+	faults := func(string) FaultModel {
+		return NewRandomFaults(1, 1500*time.Millisecond, 0, 0, rand.New(rand.NewSource(1)))
+	}
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Faults:   faults,
+		Clock:    NewVirtualClock(epoch, 0, 99*time.Second),
+		NewCache: NewLimitedCacheFunc(100),
+		Logger:   logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	all, _, err := g.rates("EURUSD", nil, nil, make([]v1.ExchangeRate, 0, 100))
+	require.Nil(t, err)
+	require.Len(t, all, 100)
+
+	outOfOrder := 0
+	for i := 1; i < len(all); i++ {
+		if all[i].Time.Before(all[i-1].Time) {
+			outOfOrder++
+		}
+	}
+	require.Positive(t, outOfOrder)
+
+	// rates are read by cursor of the last read rate, late rates aren't lost
+	var read []v1.ExchangeRate
+	limit := int32(7)
+	for len(read) < len(all) {
+		var since *time.Time
+		if len(read) > 0 {
+			since = &read[len(read)-1].Time
+		}
+		out, _, err := g.rates("EURUSD", since, &limit, make([]v1.ExchangeRate, 0, 100))
+		require.Nil(t, err)
+		require.NotEmpty(t, out)
+		read = append(read, out...)
+	}
+	require.Equal(t, all, read)
+
+	// faults are reproducible
+	g = NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Faults:   faults,
+		Clock:    NewVirtualClock(epoch, 0, 99*time.Second),
+		NewCache: NewLimitedCacheFunc(100),
+		Logger:   logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+	again, _, err := g.rates("EURUSD", nil, nil, make([]v1.ExchangeRate, 0, 100))
+	require.Nil(t, err)
+	require.Equal(t, all, again)
+}
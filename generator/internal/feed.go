@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"fmt"
+	"generator/internal/api/http/v1"
+	"generator/pkg/cache"
+)
+
+// rateFeed follows caches of currency pairs from a cursor.
+// SSE, gRPC and WebSocket streams share it and differ only in how they write rates and gaps.
+type rateFeed struct {
+	g *SimplePriceGenerator
+	// cursor keeps time of the last emitted rate per currency pair
+	cursor streamCursor
+	// notify receives signals from caches of followed currency pairs
+	notify chan struct{}
+	pairs  []string
+	subs   map[string]feedSubscription
+	buffer []v1.ExchangeRate
+}
+
+type feedSubscription struct {
+	cache       cache.Cache[v1.ExchangeRate]
+	unsubscribe func()
+}
+
+// newRateFeed creates feed starting from cursor. Nil cursor is empty. Feed must be closed.
+func (s *SimplePriceGenerator) newRateFeed(cursor streamCursor) *rateFeed {
+	if cursor == nil {
+		cursor = streamCursor{}
+	}
+	return &rateFeed{
+		g:      s,
+		cursor: cursor,
+		notify: make(chan struct{}, 1),
+		subs:   map[string]feedSubscription{},
+		buffer: s.pool.Get().([]v1.ExchangeRate)[:0],
+	}
+}
+
+// follow subscribes feed to cache of currency pair.
+// Without position in cursor the pair is followed after its newest cached rate.
+func (f *rateFeed) follow(currencyPair string) error {
+	if f.following(currencyPair) {
+		return nil
+	}
+
+	c, ok := f.g.pairCache(currencyPair)
+	if !ok {
+		return fmt.Errorf("%s: %w", currencyPair, ErrUnknownCurrencyPair)
+	}
+
+	if _, ok = f.cursor[currencyPair]; !ok {
+		if last, ok := c.Last(); ok {
+			f.cursor[currencyPair] = last.Time
+		}
+	}
+
+	f.subs[currencyPair] = feedSubscription{cache: c, unsubscribe: c.Subscribe(f.notify)}
+	f.pairs = append(f.pairs, currencyPair)
+	return nil
+}
+
+func (f *rateFeed) following(currencyPair string) bool {
+	_, ok := f.subs[currencyPair]
+	return ok
+}
+
+// unfollow unsubscribes feed from cache of currency pair and forgets its position
+func (f *rateFeed) unfollow(currencyPair string) bool {
+	sub, ok := f.subs[currencyPair]
+	if !ok {
+		return false
+	}
+	sub.unsubscribe()
+	delete(f.subs, currencyPair)
+	delete(f.cursor, currencyPair)
+	for i, p := range f.pairs {
+		if p == currencyPair {
+			f.pairs = append(f.pairs[:i], f.pairs[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// feedEvent is a kind of event emitted by rateFeed
+type feedEvent int
+
+const (
+	// feedRate carries a rate created after cursor
+	feedRate feedEvent = iota
+	// feedGap precedes rates of currency pair that follow evicted ones
+	feedGap
+	// feedEnd is the last event of deleted currency pair, feed doesn't follow it anymore
+	feedEnd
+)
+
+// next calls emit for every rate created after cursor in order of followed currency pairs.
+// Cursor is moved before rate is emitted and next stops at the first error of emit.
+func (f *rateFeed) next(emit func(currencyPair string, event feedEvent, rate *v1.ExchangeRate) error) error {
+	var ended []string
+	for _, p := range f.pairs {
+		c := f.subs[p].cache
+		// rates put before cache was closed are still emitted
+		closed := c.Closed()
+		since, ok := f.cursor[p]
+		f.buffer = c.Since(since, f.buffer[:0])
+		if ok && ratesGap(c, f.buffer, since) {
+			if err := emit(p, feedGap, nil); err != nil {
+				return err
+			}
+		}
+		for i := range f.buffer {
+			f.cursor[p] = f.buffer[i].Time
+			if err := emit(p, feedRate, &f.buffer[i]); err != nil {
+				return err
+			}
+		}
+		if closed {
+			ended = append(ended, p)
+		}
+	}
+
+	for _, p := range ended {
+		f.unfollow(p)
+		if err := emit(p, feedEnd, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close unsubscribes feed from all caches
+func (f *rateFeed) close() {
+	for _, p := range f.pairs {
+		f.subs[p].unsubscribe()
+	}
+	f.pairs = nil
+	f.subs = map[string]feedSubscription{}
+	f.g.pool.Put(f.buffer[:0])
+	f.buffer = nil
+}
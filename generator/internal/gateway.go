@@ -0,0 +1,287 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"github.com/gorilla/websocket"
+	"github.com/mazitovt/logger"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// errNotEnqueued stops push when connection is closed or message can't be encoded
+var errNotEnqueued = errors.New("message isn't enqueued")
+
+const (
+	defaultSendBuffer = 256
+	defaultPingPeriod = 30 * time.Second
+
+	wsWriteWait    = 10 * time.Second
+	wsMaxReadBytes = 4096
+
+	wsOpSubscribe   = "subscribe"
+	wsOpUnsubscribe = "unsubscribe"
+)
+
+// WebSocketRequest is a message from client
+type WebSocketRequest struct {
+	Op    string   `json:"op"`
+	Pairs []string `json:"pairs"`
+}
+
+// WebSocketMessage is a message to client. Type is one of "rate", "gap", "end", "subscribed", "unsubscribed", "error".
+type WebSocketMessage struct {
+	Type         string           `json:"type"`
+	CurrencyPair string           `json:"currency_pair,omitempty"`
+	Pairs        []string         `json:"pairs,omitempty"`
+	Rate         *v1.ExchangeRate `json:"rate,omitempty"`
+	Message      string           `json:"message,omitempty"`
+}
+
+// WebSocketGateway pushes rates of subscribed currency pairs over WebSocket.
+//
+// Every connection has its own send buffer. Connection that doesn't read fast enough
+// to keep the buffer from overflowing is closed.
+type WebSocketGateway struct {
+	g          *SimplePriceGenerator
+	upgrader   websocket.Upgrader
+	sendBuffer int
+	pingPeriod time.Duration
+	logger     logger.Logger
+}
+
+// NewWebSocketGateway creates gateway. Zero sendBuffer and pingPeriod are replaced with defaults.
+func NewWebSocketGateway(g *SimplePriceGenerator, sendBuffer int, pingPeriod time.Duration, logger logger.Logger) *WebSocketGateway {
+	if sendBuffer <= 0 {
+		sendBuffer = defaultSendBuffer
+	}
+	if pingPeriod <= 0 {
+		pingPeriod = defaultPingPeriod
+	}
+	return &WebSocketGateway{
+		g:          g,
+		sendBuffer: sendBuffer,
+		pingPeriod: pingPeriod,
+		logger:     logger,
+	}
+}
+
+func (gw *WebSocketGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := gw.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		gw.logger.Debug("WebSocketGateway.ServeHTTP: upgrade: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	c := &wsConn{
+		gw:     gw,
+		ws:     ws,
+		cancel: cancel,
+		send:   make(chan []byte, gw.sendBuffer),
+		rates:  gw.g.newRateFeed(nil),
+	}
+
+	gw.logger.Debug("WebSocketGateway: connected: %v", ws.RemoteAddr())
+	defer gw.logger.Debug("WebSocketGateway: disconnected: %v", ws.RemoteAddr())
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		c.writePump(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		c.feed(ctx)
+	}()
+
+	c.readPump(ctx)
+	cancel()
+	wg.Wait()
+
+	c.unsubscribeAll()
+}
+
+type wsConn struct {
+	gw     *WebSocketGateway
+	ws     *websocket.Conn
+	cancel context.CancelFunc
+
+	// send is a connection buffer, writePump writes messages from it
+	send chan []byte
+
+	// mu guards rates, which follows subscribed currency pairs
+	mu    sync.Mutex
+	rates *rateFeed
+}
+
+// readPump handles client requests until connection is closed
+func (c *wsConn) readPump(ctx context.Context) {
+	c.ws.SetReadLimit(wsMaxReadBytes)
+	pongWait := c.gw.pingPeriod * 2
+	_ = c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var req WebSocketRequest
+		if err := c.ws.ReadJSON(&req); err != nil {
+			if _, ok := err.(*json.SyntaxError); ok {
+				c.enqueue(WebSocketMessage{Type: "error", Message: "invalid request: " + err.Error()})
+				continue
+			}
+			c.gw.logger.Debug("wsConn.readPump: %v", err)
+			return
+		}
+
+		switch req.Op {
+		case wsOpSubscribe:
+			c.subscribe(req.Pairs)
+		case wsOpUnsubscribe:
+			c.unsubscribe(req.Pairs)
+		default:
+			c.enqueue(WebSocketMessage{Type: "error", Message: fmt.Sprintf("unknown op: '%s'", req.Op)})
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// writePump writes buffered messages and pings client
+func (c *wsConn) writePump(ctx context.Context) {
+	ticker := time.NewTicker(c.gw.pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.close(websocket.CloseNormalClosure, "")
+			return
+		case msg := <-c.send:
+			_ = c.ws.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.ws.WriteMessage(websocket.TextMessage, msg); err != nil {
+				c.gw.logger.Debug("wsConn.writePump: %v", err)
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				c.gw.logger.Debug("wsConn.writePump: ping: %v", err)
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		}
+	}
+}
+
+// feed moves new rates of subscribed currency pairs to send buffer
+func (c *wsConn) feed(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.rates.notify:
+		}
+
+		c.mu.Lock()
+		ok := c.push()
+		c.mu.Unlock()
+		if !ok {
+			return
+		}
+	}
+}
+
+// push enqueues rates created after cursor of rates feed. Must be called with mu held.
+func (c *wsConn) push() bool {
+	err := c.rates.next(func(currencyPair string, event feedEvent, rate *v1.ExchangeRate) error {
+		msg := WebSocketMessage{Type: "rate", CurrencyPair: currencyPair, Rate: rate}
+		switch event {
+		case feedGap:
+			msg.Type = "gap"
+		case feedEnd:
+			// subscription to deleted currency pair is dropped, other subscriptions go on
+			msg.Type = "end"
+		}
+		if !c.enqueue(msg) {
+			return errNotEnqueued
+		}
+		return nil
+	})
+	return err == nil
+}
+
+// enqueue puts message to send buffer. Slow consumer is disconnected when buffer is full.
+func (c *wsConn) enqueue(msg WebSocketMessage) bool {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		c.gw.logger.Error("wsConn.enqueue: %v", err)
+		return false
+	}
+
+	select {
+	case c.send <- b:
+		return true
+	default:
+		c.gw.logger.Warn("WebSocketGateway: slow consumer %v is disconnected", c.ws.RemoteAddr())
+		c.close(websocket.ClosePolicyViolation, "slow consumer")
+		return false
+	}
+}
+
+// close sends close message and closes connection, which stops all pumps
+func (c *wsConn) close(code int, text string) {
+	if code != websocket.CloseAbnormalClosure {
+		_ = c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(wsWriteWait))
+	}
+	c.cancel()
+	_ = c.ws.Close()
+}
+
+// subscribe starts sending rates created after the newest cached rate of currency pairs
+func (c *wsConn) subscribe(pairs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	subscribed := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		if c.rates.following(p) {
+			continue
+		}
+		if err := c.rates.follow(p); err != nil {
+			c.enqueue(WebSocketMessage{Type: "error", CurrencyPair: p, Message: fmt.Sprintf("service doesn't generate values for '%s'", p)})
+			continue
+		}
+		subscribed = append(subscribed, p)
+	}
+
+	c.enqueue(WebSocketMessage{Type: "subscribed", Pairs: subscribed})
+}
+
+func (c *wsConn) unsubscribe(pairs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unsubscribed := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		if c.rates.unfollow(p) {
+			unsubscribed = append(unsubscribed, p)
+		}
+	}
+
+	c.enqueue(WebSocketMessage{Type: "unsubscribed", Pairs: unsubscribed})
+}
+
+func (c *wsConn) unsubscribeAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rates.close()
+}
@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"context"
+	"github.com/gorilla/websocket"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func dialGateway(t *testing.T, gw *WebSocketGateway) *websocket.Conn {
+	srv := httptest.NewServer(gw)
+	t.Cleanup(srv.Close)
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+	require.Nil(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	return ws
+}
+
+func readMessage(t *testing.T, ws *websocket.Conn) WebSocketMessage {
+	var msg WebSocketMessage
+	require.Nil(t, ws.ReadJSON(&msg))
+	return msg
+}
+
+func TestWebSocketGateway(t *testing.T) {
+	// one simulated second every 10 milliseconds
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 100, 0),
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx, NewFixedScheduleFunc(time.Second))
+
+	ws := dialGateway(t, NewWebSocketGateway(g, 0, 0, logger.New(logger.Info)))
+
+	require.Nil(t, ws.WriteJSON(WebSocketRequest{Op: "subscribe", Pairs: []string{"EURUSD", "GBPUSD"}}))
+	require.Equal(t, WebSocketMessage{Type: "error", CurrencyPair: "GBPUSD", Message: "service doesn't generate values for 'GBPUSD'"}, readMessage(t, ws))
+	require.Equal(t, WebSocketMessage{Type: "subscribed", Pairs: []string{"EURUSD"}}, readMessage(t, ws))
+
+	var last time.Time
+	for i := 0; i < 3; i++ {
+		msg := readMessage(t, ws)
+		require.Equal(t, "rate", msg.Type)
+		require.Equal(t, "EURUSD", msg.CurrencyPair)
+		require.True(t, msg.Rate.Time.After(last))
+		last = msg.Rate.Time
+	}
+
+	require.Nil(t, ws.WriteJSON(WebSocketRequest{Op: "subscribe", Pairs: []string{"USDJPY"}}))
+	require.Nil(t, ws.WriteJSON(WebSocketRequest{Op: "unsubscribe", Pairs: []string{"EURUSD"}}))
+	for {
+		msg := readMessage(t, ws)
+		if msg.Type == "unsubscribed" {
+			require.Equal(t, []string{"EURUSD"}, msg.Pairs)
+			break
+		}
+	}
+	for i := 0; i < 3; i++ {
+		msg := readMessage(t, ws)
+		require.Equal(t, "rate", msg.Type)
+		require.Equal(t, "USDJPY", msg.CurrencyPair)
+	}
+
+	require.Nil(t, ws.WriteJSON(WebSocketRequest{Op: "trade"}))
+	for {
+		msg := readMessage(t, ws)
+		if msg.Type == "error" {
+			require.Equal(t, "unknown op: 'trade'", msg.Message)
+			break
+		}
+	}
+}
+
+func TestWebSocketGateway_Ping(t *testing.T) {
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    RealClock{},
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	ws := dialGateway(t, NewWebSocketGateway(g, 0, 10*time.Millisecond, logger.New(logger.Info)))
+
+	pings := make(chan struct{}, 10)
+	ws.SetPingHandler(func(string) error {
+		pings <- struct{}{}
+		return ws.WriteControl(websocket.PongMessage, nil, time.Now().Add(time.Second))
+	})
+	go func() {
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-pings:
+		case <-time.After(time.Second):
+			t.Fatal("no ping")
+		}
+	}
+}
+
+func TestWebSocketGateway_SlowConsumer(t *testing.T) {
+	// rates are generated every second from epoch to epoch+9s
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 0, 9*time.Second),
+		NewCache: NewLimitedCacheFunc(10),
+		Logger:   logger.New(logger.Info),
+	})
+	ws := dialGateway(t, NewWebSocketGateway(g, 2, 0, logger.New(logger.Info)))
+
+	require.Nil(t, ws.WriteJSON(WebSocketRequest{Op: "subscribe", Pairs: []string{"EURUSD"}}))
+	// wait for subscription before generating
+	require.Equal(t, "subscribed", readMessage(t, ws).Type)
+
+	// all 10 rates are pushed at once to buffer of 2 messages
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	for {
+		_, _, err := ws.ReadMessage()
+		if err != nil {
+			require.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), err)
+			return
+		}
+	}
+}
+
+func TestWebSocketGateway_DeletedPair(t *testing.T) {
+	// one simulated second every 10 milliseconds
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 100, 0),
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx, NewFixedScheduleFunc(time.Second))
+	ws := dialGateway(t, NewWebSocketGateway(g, 0, 0, logger.New(logger.Info)))
+
+	require.Nil(t, ws.WriteJSON(WebSocketRequest{Op: "subscribe", Pairs: []string{"EURUSD", "USDJPY"}}))
+	require.Equal(t, WebSocketMessage{Type: "subscribed", Pairs: []string{"EURUSD", "USDJPY"}}, readMessage(t, ws))
+
+	// deleted pair gets end message and is dropped, other pairs go on
+	require.Nil(t, g.DeletePair("EURUSD"))
+	for {
+		msg := readMessage(t, ws)
+		if msg.Type == "end" {
+			require.Equal(t, WebSocketMessage{Type: "end", CurrencyPair: "EURUSD"}, msg)
+			break
+		}
+		require.Equal(t, "rate", msg.Type)
+	}
+	msg := readMessage(t, ws)
+	require.Equal(t, "rate", msg.Type)
+	require.Equal(t, "USDJPY", msg.CurrencyPair)
+
+	// subscription is dropped, connection stays open
+	require.Nil(t, ws.WriteJSON(WebSocketRequest{Op: "unsubscribe", Pairs: []string{"EURUSD", "USDJPY"}}))
+	for msg = readMessage(t, ws); msg.Type == "rate"; msg = readMessage(t, ws) {
+		require.Equal(t, "USDJPY", msg.CurrencyPair)
+	}
+	require.Equal(t, WebSocketMessage{Type: "unsubscribed", Pairs: []string{"USDJPY"}}, msg)
+}
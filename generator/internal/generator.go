@@ -3,103 +3,554 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"generator/internal/api/http/v1"
 	"generator/pkg/cache"
 	"github.com/mazitovt/logger"
+	"math/rand"
+	"mtsbank/pkg/calendar"
+	"mtsbank/pkg/decimal"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
 
 var _ v1.ServerInterface = (*SimplePriceGenerator)(nil)
 
+var ErrUnknownCurrencyPair = errors.New("service doesn't generate values for currency pair")
+
+const (
+	// headerRatesGap is set when rates requested by since cursor were evicted from cache
+	headerRatesGap = "X-Rates-Gap"
+	// headerRatesGapPairs lists currency pairs of batch, rates of which requested by cursors were evicted from cache
+	headerRatesGapPairs = "X-Rates-Gap-Pairs"
+)
+
+// allPairs requests rates of all generated currency pairs
+const allPairs = "all"
+
 type SimplePriceGenerator struct {
-	cache  map[string]cache.Cache[v1.ExchangeRate]
-	f      GeneratorFunc
-	logger logger.Logger
-	pool   sync.Pool
+	// mu guards pairs and ctx
+	mu    sync.RWMutex
+	pairs map[string]*pairGenerator
+	// ctx is a context of running Start, nil when generation isn't running
+	ctx      context.Context
+	schedule ScheduleFunc
+	// wg counts running generate goroutines
+	wg           sync.WaitGroup
+	clockStopped chan struct{}
+	stopOnce     sync.Once
+
+	// instruments keep scales of currency pairs, including pairs that aren't generated yet
+	instruments *InstrumentRegistry
+	scenario    *ScenarioPlayer
+	audit       *AuditLog
+	httpFaults  *HTTPFaults
+	backfill    *Backfill
+	f           GeneratorFunc
+	quote       QuoteFunc
+	faults      FaultFunc
+	clock       Clock
+	newCache    CacheFunc
+	logger      logger.Logger
+	pool        sync.Pool
+}
+
+// pairGenerator is a cache of currency pair, number of digits after decimal point in its prices, live changes by admins,
+// the first live price, cancel of its generate goroutine and channel closed when the goroutine exits
+type pairGenerator struct {
+	cache   cache.Cache[v1.ExchangeRate]
+	scale   int32
+	control pairControl
+	start   pathStart
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// CacheFunc creates cache of rates of currency pair
+type CacheFunc func() cache.Cache[v1.ExchangeRate]
+
+// NewLimitedCacheFunc creates caches of the last size rates
+func NewLimitedCacheFunc(size uint64) CacheFunc {
+	return func() cache.Cache[v1.ExchangeRate] {
+		return cache.NewLimitedCache[v1.ExchangeRate](size, rateTime)
+	}
+}
+
+// NewWindowCacheFunc creates caches of rates created within window before now of clock, but no more than size rates.
+// Zero size doesn't limit number of rates.
+func NewWindowCacheFunc(window time.Duration, size uint64, clock Clock) CacheFunc {
+	return func() cache.Cache[v1.ExchangeRate] {
+		return cache.NewWindowCache[v1.ExchangeRate](window, size, rateTime, clock.Now)
+	}
+}
+
+// NewRingCacheFunc creates caches of the last size rates, readers of which never block generation
+func NewRingCacheFunc(size uint64) CacheFunc {
+	return func() cache.Cache[v1.ExchangeRate] {
+		return cache.NewRingCache[v1.ExchangeRate](size, rateTime)
+	}
 }
 
-func NewSimplePriceGenerator(currencyPairs []string, f GeneratorFunc, cacheSize uint64, logger logger.Logger) *SimplePriceGenerator {
+func rateTime(r v1.ExchangeRate) time.Time {
+	return r.Time
+}
 
-	m := map[string]cache.Cache[v1.ExchangeRate]{}
+func newPairGenerator(c cache.Cache[v1.ExchangeRate], scale int32) *pairGenerator {
+	return &pairGenerator{
+		cache:   c,
+		scale:   scale,
+		control: pairControl{factor: 1},
+	}
+}
+
+// GeneratorOptions configure SimplePriceGenerator, nil Quote and Faults are replaced with defaults
+type GeneratorOptions struct {
+	// Instruments keep precisions of currency pairs, missing instrument has zero precision
+	Instruments *InstrumentRegistry
+	// Prices are mid prices, fixed-point numbers with precision of currency pair instrument
+	Prices GeneratorFunc
+	// Quote quotes mid prices, nil quotes mid prices only
+	Quote QuoteFunc
+	// Faults inject anomalies into rates, nil injects nothing
+	Faults FaultFunc
+	Clock  Clock
+	// NewCache creates cache of rates of each currency pair
+	NewCache CacheFunc
+	Logger   logger.Logger
+}
+
+// NewSimplePriceGenerator creates generator of rates of currency pairs
+func NewSimplePriceGenerator(currencyPairs []string, opts GeneratorOptions) *SimplePriceGenerator {
+	if opts.Quote == nil {
+		opts.Quote = func(string) QuoteModel { return MidQuote{} }
+	}
+	if opts.Faults == nil {
+		opts.Faults = func(string) FaultModel { return NoFaults{} }
+	}
+
+	m := map[string]*pairGenerator{}
 	for _, p := range currencyPairs {
-		m[p] = cache.NewLimitedCache[v1.ExchangeRate](cacheSize)
+		in, _ := opts.Instruments.Get(p)
+		m[p] = newPairGenerator(opts.NewCache(), in.Precision)
 	}
 
 	return &SimplePriceGenerator{
-		cache:  m,
-		f:      f,
-		logger: logger,
+		pairs:        m,
+		clockStopped: make(chan struct{}),
+		instruments:  opts.Instruments,
+		scenario:     NewScenarioPlayer(opts.Instruments),
+		audit:        NewAuditLog(auditLogSize, opts.Logger),
+		httpFaults:   NewHTTPFaults(rand.New(rand.NewSource(time.Now().UnixNano())), opts.Logger),
+		f:            opts.Prices,
+		quote:        opts.Quote,
+		faults:       opts.Faults,
+		clock:        opts.Clock,
+		newCache:     opts.NewCache,
+		logger:       opts.Logger,
 		pool: sync.Pool{New: func() any {
-			return make([]v1.ExchangeRate, 0, cacheSize)
+			return make([]v1.ExchangeRate, 0)
 		}},
 	}
 }
 
-// Start generates new rates until context is Done
-func (s *SimplePriceGenerator) Start(ctx context.Context, period time.Duration) {
+// Start generates new rates by schedules of currency pairs until context is Done or clock is stopped.
+// Currency pairs added while Start is running are generated too.
+func (s *SimplePriceGenerator) Start(ctx context.Context, schedule ScheduleFunc) {
 	loggerLine := "SimplePriceGenerator.Start: "
 	s.logger.Debug(loggerLine + "start")
 	defer s.logger.Debug(loggerLine + "end")
 
-	wg := sync.WaitGroup{}
-	wg.Add(len(s.cache))
-	for cur, c := range s.cache {
-		go func(cur string, c cache.Cache[v1.ExchangeRate]) {
-			defer wg.Done()
-			s.generate(ctx, cur, c, period)
-		}(cur, c)
+	s.mu.Lock()
+	s.ctx, s.schedule = ctx, schedule
+	// pairs start together, though clock of zero scale moves as soon as the first of them is generated
+	from := s.clock.Now()
+	for cur, p := range s.pairs {
+		s.run(cur, p, from)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+	case <-s.clockStopped:
 	}
-	wg.Wait()
+
+	// no goroutines are started after ctx is reset, so wg.Wait doesn't race with wg.Add
+	s.mu.Lock()
+	s.ctx = nil
+	s.mu.Unlock()
+
+	s.wg.Wait()
 	s.logger.Info("Generating stopped")
 }
 
-func (s *SimplePriceGenerator) GetRatesCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string) {
-	v, ok := s.cache[currencyPair]
+// run starts generate goroutine of currency pair, which rates are created from from. Must be called with mu held.
+func (s *SimplePriceGenerator) run(cur string, p *pairGenerator, from time.Time) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	p.cancel, p.done = cancel, make(chan struct{})
+	r := &pairRun{
+		cur:      cur,
+		scale:    p.scale,
+		calendar: s.instruments.Calendar(cur),
+		schedule: s.schedule(cur),
+		quote:    s.quote(cur),
+		faults:   s.faults(cur),
+	}
+
+	s.wg.Add(1)
+	go func(done chan struct{}) {
+		defer s.wg.Done()
+		defer close(done)
+		defer cancel()
+		s.generate(ctx, p, r, from)
+	}(p.done)
+}
+
+// pairCache returns cache of currency pair
+func (s *SimplePriceGenerator) pairCache(currencyPair string) (cache.Cache[v1.ExchangeRate], bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.pairs[currencyPair]
 	if !ok {
+		return nil, false
+	}
+	return p.cache, true
+}
+
+// pairSnapshot returns snapshot of all cached rates of currency pair, if its cache keeps one
+func (s *SimplePriceGenerator) pairSnapshot(currencyPair string) (*ratesSnapshot, bool) {
+	c, ok := s.pairCache(currencyPair)
+	if !ok {
+		return nil, false
+	}
+	sc, ok := c.(*snapshotCache)
+	if !ok {
+		return nil, false
+	}
+	return sc.Snapshot()
+}
+
+func (s *SimplePriceGenerator) GetRatesCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string, params v1.GetRatesCurrencyPairParams) {
+	ranged := params.From != nil || params.To != nil
+	// all rates are served without encoding
+	if params.Since == nil && params.Limit == nil && !ranged {
+		if snapshot, ok := s.pairSnapshot(currencyPair); ok {
+			snapshot.serve(w, r)
+			return
+		}
+	}
+	if ranged && params.Since != nil {
+		s.writeError(w, http.StatusBadRequest, "since can't be used with from and to")
+		return
+	}
+	if params.From != nil && params.To != nil && params.To.Before(*params.From) {
+		s.writeError(w, http.StatusBadRequest, "to is before from")
+		return
+	}
+
+	out := s.pool.Get().([]v1.ExchangeRate)
+	out = out[:0]
+	defer func() { s.pool.Put(out[:0]) }()
+
+	var gap bool
+	var err error
+	if ranged {
+		out, gap, err = s.ratesRange(currencyPair, params.From, params.To, params.Limit, out)
+	} else {
+		out, gap, err = s.rates(currencyPair, params.Since, params.Limit, out)
+	}
+	if err != nil {
 		s.writeError(w, http.StatusNotFound, fmt.Sprintf("service doesn't generate values for '%s'", currencyPair))
 		return
 	}
 
+	if gap {
+		w.Header().Set(headerRatesGap, "true")
+	}
+
 	// content is set to application/json only that order
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	out := s.pool.Get().([]v1.ExchangeRate)
-	out = out[:0]
-	defer s.pool.Put(out)
+	if err = json.NewEncoder(w).Encode(out); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
 
-	out = v.Fill(out)
+// GetRatesBatch returns rates of several currency pairs created after their cursors, or after since if pair has no cursor
+func (s *SimplePriceGenerator) GetRatesBatch(w http.ResponseWriter, r *http.Request, params v1.GetRatesBatchParams) {
+	pairs := params.Pairs
+	if len(pairs) == 1 && pairs[0] == allPairs {
+		pairs = s.Pairs()
+	}
 
-	err := json.NewEncoder(w).Encode(out)
-	if err != nil {
+	cursor := streamCursor{}
+	if params.Cursor != nil && *params.Cursor != "" {
+		var err error
+		if cursor, err = parseStreamCursor(*params.Cursor); err != nil {
+			s.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid cursor: %s", *params.Cursor))
+			return
+		}
+	}
+
+	batch := v1.RatesBatch{AdditionalProperties: make(map[string][]v1.ExchangeRate, len(pairs))}
+	var gaps []string
+	for _, p := range pairs {
+		since := params.Since
+		if t, ok := cursor[p]; ok {
+			since = &t
+		}
+
+		// rates of pairs are kept until response is encoded, so they aren't pooled
+		out, gap, err := s.rates(p, since, params.Limit, []v1.ExchangeRate{})
+		if err != nil {
+			s.writeError(w, http.StatusNotFound, fmt.Sprintf("service doesn't generate values for '%s'", p))
+			return
+		}
+		batch.Set(p, out)
+		if gap {
+			gaps = append(gaps, p)
+		}
+	}
+
+	if len(gaps) != 0 {
+		w.Header().Set(headerRatesGapPairs, strings.Join(gaps, ","))
+	}
+
+	// content is set to application/json only that order
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(batch); err != nil {
 		s.logger.Error("Encode.Err: %v", err)
 	}
 }
 
-func (s *SimplePriceGenerator) generate(ctx context.Context, cur string, cache cache.Cache[v1.ExchangeRate], period time.Duration) {
-	ticker := time.NewTicker(period)
-	defer ticker.Stop()
+// rates appends to buffer cached rates of currency pair created after since, but no more than limit.
+// Nil since and limit aren't applied. Reports whether rates created after since were evicted from cache.
+func (s *SimplePriceGenerator) rates(currencyPair string, since *time.Time, limit *int32, buffer []v1.ExchangeRate) ([]v1.ExchangeRate, bool, error) {
+	c, ok := s.pairCache(currencyPair)
+	if !ok {
+		return buffer, false, ErrUnknownCurrencyPair
+	}
+
+	var from time.Time
+	if since != nil {
+		from = *since
+	}
+	out := c.Since(from, buffer)
+
+	gap := false
+	if since != nil {
+		gap = ratesGap(c, out, *since)
+	}
+
+	if limit != nil && int(*limit) < len(out) {
+		out = out[:*limit]
+	}
+
+	return out, gap, nil
+}
+
+// ratesRange copies rates created from from until to inclusive to buffer, nil from or to leaves range open.
+// Reports whether rates created at or after from were evicted.
+func (s *SimplePriceGenerator) ratesRange(currencyPair string, from, to *time.Time, limit *int32, buffer []v1.ExchangeRate) ([]v1.ExchangeRate, bool, error) {
+	c, ok := s.pairCache(currencyPair)
+	if !ok {
+		return buffer, false, ErrUnknownCurrencyPair
+	}
+
+	var start time.Time
+	// the farthest time leaves range open
+	end := time.Unix(1<<62, 0)
+	if from != nil {
+		start = *from
+	}
+	if to != nil {
+		end = *to
+	}
+	out := c.Range(start, end, buffer)
+
+	gap := false
+	if from != nil {
+		// rate created at from is lost too
+		gap = ratesGap(c, out, from.Add(-time.Nanosecond))
+	}
+
+	if limit != nil && int(*limit) < len(out) {
+		out = out[:*limit]
+	}
+
+	return out, gap, nil
+}
+
+// generate puts new rates of r to cache of p at times of schedule while trading calendar of currency pair is open.
+// Prices of the model are changed by played scenario and by admins. Anomalies injected by faults are logged with fault tag.
+func (s *SimplePriceGenerator) generate(ctx context.Context, p *pairGenerator, r *pairRun, from time.Time) {
+	cur := r.cur
+	if !r.start(from) {
+		s.finish(ctx, cur)
+		return
+	}
+	if _, timed := r.schedule.(TimedSchedule); timed && !s.waitUntil(ctx, cur, r.next) {
+		return
+	}
+
+	rates := make([]v1.ExchangeRate, 0, 2)
 	for {
-		t := time.Now().Round(time.Microsecond)
-		rate := s.f(cur)
-		exRate := v1.ExchangeRate{
-			Time: t,
-			Rate: rate,
+		// schedule continues when market opens
+		if !r.open() {
+			s.logger.Debug("market is closed: currency=%v, calendar=%v, until=%v", cur, r.calendar.Name(), r.next)
+			if !s.waitUntil(ctx, cur, r.next) {
+				return
+			}
 		}
+
+		mid, ok := s.scenario.Apply(cur, r.next, s.f(cur))
+		if ok {
+			mid, ok = p.control.apply(mid)
+		}
+		if !ok {
+			s.logger.Debug("currency=%v is halted", cur)
+			if !s.wait(ctx, r) {
+				return
+			}
+			continue
+		}
+
+		var (
+			exRate v1.ExchangeRate
+			fault  FaultKind
+		)
+		exRate, fault, rates = r.step(mid, rates[:0])
+		p.start.set(exRate.Time, mid)
 		s.logger.Debug("currency=%v, rate=%v", cur, exRate)
-		cache.Put(exRate)
-		select {
-		case <-ctx.Done():
+		if fault != "" {
+			s.logger.Debug("fault=%v, currency=%v, rate=%v, put=%v", fault, cur, exRate, rates)
+		}
+		for _, rate := range rates {
+			p.cache.Put(rate)
+		}
+
+		if !s.wait(ctx, r) {
 			return
-		case <-ticker.C:
-			continue
 		}
 	}
 }
 
+// pairRun is a generation of rates of currency pair by its schedule, quote and faults.
+// SimplePriceGenerator and TickExporter make the same steps of it, only the first one waits for clock between them.
+type pairRun struct {
+	cur      string
+	scale    int32
+	calendar *calendar.Calendar
+	schedule Schedule
+	quote    QuoteModel
+	faults   FaultModel
+	// next is a time of the next rate
+	next time.Time
+}
+
+// start moves next to the first time of schedule at or after from. False means that schedule has no times.
+func (r *pairRun) start(from time.Time) bool {
+	r.next = from
+	if timed, ok := r.schedule.(TimedSchedule); ok {
+		r.next, ok = timed.First(from)
+		return ok
+	}
+	return true
+}
+
+// open moves next to the opening of market, if market is closed at next. False means that market was closed.
+func (r *pairRun) open() bool {
+	open := r.calendar.NextOpen(r.next)
+	if open.Equal(r.next) {
+		return true
+	}
+	r.next = open
+	return false
+}
+
+// step quotes mid price at next and appends the rate with anomalies injected by faults to out
+func (r *pairRun) step(mid int64, out []v1.ExchangeRate) (v1.ExchangeRate, FaultKind, []v1.ExchangeRate) {
+	exRate := newExchangeRate(r.next, mid, r.scale, r.quote)
+	out, fault := r.faults.Inject(exRate, out)
+	return exRate, fault, out
+}
+
+// advance moves next by schedule. False means that schedule is finished.
+func (r *pairRun) advance() bool {
+	d := r.schedule.Next()
+	if d == ScheduleFinished {
+		return false
+	}
+	r.next = r.next.Add(d)
+	return true
+}
+
+// newExchangeRate quotes mid price of time t
+func newExchangeRate(t time.Time, mid int64, scale int32, quote QuoteModel) v1.ExchangeRate {
+	q := quote.Quote(mid)
+	m := decimal.Format(mid, scale)
+	return v1.ExchangeRate{
+		Time:   t.Round(time.Microsecond),
+		Rate:   m,
+		Bid:    decimal.Format(q.Bid, scale),
+		Ask:    decimal.Format(q.Ask, scale),
+		Mid:    m,
+		Volume: q.Volume,
+	}
+}
+
+// wait advances next of r by schedule and waits for it. Reports whether generation continues.
+func (s *SimplePriceGenerator) wait(ctx context.Context, r *pairRun) bool {
+	if !r.advance() {
+		s.finish(ctx, r.cur)
+		return false
+	}
+	return s.waitUntil(ctx, r.cur, r.next)
+}
+
+// finish waits for the end of generation of currency pair with finished schedule
+func (s *SimplePriceGenerator) finish(ctx context.Context, cur string) {
+	s.logger.Debug("schedule finished: currency=%v", cur)
+	select {
+	case <-ctx.Done():
+	case <-s.clockStopped:
+	}
+}
+
+// waitUntil waits for clock to show next. Reports whether generation continues.
+func (s *SimplePriceGenerator) waitUntil(ctx context.Context, cur string, next time.Time) bool {
+	if err := s.clock.WaitUntil(ctx, next); err != nil {
+		if err == ErrClockStopped {
+			s.logger.Info("clock stopped: currency=%v", cur)
+			s.stopOnce.Do(func() { close(s.clockStopped) })
+		}
+		return false
+	}
+	return true
+}
+
+// PlayScenario replaces played scenario, its timelines start at the current time of clock plus start of set.
+// Scenario played before Start on virtual clock starts at epoch of clock, whenever the service has started.
+func (s *SimplePriceGenerator) PlayScenario(set v1.ScenarioSet) error {
+	s.mu.RLock()
+	origin := s.clock.Now()
+	if c, ok := s.clock.(*VirtualClock); ok && s.ctx == nil {
+		origin = c.Epoch()
+	}
+	s.mu.RUnlock()
+
+	if err := s.scenario.Play(set, origin); err != nil {
+		return err
+	}
+	s.logger.Info("scenario played: run=%v", set.Run)
+	return nil
+}
+
 func (s *SimplePriceGenerator) writeError(w http.ResponseWriter, code int, message string) {
 	petErr := v1.Error{
 		Code:    int32(code),
@@ -111,3 +562,15 @@ func (s *SimplePriceGenerator) writeError(w http.ResponseWriter, code int, messa
 		s.logger.Error("Encode.Err: %v", err)
 	}
 }
+
+// ratesGap reports whether rates created after since were evicted from cache.
+//
+// Must be called after taking rates since since from c: if a rate is evicted between the calls,
+// it is the oldest of rates and isn't counted as lost.
+func ratesGap(c cache.Cache[v1.ExchangeRate], rates []v1.ExchangeRate, since time.Time) bool {
+	evicted, ok := c.Evicted()
+	if !ok || !evicted.Time.After(since) {
+		return false
+	}
+	return len(rates) == 0 || evicted.Time.Before(rates[0].Time)
+}
@@ -1,30 +1,66 @@
 package internal
 
 import (
+	"encoding/binary"
+	"hash/fnv"
 	"math/rand"
+	"sync"
 	"time"
 )
 
 type GeneratorFunc func(string) int64
 
+// NewExchangeRateFromSeed returns GeneratorFunc that gives every currency pair its own
+// random stream derived from seed and currency pair (see PairSeed).
+//
+// Safe for concurrent use by different currency pairs.
 func NewExchangeRateFromSeed(seed int64) GeneratorFunc {
-	r := rand.New(rand.NewSource(seed))
+	var mu sync.Mutex
+	streams := map[string]*rand.Rand{}
+
 	return func(currencyPair string) int64 {
-		b := []byte(currencyPair)
-		s := int64(0)
-		for _, v := range b {
-			s += int64(v)
+		mu.Lock()
+		r, ok := streams[currencyPair]
+		if !ok {
+			r = rand.New(rand.NewSource(PairSeed(seed, currencyPair)))
+			streams[currencyPair] = r
 		}
-		return r.Int63() % s
+		mu.Unlock()
+
+		return r.Int63() % pairModulo(currencyPair)
 	}
 }
 
 func ExchangeRateFromTime(currencyPair string) int64 {
-	b := []byte(currencyPair)
+	return rand.New(rand.NewSource(time.Now().UnixNano())).Int63() % pairModulo(currencyPair)
+}
+
+// PairSeed derives seed of currency pair stream from global seed.
+// Result doesn't depend on order in which currency pairs are started.
+func PairSeed(seed int64, currencyPair string) int64 {
+	h := fnv.New64a()
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(seed))
+	_, _ = h.Write(b)
+	_, _ = h.Write([]byte(currencyPair))
+	return int64(h.Sum64())
+}
+
+func pairModulo(currencyPair string) int64 {
 	s := int64(0)
-	for _, v := range b {
+	for _, v := range []byte(currencyPair) {
 		s += int64(v)
 	}
+	return s
+}
 
-	return rand.New(rand.NewSource(time.Now().UnixNano())).Int63() % s
+// NewModelGeneratorFunc returns GeneratorFunc that takes prices from models.
+// Currency pairs without model are generated by fallback.
+func NewModelGeneratorFunc(models map[string]PriceModel, fallback GeneratorFunc) GeneratorFunc {
+	return func(currencyPair string) int64 {
+		if m, ok := models[currencyPair]; ok {
+			return m.Next()
+		}
+		return fallback(currencyPair)
+	}
 }
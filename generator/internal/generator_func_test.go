@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// seedStreams pins first values of SEED streams. Changing them breaks reproducibility of existing configs.
+var seedStreams = map[string][]int64{
+	"EURUSD": {4, 147, 256, 103, 329},
+	"USDRUB": {116, 320, 359, 121, 103},
+	"USDJPY": {282, 159, 222, 466, 402},
+}
+
+func TestNewExchangeRateFromSeed(t *testing.T) {
+	f := NewExchangeRateFromSeed(123)
+	for pair, want := range seedStreams {
+		got := make([]int64, len(want))
+		for i := range got {
+			got[i] = f(pair)
+		}
+		require.Equal(t, want, got, pair)
+	}
+}
+
+func TestNewExchangeRateFromSeed_Concurrent(t *testing.T) {
+	f := NewExchangeRateFromSeed(123)
+
+	var mu sync.Mutex
+	res := map[string][]int64{}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(seedStreams))
+	for pair, want := range seedStreams {
+		go func(pair string, n int) {
+			defer wg.Done()
+			got := make([]int64, n)
+			for i := range got {
+				got[i] = f(pair)
+			}
+			mu.Lock()
+			res[pair] = got
+			mu.Unlock()
+		}(pair, len(want))
+	}
+	wg.Wait()
+
+	require.Equal(t, seedStreams, res)
+}
+
+func TestPairSeed(t *testing.T) {
+	require.Equal(t, PairSeed(123, "EURUSD"), PairSeed(123, "EURUSD"))
+	require.NotEqual(t, PairSeed(123, "EURUSD"), PairSeed(123, "USDJPY"))
+	require.NotEqual(t, PairSeed(123, "EURUSD"), PairSeed(124, "EURUSD"))
+
+	m := NewGeometricBrownianMotion(100000, 0, 0.001, rand.New(rand.NewSource(PairSeed(123, "EURUSD"))))
+	want := []int64{100094, 100068, 100057, 100077, 99940}
+	for i := range want {
+		require.Equal(t, want[i], m.Next())
+	}
+}
@@ -2,7 +2,12 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
+	v1 "generator/internal/api/http/v1"
 	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -11,11 +16,16 @@ func TestPriceGenerator(t *testing.T) {
 
 	pairs := []string{"EURUSD", "USDRUB", "USDJPY"}
 	period := 1000 * time.Millisecond
-	g := NewSimplePriceGenerator(pairs, ExchangeRateFromTime, 3, logger.New(logger.Info))
+	g := NewSimplePriceGenerator(pairs, GeneratorOptions{
+		Prices:   ExchangeRateFromTime,
+		Clock:    RealClock{},
+		NewCache: NewLimitedCacheFunc(3),
+		Logger:   logger.New(logger.Info),
+	})
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	go g.Start(ctx, period)
+	go g.Start(ctx, NewFixedScheduleFunc(period))
 
 	time.Sleep(3 * time.Second)
 	cancel()
@@ -23,3 +33,239 @@ func TestPriceGenerator(t *testing.T) {
 	time.Sleep(3 * time.Second)
 
 }
+
+func TestPriceGenerator_Reproducible(t *testing.T) {
+	pairs := []string{"EURUSD", "USDRUB", "USDJPY"}
+
+	run := func() map[string]string {
+		clock := NewVirtualClock(epoch, 0, time.Minute)
+		g := NewSimplePriceGenerator(pairs, GeneratorOptions{
+			Prices:   NewExchangeRateFromSeed(123),
+			Clock:    clock,
+			NewCache: NewLimitedCacheFunc(100),
+			Logger:   logger.New(logger.Info),
+		})
+		g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+		out := map[string]string{}
+		for _, p := range pairs {
+			w := httptest.NewRecorder()
+			g.GetRatesCurrencyPair(w, httptest.NewRequest(http.MethodGet, "/rates/"+p, nil), p, v1.GetRatesCurrencyPairParams{})
+			require.Equal(t, http.StatusOK, w.Code)
+			out[p] = w.Body.String()
+		}
+		return out
+	}
+
+	first := run()
+	require.Equal(t, first, run())
+	require.Contains(t, first["EURUSD"], `{"ask":"4","bid":"4","mid":"4","rate":"4","time":"2022-08-01T00:00:00Z","volume":0}`)
+	require.Contains(t, first["EURUSD"], `"time":"2022-08-01T00:01:00Z"`)
+}
+
+func TestSimplePriceGenerator_GetRatesCurrencyPair_Since(t *testing.T) {
+	// rates are generated every second from epoch to epoch+9s, cache keeps the last 5
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 0, 9*time.Second),
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	at := func(sec int) *time.Time {
+		t := epoch.Add(time.Duration(sec) * time.Second)
+		return &t
+	}
+	limit := func(n int32) *int32 {
+		return &n
+	}
+
+	tests := []struct {
+		name   string
+		params v1.GetRatesCurrencyPairParams
+		times  []int
+		gap    bool
+	}{
+		{
+			name:  "all",
+			times: []int{5, 6, 7, 8, 9},
+		},
+		{
+			name:   "since evicted rate",
+			params: v1.GetRatesCurrencyPairParams{Since: at(3)},
+			times:  []int{5, 6, 7, 8, 9},
+			gap:    true,
+		},
+		{
+			name:   "since last evicted rate",
+			params: v1.GetRatesCurrencyPairParams{Since: at(4)},
+			times:  []int{5, 6, 7, 8, 9},
+		},
+		{
+			name:   "since with limit",
+			params: v1.GetRatesCurrencyPairParams{Since: at(6), Limit: limit(2)},
+			times:  []int{7, 8},
+		},
+		{
+			name:   "since last rate",
+			params: v1.GetRatesCurrencyPairParams{Since: at(9)},
+			times:  []int{},
+		},
+		{
+			name:   "from evicted rate",
+			params: v1.GetRatesCurrencyPairParams{From: at(4)},
+			times:  []int{5, 6, 7, 8, 9},
+			gap:    true,
+		},
+		{
+			name:   "from oldest rate",
+			params: v1.GetRatesCurrencyPairParams{From: at(5)},
+			times:  []int{5, 6, 7, 8, 9},
+		},
+		{
+			name:   "from to",
+			params: v1.GetRatesCurrencyPairParams{From: at(6), To: at(8)},
+			times:  []int{6, 7, 8},
+		},
+		{
+			name:   "to with limit",
+			params: v1.GetRatesCurrencyPairParams{To: at(8), Limit: limit(2)},
+			times:  []int{5, 6},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.GetRatesCurrencyPair(w, httptest.NewRequest(http.MethodGet, "/rates/EURUSD", nil), "EURUSD", tc.params)
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, tc.gap, w.Header().Get(headerRatesGap) == "true")
+
+			var rates []v1.ExchangeRate
+			require.Nil(t, json.NewDecoder(w.Body).Decode(&rates))
+			times := make([]int, len(rates))
+			for i := range rates {
+				times[i] = int(rates[i].Time.Sub(epoch) / time.Second)
+			}
+			require.Equal(t, tc.times, times)
+		})
+	}
+
+	for _, params := range []v1.GetRatesCurrencyPairParams{{Since: at(6), From: at(6)}, {From: at(8), To: at(6)}} {
+		w := httptest.NewRecorder()
+		g.GetRatesCurrencyPair(w, httptest.NewRequest(http.MethodGet, "/rates/EURUSD", nil), "EURUSD", params)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestSimplePriceGenerator_GetRatesCurrencyPair_Window(t *testing.T) {
+	// cache keeps rates created within 5 seconds before now of clock, which shows the newest rate
+	clock := NewVirtualClock(epoch, 0, 19*time.Second)
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    clock,
+		NewCache: NewWindowCacheFunc(5*time.Second, 0, clock),
+		Logger:   logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	since := epoch.Add(10 * time.Second)
+	w := httptest.NewRecorder()
+	g.GetRatesCurrencyPair(w, httptest.NewRequest(http.MethodGet, "/rates/EURUSD", nil), "EURUSD", v1.GetRatesCurrencyPairParams{Since: &since})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "true", w.Header().Get(headerRatesGap))
+
+	var rates []v1.ExchangeRate
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&rates))
+	require.Len(t, rates, 6)
+	require.True(t, rates[0].Time.Equal(epoch.Add(14*time.Second)))
+}
+
+func TestSimplePriceGenerator_GetRatesBatch(t *testing.T) {
+	// rates are generated every second from epoch to epoch+9s, cache keeps the last 5
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Prices:      NewExchangeRateFromSeed(123),
+		Clock:       NewVirtualClock(epoch, 0, 9*time.Second),
+		NewCache:    NewLimitedCacheFunc(5),
+		Logger:      logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	at := func(sec int) *time.Time {
+		t := epoch.Add(time.Duration(sec) * time.Second)
+		return &t
+	}
+	cursor := func(c string) *string {
+		return &c
+	}
+	limit := int32(2)
+
+	tests := []struct {
+		name   string
+		params v1.GetRatesBatchParams
+		times  map[string][]int
+		gaps   string
+		code   int
+	}{
+		{
+			name:   "all",
+			params: v1.GetRatesBatchParams{Pairs: []string{"all"}},
+			times:  map[string][]int{"EURUSD": {5, 6, 7, 8, 9}, "USDJPY": {5, 6, 7, 8, 9}},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "since",
+			params: v1.GetRatesBatchParams{Pairs: []string{"EURUSD"}, Since: at(7)},
+			times:  map[string][]int{"EURUSD": {8, 9}},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "cursor",
+			params: v1.GetRatesBatchParams{Pairs: []string{"EURUSD", "USDJPY"}, Since: at(8), Cursor: cursor("USDJPY=2022-08-01T00:00:03Z")},
+			times:  map[string][]int{"EURUSD": {9}, "USDJPY": {5, 6, 7, 8, 9}},
+			gaps:   "USDJPY",
+			code:   http.StatusOK,
+		},
+		{
+			name:   "limit",
+			params: v1.GetRatesBatchParams{Pairs: []string{"all"}, Cursor: cursor("EURUSD=2022-08-01T00:00:09Z"), Limit: &limit},
+			times:  map[string][]int{"EURUSD": {}, "USDJPY": {5, 6}},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "unknown pair",
+			params: v1.GetRatesBatchParams{Pairs: []string{"EURUSD", "GBPUSD"}},
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "invalid cursor",
+			params: v1.GetRatesBatchParams{Pairs: []string{"EURUSD"}, Cursor: cursor("EURUSD")},
+			code:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.GetRatesBatch(w, httptest.NewRequest(http.MethodGet, "/rates", nil), tc.params)
+			require.Equal(t, tc.code, w.Code)
+			if tc.code != http.StatusOK {
+				return
+			}
+			require.Equal(t, tc.gaps, w.Header().Get(headerRatesGapPairs))
+
+			var batch map[string][]v1.ExchangeRate
+			require.Nil(t, json.NewDecoder(w.Body).Decode(&batch))
+			times := map[string][]int{}
+			for p, rates := range batch {
+				times[p] = make([]int, len(rates))
+				for i := range rates {
+					times[p][i] = int(rates[i].Time.Sub(epoch) / time.Second)
+				}
+			}
+			require.Equal(t, tc.times, times)
+		})
+	}
+}
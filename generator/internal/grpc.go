@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"context"
+	pb "generator/internal/api/grpc/v1"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"mtsbank/pkg/decimal"
+	"time"
+)
+
+var _ pb.GeneratorServer = (*GRPCServer)(nil)
+
+// GRPCServer serves rates of SimplePriceGenerator over gRPC
+type GRPCServer struct {
+	pb.UnimplementedGeneratorServer
+	g      *SimplePriceGenerator
+	logger logger.Logger
+}
+
+func NewGRPCServer(g *SimplePriceGenerator, logger logger.Logger) *GRPCServer {
+	return &GRPCServer{g: g, logger: logger}
+}
+
+func (s *GRPCServer) GetRates(ctx context.Context, req *pb.GetRatesRequest) (*pb.GetRatesResponse, error) {
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be equal or greater than zero")
+	}
+
+	buffer := s.g.pool.Get().([]v1.ExchangeRate)
+	buffer = buffer[:0]
+	defer s.g.pool.Put(buffer)
+
+	var (
+		since *time.Time
+		limit *int32
+	)
+	if req.Since != nil {
+		t := req.Since.AsTime()
+		since = &t
+	}
+	if req.Limit != 0 {
+		limit = &req.Limit
+	}
+
+	out, gap, err := s.g.rates(req.CurrencyPair, since, limit, buffer)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "service doesn't generate values for '%s'", req.CurrencyPair)
+	}
+
+	resp := &pb.GetRatesResponse{
+		Rates: make([]*pb.ExchangeRate, len(out)),
+		Gap:   gap,
+	}
+	for i := range out {
+		resp.Rates[i] = toProto(out[i])
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCServer) StreamRates(req *pb.StreamRatesRequest, stream pb.Generator_StreamRatesServer) error {
+	loggerLine := "GRPCServer.StreamRates: "
+
+	if len(req.CurrencyPairs) == 0 {
+		return status.Error(codes.InvalidArgument, "currency pairs are required")
+	}
+
+	// stream starts after the newest cached rate
+	feed := s.g.newRateFeed(nil)
+	defer feed.close()
+	for _, p := range req.CurrencyPairs {
+		if err := feed.follow(p); err != nil {
+			return status.Errorf(codes.NotFound, "service doesn't generate values for '%s'", p)
+		}
+	}
+
+	s.logger.Debug(loggerLine+"start: %v", req.CurrencyPairs)
+	defer s.logger.Debug(loggerLine+"end: %v", req.CurrencyPairs)
+
+	for {
+		err := feed.next(func(currencyPair string, event feedEvent, rate *v1.ExchangeRate) error {
+			switch event {
+			case feedGap:
+				return stream.Send(&pb.StreamRatesResponse{CurrencyPair: currencyPair, Gap: true})
+			case feedEnd:
+				// deleted currency pair is dropped, other pairs go on
+				return stream.Send(&pb.StreamRatesResponse{CurrencyPair: currencyPair, End: true})
+			}
+			return stream.Send(&pb.StreamRatesResponse{CurrencyPair: currencyPair, Rate: toProto(*rate)})
+		})
+		if err != nil {
+			return err
+		}
+
+		// stream ends when all its currency pairs are deleted
+		if len(feed.pairs) == 0 {
+			return nil
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-feed.notify:
+		}
+	}
+}
+
+// toProto converts decimal prices of r to fixed-point numbers
+func toProto(r v1.ExchangeRate) *pb.ExchangeRate {
+	// prices are formatted by generator with the same scale, so they are valid
+	mid, scale, _ := decimal.Parse(r.Mid)
+	bid, _, _ := decimal.Parse(r.Bid)
+	ask, _, _ := decimal.Parse(r.Ask)
+
+	return &pb.ExchangeRate{
+		Time:   timestamppb.New(r.Time),
+		Rate:   mid,
+		Bid:    bid,
+		Ask:    ask,
+		Mid:    mid,
+		Volume: r.Volume,
+		Scale:  scale,
+	}
+}
@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"context"
+	pb "generator/internal/api/grpc/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func dialGRPC(t *testing.T, g *SimplePriceGenerator) pb.GeneratorClient {
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	pb.RegisterGeneratorServer(gs, NewGRPCServer(g, logger.New(logger.Info)))
+	go func() {
+		_ = gs.Serve(lis)
+	}()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewGeneratorClient(conn)
+}
+
+func TestGRPCServer_GetRates(t *testing.T) {
+	// rates are generated every second from epoch to epoch+9s, cache keeps the last 5
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 0, 9*time.Second),
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	client := dialGRPC(t, g)
+	ctx := context.Background()
+
+	resp, err := client.GetRates(ctx, &pb.GetRatesRequest{CurrencyPair: "EURUSD"})
+	require.Nil(t, err)
+	require.Len(t, resp.Rates, 5)
+	require.False(t, resp.Gap)
+	require.Equal(t, epoch.Add(5*time.Second), resp.Rates[0].Time.AsTime())
+
+	resp, err = client.GetRates(ctx, &pb.GetRatesRequest{CurrencyPair: "EURUSD", Since: timestamppb.New(epoch.Add(3 * time.Second)), Limit: 2})
+	require.Nil(t, err)
+	require.Len(t, resp.Rates, 2)
+	require.True(t, resp.Gap)
+
+	_, err = client.GetRates(ctx, &pb.GetRatesRequest{CurrencyPair: "GBPUSD"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCServer_StreamRates(t *testing.T) {
+	// one simulated second every 10 milliseconds
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 100, 0),
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx, NewFixedScheduleFunc(time.Second))
+
+	client := dialGRPC(t, g)
+
+	streamCtx, streamCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer streamCancel()
+	stream, err := client.StreamRates(streamCtx, &pb.StreamRatesRequest{CurrencyPairs: []string{"EURUSD", "USDJPY"}})
+	require.Nil(t, err)
+
+	seen := map[string]bool{}
+	for i := 0; i < 6; i++ {
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		require.NotNil(t, resp.Rate)
+		seen[resp.CurrencyPair] = true
+	}
+	require.Equal(t, map[string]bool{"EURUSD": true, "USDJPY": true}, seen)
+
+	streamCancel()
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	require.Equal(t, codes.Canceled, status.Code(err))
+
+	stream, err = client.StreamRates(context.Background(), &pb.StreamRatesRequest{CurrencyPairs: []string{"GBPUSD"}})
+	require.Nil(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCServer_StreamRates_DeletedPair(t *testing.T) {
+	// one simulated second every 10 milliseconds
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 100, 0),
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx, NewFixedScheduleFunc(time.Second))
+
+	client := dialGRPC(t, g)
+
+	streamCtx, streamCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer streamCancel()
+	stream, err := client.StreamRates(streamCtx, &pb.StreamRatesRequest{CurrencyPairs: []string{"EURUSD", "USDJPY"}})
+	require.Nil(t, err)
+	_, err = stream.Recv()
+	require.Nil(t, err)
+
+	// deleted pair gets end response and is dropped, other pairs go on
+	require.Nil(t, g.DeletePair("EURUSD"))
+	for {
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		if resp.End {
+			require.Equal(t, "EURUSD", resp.CurrencyPair)
+			require.Nil(t, resp.Rate)
+			break
+		}
+	}
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "USDJPY", resp.CurrencyPair)
+	require.NotNil(t, resp.Rate)
+
+	// stream ends when all its pairs are deleted
+	require.Nil(t, g.DeletePair("USDJPY"))
+	for {
+		next, err := stream.Recv()
+		if err != nil {
+			require.Equal(t, io.EOF, err)
+			break
+		}
+		resp = next
+	}
+	require.Equal(t, "USDJPY", resp.CurrencyPair)
+	require.True(t, resp.End)
+}
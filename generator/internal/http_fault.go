@@ -0,0 +1,291 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"math/rand"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrHTTPFault = errors.New("invalid HTTP fault rule")
+
+// adminPathPrefix is a prefix of admin operations, they never get faults
+const adminPathPrefix = "/admin/"
+
+const defaultHTTPFaultCode = http.StatusServiceUnavailable
+
+// httpFaultRule is a validated rule with default values
+type httpFaultRule struct {
+	rule               v1.HTTPFaultRule
+	latency            time.Duration
+	latencyProbability float64
+	errorProbability   float64
+	errorCode          int
+	// truncate, malformed and reset are probabilities of faults
+	truncate, malformed, reset float64
+}
+
+// HTTPFaults injects faults into HTTP responses of routes matching its rules
+type HTTPFaults struct {
+	logger logger.Logger
+
+	// mu guards rules and r
+	mu    sync.Mutex
+	rules []httpFaultRule
+	r     *rand.Rand
+}
+
+func NewHTTPFaults(r *rand.Rand, logger logger.Logger) *HTTPFaults {
+	return &HTTPFaults{r: r, logger: logger}
+}
+
+// SetRules replaces rules, empty rules turn faults off
+func (f *HTTPFaults) SetRules(rules []v1.HTTPFaultRule) error {
+	compiled := make([]httpFaultRule, 0, len(rules))
+	for _, rule := range rules {
+		c, err := newHTTPFaultRule(rule)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rule.Route, err)
+		}
+		compiled = append(compiled, c)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = compiled
+	return nil
+}
+
+// Rules returns rules in order of matching
+func (f *HTTPFaults) Rules() []v1.HTTPFaultRule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rules := make([]v1.HTTPFaultRule, 0, len(f.rules))
+	for _, r := range f.rules {
+		rules = append(rules, r.rule)
+	}
+	return rules
+}
+
+func newHTTPFaultRule(rule v1.HTTPFaultRule) (httpFaultRule, error) {
+	if _, err := path.Match(rule.Route, ""); err != nil || !strings.HasPrefix(rule.Route, "/") {
+		return httpFaultRule{}, ErrHTTPFault
+	}
+
+	c := httpFaultRule{
+		rule:               rule,
+		latencyProbability: 1,
+		errorCode:          defaultHTTPFaultCode,
+		errorProbability:   valueOf(rule.ErrorProbability),
+		truncate:           valueOf(rule.TruncateProbability),
+		malformed:          valueOf(rule.MalformedProbability),
+		reset:              valueOf(rule.ResetProbability),
+	}
+	if rule.Latency != nil {
+		latency, err := time.ParseDuration(*rule.Latency)
+		if err != nil || latency < 0 {
+			return httpFaultRule{}, ErrHTTPFault
+		}
+		c.latency = latency
+	}
+	if rule.LatencyProbability != nil {
+		c.latencyProbability = *rule.LatencyProbability
+	}
+	if rule.ErrorCode != nil {
+		if *rule.ErrorCode < 500 || *rule.ErrorCode > 599 {
+			return httpFaultRule{}, ErrHTTPFault
+		}
+		c.errorCode = int(*rule.ErrorCode)
+	}
+
+	for _, p := range []float64{c.latencyProbability, c.errorProbability, c.truncate, c.malformed, c.reset} {
+		if p < 0 || p > 1 {
+			return httpFaultRule{}, ErrHTTPFault
+		}
+	}
+	if c.errorProbability+c.truncate+c.malformed+c.reset > 1 {
+		return httpFaultRule{}, ErrHTTPFault
+	}
+
+	return c, nil
+}
+
+// httpFault is a fault chosen for request
+type httpFault struct {
+	route   string
+	latency time.Duration
+	kind    string
+	code    int
+}
+
+// choose returns fault of request path, false if path doesn't match rules
+func (f *HTTPFaults) choose(urlPath string) (httpFault, bool) {
+	if strings.HasPrefix(urlPath, adminPathPrefix) {
+		return httpFault{}, false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, rule := range f.rules {
+		if ok, _ := path.Match(rule.rule.Route, urlPath); !ok {
+			continue
+		}
+
+		fault := httpFault{route: rule.rule.Route}
+		if rule.latency > 0 && f.r.Float64() < rule.latencyProbability {
+			fault.latency = rule.latency
+		}
+
+		u := f.r.Float64()
+		switch {
+		case u < rule.errorProbability:
+			fault.kind, fault.code = "error", rule.errorCode
+		case u < rule.errorProbability+rule.truncate:
+			fault.kind = "truncate"
+		case u < rule.errorProbability+rule.truncate+rule.malformed:
+			fault.kind = "malformed"
+		case u < rule.errorProbability+rule.truncate+rule.malformed+rule.reset:
+			fault.kind = "reset"
+		}
+		return fault, true
+	}
+
+	return httpFault{}, false
+}
+
+// Middleware injects faults into responses of requests matching rules
+func (f *HTTPFaults) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault, ok := f.choose(r.URL.Path)
+		if !ok || (fault.latency == 0 && fault.kind == "") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		f.logger.Debug("http fault=%v, latency=%v, route=%v, method=%v, path=%v", fault.kind, fault.latency, fault.route, r.Method, r.URL.Path)
+
+		if fault.latency > 0 {
+			t := time.NewTimer(fault.latency)
+			select {
+			case <-r.Context().Done():
+				t.Stop()
+				return
+			case <-t.C:
+			}
+		}
+
+		switch fault.kind {
+		case "error":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(fault.code)
+			if err := json.NewEncoder(w).Encode(v1.Error{Code: int32(fault.code), Message: "injected fault"}); err != nil {
+				f.logger.Error("Encode.Err: %v", err)
+			}
+		case "reset":
+			resetConnection(w)
+		case "truncate", "malformed":
+			b := &bufferedResponseWriter{ResponseWriter: w, code: http.StatusOK}
+			next.ServeHTTP(b, r)
+			if !b.streaming {
+				b.writeFault(fault.kind)
+			}
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// resetConnection closes connection of w without response, TCP connection is reset
+func resetConnection(w http.ResponseWriter) {
+	if hj, ok := w.(http.Hijacker); ok {
+		if conn, _, err := hj.Hijack(); err == nil {
+			if tcp, ok := conn.(*net.TCPConn); ok {
+				_ = tcp.SetLinger(0)
+			}
+			_ = conn.Close()
+			return
+		}
+	}
+	// server closes connection without response
+	panic(http.ErrAbortHandler)
+}
+
+// bufferedResponseWriter keeps response body to corrupt it. Streams flush body and pass through without faults,
+// as do hijacked connections, e.g. WebSocket upgrades.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	code      int
+	body      bytes.Buffer
+	streaming bool
+}
+
+func (b *bufferedResponseWriter) WriteHeader(code int) {
+	if b.streaming {
+		b.ResponseWriter.WriteHeader(code)
+		return
+	}
+	b.code = code
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	if b.streaming {
+		return b.ResponseWriter.Write(p)
+	}
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponseWriter) Flush() {
+	if !b.streaming {
+		b.streaming = true
+		b.ResponseWriter.WriteHeader(b.code)
+		_, _ = b.ResponseWriter.Write(b.body.Bytes())
+	}
+	if flusher, ok := b.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack takes over connection of wrapped writer, nothing is buffered after it
+func (b *bufferedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := b.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking isn't supported")
+	}
+	conn, rw, err := hj.Hijack()
+	if err == nil {
+		b.streaming = true
+	}
+	return conn, rw, err
+}
+
+// writeFault writes buffered response with truncated or malformed body
+func (b *bufferedResponseWriter) writeFault(kind string) {
+	body := b.body.Bytes()
+	if kind == "truncate" {
+		// declared length is greater than written body, so server closes connection
+		b.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		body = body[:len(body)/2]
+	} else {
+		// the last closing bracket is dropped
+		body = bytes.TrimSpace(body)
+		if len(body) > 0 {
+			body = body[:len(body)-1]
+		}
+		b.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	b.ResponseWriter.WriteHeader(b.code)
+	_, _ = b.ResponseWriter.Write(body)
+}
@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"encoding/json"
+	"generator/internal/api/http/v1"
+	"github.com/gorilla/websocket"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPFaults_SetRules(t *testing.T) {
+	f := NewHTTPFaults(rand.New(rand.NewSource(1)), logger.New(logger.Error))
+
+	probability := func(p float64) *float64 { return &p }
+	latency := func(d string) *string { return &d }
+	code := func(c int32) *int32 { return &c }
+
+	tests := []struct {
+		name string
+		rule v1.HTTPFaultRule
+	}{
+		{name: "relative route", rule: v1.HTTPFaultRule{Route: "rates/*"}},
+		{name: "bad pattern", rule: v1.HTTPFaultRule{Route: "/rates/[EUR"}},
+		{name: "bad latency", rule: v1.HTTPFaultRule{Route: "/rates/*", Latency: latency("fast")}},
+		{name: "probability is greater than one", rule: v1.HTTPFaultRule{Route: "/rates/*", ErrorProbability: probability(1.5)}},
+		{name: "sum of probabilities is greater than one", rule: v1.HTTPFaultRule{Route: "/rates/*", ErrorProbability: probability(0.6), ResetProbability: probability(0.6)}},
+		{name: "not 5xx", rule: v1.HTTPFaultRule{Route: "/rates/*", ErrorCode: code(404)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, f.SetRules([]v1.HTTPFaultRule{tc.rule}), ErrHTTPFault)
+		})
+	}
+
+	rules := []v1.HTTPFaultRule{{Route: "/rates/*", Latency: latency("10ms"), ErrorCode: code(502), ErrorProbability: probability(0.5)}}
+	require.Nil(t, f.SetRules(rules))
+	require.Equal(t, rules, f.Rules())
+}
+
+func TestHTTPFaults_Middleware(t *testing.T) {
+	f := NewHTTPFaults(rand.New(rand.NewSource(1)), logger.New(logger.Error))
+
+	mux := http.NewServeMux()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]v1.ExchangeRate{{Time: epoch, Rate: "1.00000"}})
+	}
+	mux.HandleFunc("/rates/EURUSD", handler)
+	mux.HandleFunc("/admin/audit", handler)
+	mux.HandleFunc("/rates/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("event: EURUSD\ndata: {}\n\n"))
+		w.(http.Flusher).Flush()
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		_ = ws.WriteMessage(websocket.TextMessage, []byte("upgraded"))
+	})
+
+	s := httptest.NewServer(f.Middleware(mux))
+	defer s.Close()
+
+	one := 1.0
+	latency := "50ms"
+	get := func(rule v1.HTTPFaultRule, path string) (*http.Response, []byte, error) {
+		require.Nil(t, f.SetRules([]v1.HTTPFaultRule{rule}))
+		// every request gets a new connection, so reset doesn't break following requests
+		client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+		resp, err := client.Get(s.URL + path)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return resp, body, err
+	}
+
+	t.Run("no faults", func(t *testing.T) {
+		resp, body, err := get(v1.HTTPFaultRule{Route: "/pairs", ErrorProbability: &one}, "/rates/EURUSD")
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.True(t, json.Valid(body))
+	})
+
+	t.Run("admin operations don't get faults", func(t *testing.T) {
+		resp, _, err := get(v1.HTTPFaultRule{Route: "/admin/*", ErrorProbability: &one}, "/admin/audit")
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		start := time.Now()
+		resp, _, err := get(v1.HTTPFaultRule{Route: "/rates/*", Latency: &latency}, "/rates/EURUSD")
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		resp, body, err := get(v1.HTTPFaultRule{Route: "/rates/*", ErrorProbability: &one}, "/rates/EURUSD")
+		require.Nil(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+		var e v1.Error
+		require.Nil(t, json.Unmarshal(body, &e))
+		require.Equal(t, int32(http.StatusServiceUnavailable), e.Code)
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		_, _, err := get(v1.HTTPFaultRule{Route: "/rates/*", TruncateProbability: &one}, "/rates/EURUSD")
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		resp, body, err := get(v1.HTTPFaultRule{Route: "/rates/*", MalformedProbability: &one}, "/rates/EURUSD")
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NotEmpty(t, body)
+		require.False(t, json.Valid(body))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		_, _, err := get(v1.HTTPFaultRule{Route: "/rates/*", ResetProbability: &one}, "/rates/EURUSD")
+		require.NotNil(t, err)
+	})
+
+	t.Run("streams don't get body faults", func(t *testing.T) {
+		resp, body, err := get(v1.HTTPFaultRule{Route: "/rates/*", TruncateProbability: &one}, "/rates/stream")
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "event: EURUSD\ndata: {}\n\n", string(body))
+	})
+
+	t.Run("upgrades don't get body faults", func(t *testing.T) {
+		for _, rule := range []v1.HTTPFaultRule{{Route: "/ws", TruncateProbability: &one}, {Route: "/ws", MalformedProbability: &one}} {
+			require.Nil(t, f.SetRules([]v1.HTTPFaultRule{rule}))
+			ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.URL, "http")+"/ws", nil)
+			require.Nil(t, err)
+			_, msg, err := ws.ReadMessage()
+			require.Nil(t, err)
+			require.Equal(t, "upgraded", string(msg))
+			_ = ws.Close()
+		}
+	})
+}
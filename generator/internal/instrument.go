@@ -0,0 +1,253 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"github.com/go-chi/chi/v5"
+	"github.com/mazitovt/logger"
+	"mtsbank/pkg/calendar"
+	"mtsbank/pkg/decimal"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrUnknownInstrument  = errors.New("currency pair isn't in instrument registry")
+	ErrDisabledInstrument = errors.New("currency pair is disabled in instrument registry")
+	ErrInstrumentPair     = errors.New("currency pair must be base and quote ISO 4217 codes")
+	ErrInstrumentCurrency = errors.New("unknown ISO 4217 currency code")
+	ErrInstrumentPrice    = errors.New("pip size must be a positive decimal with no more digits than precision")
+	ErrInstrumentCalendar = errors.New("unknown trading calendar")
+)
+
+// DefaultCalendar is a trading calendar of instruments, which trade all the time
+const DefaultCalendar = calendar.AlwaysOpen
+
+// alwaysOpen is a calendar of instruments, which aren't in registry
+var alwaysOpen, _ = calendar.Builtin(calendar.AlwaysOpen, nil)
+
+// currencies are active ISO 4217 codes
+var currencies = strings.Fields(`
+	AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL BSD BTN BWP BYN BZD
+	CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD
+	GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT
+	LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR
+	NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP
+	STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD UYU UZS VES VND VUV WST XAF XAG XAU
+	XCD XOF XPD XPF XPT YER ZAR ZMW ZWL
+`)
+
+// InstrumentRegistry keeps reference data of currency pairs and their trading calendars. Nil registry has no instruments.
+type InstrumentRegistry struct {
+	instruments map[string]v1.Instrument
+	calendars   map[string]*calendar.Calendar
+}
+
+// NewInstrumentRegistry validates instruments and creates registry of them.
+// Instruments use built-in calendars without holidays or calendars, which replace built-in ones of the same name.
+func NewInstrumentRegistry(instruments []v1.Instrument, calendars ...*calendar.Calendar) (*InstrumentRegistry, error) {
+	cals := map[string]*calendar.Calendar{}
+	for _, name := range []string{calendar.AlwaysOpen, calendar.FX} {
+		cals[name], _ = calendar.Builtin(name, nil)
+	}
+	for _, c := range calendars {
+		cals[c.Name()] = c
+	}
+
+	m := make(map[string]v1.Instrument, len(instruments))
+	for _, in := range instruments {
+		if err := validateInstrument(in); err != nil {
+			return nil, fmt.Errorf("%s: %w", in.CurrencyPair, err)
+		}
+		if _, ok := cals[in.Calendar]; !ok {
+			return nil, fmt.Errorf("%s: %w: %s", in.CurrencyPair, ErrInstrumentCalendar, in.Calendar)
+		}
+		m[in.CurrencyPair] = in
+	}
+
+	return &InstrumentRegistry{instruments: m, calendars: cals}, nil
+}
+
+// NewInstrument creates enabled instrument of currency pair with pip size of one digit before the last
+// (0.0001 for precision 5, 0.01 for precision 3) and DefaultCalendar
+func NewInstrument(currencyPair string, precision int32) v1.Instrument {
+	in := v1.Instrument{
+		CurrencyPair: currencyPair,
+		PipSize:      decimal.Format(1, 0),
+		Precision:    precision,
+		Calendar:     DefaultCalendar,
+		Enabled:      true,
+	}
+	if precision > 0 {
+		in.PipSize = decimal.Format(1, precision-1)
+	}
+	if len(currencyPair) == 6 {
+		in.Base, in.Quote = currencyPair[:3], currencyPair[3:]
+	}
+	return in
+}
+
+func validateInstrument(in v1.Instrument) error {
+	if in.Base+in.Quote != in.CurrencyPair || in.Base == in.Quote {
+		return ErrInstrumentPair
+	}
+	for _, c := range []string{in.Base, in.Quote} {
+		if i := sort.SearchStrings(currencies, c); i == len(currencies) || currencies[i] != c {
+			return ErrInstrumentCurrency
+		}
+	}
+
+	if in.Precision < 0 || in.Precision > decimal.MaxScale {
+		return decimal.ErrScale
+	}
+	pip, scale, err := decimal.Parse(in.PipSize)
+	if err != nil || pip <= 0 || scale > in.Precision {
+		return ErrInstrumentPrice
+	}
+
+	return nil
+}
+
+// Get returns instrument of currency pair
+func (r *InstrumentRegistry) Get(currencyPair string) (v1.Instrument, bool) {
+	if r == nil {
+		return v1.Instrument{}, false
+	}
+	in, ok := r.instruments[currencyPair]
+	return in, ok
+}
+
+// Check returns ErrUnknownInstrument or ErrDisabledInstrument, if currency pair can't be generated
+func (r *InstrumentRegistry) Check(currencyPair string) error {
+	in, ok := r.Get(currencyPair)
+	switch {
+	case !ok:
+		return ErrUnknownInstrument
+	case !in.Enabled:
+		return ErrDisabledInstrument
+	}
+	return nil
+}
+
+// PipUnits returns pip size of currency pair in units of its prices, one unit for unknown instrument
+func (r *InstrumentRegistry) PipUnits(currencyPair string) int64 {
+	in, ok := r.Get(currencyPair)
+	if !ok {
+		return 1
+	}
+	value, scale, err := decimal.Parse(in.PipSize)
+	if err != nil {
+		return 1
+	}
+	if value, err = decimal.Rescale(value, scale, in.Precision); err != nil {
+		return 1
+	}
+	return value
+}
+
+// Calendar returns trading calendar of currency pair, unknown instrument trades all the time
+func (r *InstrumentRegistry) Calendar(currencyPair string) *calendar.Calendar {
+	in, ok := r.Get(currencyPair)
+	if !ok {
+		return alwaysOpen
+	}
+	return r.calendars[in.Calendar]
+}
+
+// Calendars returns trading calendars in alphabetical order of names
+func (r *InstrumentRegistry) Calendars() []*calendar.Calendar {
+	if r == nil {
+		return []*calendar.Calendar{}
+	}
+
+	out := make([]*calendar.Calendar, 0, len(r.calendars))
+	for _, c := range r.calendars {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+	return out
+}
+
+// Instruments returns instruments in alphabetical order of currency pairs
+func (r *InstrumentRegistry) Instruments() []v1.Instrument {
+	if r == nil {
+		return []v1.Instrument{}
+	}
+
+	out := make([]v1.Instrument, 0, len(r.instruments))
+	for _, in := range r.instruments {
+		out = append(out, in)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CurrencyPair < out[j].CurrencyPair })
+	return out
+}
+
+// InstrumentValidator responds 404, if path parameter currency_pair isn't in registry
+func InstrumentValidator(r *InstrumentRegistry, logger logger.Logger) v1.MiddlewareFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			pair := chi.URLParam(req, "currency_pair")
+			if _, ok := r.Get(pair); pair != "" && !ok {
+				writeUnknownInstrument(w, pair, logger)
+				return
+			}
+			next(w, req)
+		}
+	}
+}
+
+func (s *SimplePriceGenerator) GetInstruments(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(s.instruments.Instruments()); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+func (s *SimplePriceGenerator) GetInstrumentsCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string) {
+	in, ok := s.instruments.Get(currencyPair)
+	if !ok {
+		writeUnknownInstrument(w, currencyPair, s.logger)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(in); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+func (s *SimplePriceGenerator) GetCalendars(w http.ResponseWriter, r *http.Request) {
+	calendars := s.instruments.Calendars()
+	out := make([]v1.Calendar, len(calendars))
+	for i, c := range calendars {
+		out[i] = v1.Calendar{Name: c.Name(), Days: c.Days(), Rollover: c.Rollover().String(), Holidays: c.Holidays()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+// writeUnknownInstrument writes the same 404 response as other services do for unknown currency pair
+func writeUnknownInstrument(w http.ResponseWriter, currencyPair string, logger logger.Logger) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+
+	err := json.NewEncoder(w).Encode(v1.Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("unknown currency pair '%s'", currencyPair),
+	})
+	if err != nil {
+		logger.Error("Encode.Err: %v", err)
+	}
+}
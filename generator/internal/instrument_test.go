@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"encoding/json"
+	"generator/internal/api/http/v1"
+	"github.com/go-chi/chi/v5"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"mtsbank/pkg/calendar"
+	"mtsbank/pkg/decimal"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestNewInstrument(t *testing.T) {
+	require.Equal(t, v1.Instrument{
+		CurrencyPair: "EURUSD",
+		Base:         "EUR",
+		Quote:        "USD",
+		PipSize:      "0.0001",
+		Precision:    5,
+		Calendar:     DefaultCalendar,
+		Enabled:      true,
+	}, NewInstrument("EURUSD", 5))
+	require.Equal(t, "0.01", NewInstrument("USDJPY", 3).PipSize)
+	require.Equal(t, "1", NewInstrument("USDRUB", 0).PipSize)
+}
+
+func TestNewInstrumentRegistry_Errors(t *testing.T) {
+	require.True(t, sort.StringsAreSorted(currencies))
+
+	tests := []struct {
+		name       string
+		instrument v1.Instrument
+		err        error
+	}{
+		{
+			name:       "unknown currency",
+			instrument: NewInstrument("EURXYZ", 5),
+			err:        ErrInstrumentCurrency,
+		},
+		{
+			name:       "same currencies",
+			instrument: NewInstrument("USDUSD", 2),
+			err:        ErrInstrumentPair,
+		},
+		{
+			name:       "base and quote don't match currency pair",
+			instrument: v1.Instrument{CurrencyPair: "EURUSD", Base: "USD", Quote: "EUR", PipSize: "0.0001", Precision: 5},
+			err:        ErrInstrumentPair,
+		},
+		{
+			name:       "too big precision",
+			instrument: NewInstrument("EURUSD", 19),
+			err:        decimal.ErrScale,
+		},
+		{
+			name:       "pip size is finer than precision",
+			instrument: v1.Instrument{CurrencyPair: "EURUSD", Base: "EUR", Quote: "USD", PipSize: "0.0001", Precision: 3},
+			err:        ErrInstrumentPrice,
+		},
+		{
+			name:       "zero pip size",
+			instrument: v1.Instrument{CurrencyPair: "EURUSD", Base: "EUR", Quote: "USD", PipSize: "0", Precision: 5},
+			err:        ErrInstrumentPrice,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewInstrumentRegistry([]v1.Instrument{tc.instrument})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestInstrumentRegistry_Check(t *testing.T) {
+	usdrub := NewInstrument("USDRUB", 2)
+	usdrub.Enabled = false
+	r, err := NewInstrumentRegistry([]v1.Instrument{NewInstrument("EURUSD", 5), usdrub})
+	require.Nil(t, err)
+
+	require.Nil(t, r.Check("EURUSD"))
+	require.ErrorIs(t, r.Check("USDRUB"), ErrDisabledInstrument)
+	require.ErrorIs(t, r.Check("GBPUSD"), ErrUnknownInstrument)
+
+	var nilRegistry *InstrumentRegistry
+	require.ErrorIs(t, nilRegistry.Check("EURUSD"), ErrUnknownInstrument)
+	require.Empty(t, nilRegistry.Instruments())
+}
+
+func TestInstrumentRegistry_Calendar(t *testing.T) {
+	moex, err := calendar.New("MOEX", []string{"MON", "TUE", "WED", "THU", "FRI"}, 7*time.Hour, nil)
+	require.Nil(t, err)
+
+	usdrub := NewInstrument("USDRUB", 2)
+	usdrub.Calendar = "MOEX"
+	_, err = NewInstrumentRegistry([]v1.Instrument{usdrub})
+	require.ErrorIs(t, err, ErrInstrumentCalendar)
+
+	r, err := NewInstrumentRegistry([]v1.Instrument{NewInstrument("EURUSD", 5), usdrub}, moex)
+	require.Nil(t, err)
+	require.Equal(t, moex, r.Calendar("USDRUB"))
+	require.Equal(t, calendar.AlwaysOpen, r.Calendar("EURUSD").Name())
+	// unknown currency pair trades all the time
+	require.Equal(t, calendar.AlwaysOpen, r.Calendar("GBPUSD").Name())
+	require.Len(t, r.Calendars(), 3)
+}
+
+func TestInstrumentValidator(t *testing.T) {
+	instruments, err := NewInstrumentRegistry([]v1.Instrument{NewInstrument("USDJPY", 3), NewInstrument("EURUSD", 5)})
+	require.Nil(t, err)
+
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: instruments,
+		Prices:      ExchangeRateFromTime,
+		Clock:       RealClock{},
+		NewCache:    NewLimitedCacheFunc(5),
+		Logger:      logger.New(logger.Error),
+	})
+	r := chi.NewRouter()
+	v1.HandlerWithOptions(g, v1.ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: []v1.MiddlewareFunc{InstrumentValidator(instruments, logger.New(logger.Error))},
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	get := func(path string, v any) int {
+		resp, err := http.Get(srv.URL + path)
+		require.Nil(t, err)
+		defer resp.Body.Close()
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(v))
+		return resp.StatusCode
+	}
+
+	var list []v1.Instrument
+	require.Equal(t, http.StatusOK, get("/instruments", &list))
+	require.Equal(t, []v1.Instrument{NewInstrument("EURUSD", 5), NewInstrument("USDJPY", 3)}, list)
+
+	var in v1.Instrument
+	require.Equal(t, http.StatusOK, get("/instruments/USDJPY", &in))
+	require.Equal(t, NewInstrument("USDJPY", 3), in)
+
+	var calendars []v1.Calendar
+	require.Equal(t, http.StatusOK, get("/calendars", &calendars))
+	require.Equal(t, []v1.Calendar{
+		{Name: "24x7", Days: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}, Rollover: "0s", Holidays: []string{}},
+		{Name: "FX", Days: []string{"MON", "TUE", "WED", "THU", "FRI"}, Rollover: "-2h0m0s", Holidays: []string{}},
+	}, calendars)
+
+	// unknown currency pair gets the same response in every path
+	for _, path := range []string{"/instruments/GBPUSD", "/rates/GBPUSD", "/rates/GBPUSD/stream", "/pairs/GBPUSD"} {
+		var e v1.Error
+		method := http.MethodGet
+		if path == "/pairs/GBPUSD" {
+			method = http.MethodDelete
+		}
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		require.Nil(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(&e))
+		resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+		require.Equal(t, v1.Error{Code: http.StatusNotFound, Message: "unknown currency pair 'GBPUSD'"}, e, path)
+	}
+
+	// known currency pair reaches handler
+	var e v1.Error
+	require.Equal(t, http.StatusNotFound, get("/rates/USDJPY", &e))
+	require.Equal(t, "service doesn't generate values for 'USDJPY'", e.Message)
+}
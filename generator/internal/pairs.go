@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"net/http"
+	"sort"
+)
+
+var ErrCurrencyPairExists = errors.New("service already generates values for currency pair")
+
+// Pairs returns generated currency pairs in alphabetical order
+func (s *SimplePriceGenerator) Pairs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pairs := make([]string, 0, len(s.pairs))
+	for p := range s.pairs {
+		pairs = append(pairs, p)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// AddPair starts generating rates with scale for currency pair. If Start isn't running, rates are generated after Start is called.
+func (s *SimplePriceGenerator) AddPair(currencyPair string, scale int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pairs[currencyPair]; ok {
+		return ErrCurrencyPairExists
+	}
+
+	p := newPairGenerator(s.newCache(), scale)
+	s.pairs[currencyPair] = p
+	if s.ctx != nil {
+		s.run(currencyPair, p, s.clock.Now())
+	}
+
+	s.logger.Info("currency pair added: %v", currencyPair)
+	return nil
+}
+
+// DeletePair stops generating rates for currency pair and drops its cache. Streams of currency pair are ended.
+// DeletePair returns after generate goroutine of currency pair exits, so the pair added again doesn't share
+// its stream of prices with the deleted one.
+func (s *SimplePriceGenerator) DeletePair(currencyPair string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.pairs[currencyPair]
+	if !ok {
+		return ErrUnknownCurrencyPair
+	}
+
+	if p.cancel != nil {
+		p.cancel()
+		// generate doesn't take mu, so it exits while mu is held
+		<-p.done
+	}
+	delete(s.pairs, currencyPair)
+	p.cache.Close()
+
+	s.logger.Info("currency pair deleted: %v", currencyPair)
+	return nil
+}
+
+func (s *SimplePriceGenerator) GetPairs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(s.Pairs()); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+func (s *SimplePriceGenerator) PostPairs(w http.ResponseWriter, r *http.Request) {
+	var body v1.PostPairsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	in, ok := s.instruments.Get(body.CurrencyPair)
+	switch {
+	case !ok:
+		writeUnknownInstrument(w, body.CurrencyPair, s.logger)
+		return
+	case !in.Enabled:
+		s.writeError(w, http.StatusNotFound, fmt.Sprintf("currency pair '%s' is disabled", body.CurrencyPair))
+		return
+	}
+
+	// prices of instrument have its precision, other scales are rejected
+	if body.Scale != nil && *body.Scale != in.Precision {
+		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("scale of '%s' must be %d", body.CurrencyPair, in.Precision))
+		return
+	}
+	body.Scale = &in.Precision
+
+	if err := s.AddPair(body.CurrencyPair, *body.Scale); err != nil {
+		s.writeError(w, http.StatusConflict, fmt.Sprintf("service already generates values for '%s'", body.CurrencyPair))
+		return
+	}
+	s.addAuditEntry(r, v1.AuditEntry{Action: v1.AuditEntryActionAddPair, CurrencyPair: &body.CurrencyPair})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+func (s *SimplePriceGenerator) DeletePairsCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string) {
+	if err := s.DeletePair(currencyPair); err != nil {
+		s.writeError(w, http.StatusNotFound, fmt.Sprintf("service doesn't generate values for '%s'", currencyPair))
+		return
+	}
+	s.addAuditEntry(r, v1.AuditEntry{Action: v1.AuditEntryActionDeletePair, CurrencyPair: &currencyPair})
+
+	w.WriteHeader(http.StatusNoContent)
+}
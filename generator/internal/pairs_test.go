@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSimplePriceGenerator_Pairs(t *testing.T) {
+	usdrub := NewInstrument("USDRUB", 2)
+	usdrub.Enabled = false
+	instruments, err := NewInstrumentRegistry([]v1.Instrument{
+		NewInstrument("EURUSD", 5), NewInstrument("USDJPY", 3), NewInstrument("GBPUSD", 5), usdrub,
+	})
+	require.Nil(t, err)
+
+	g := NewSimplePriceGenerator([]string{"USDJPY", "EURUSD"}, GeneratorOptions{
+		Instruments: instruments,
+		Prices:      ExchangeRateFromTime,
+		Clock:       RealClock{},
+		NewCache:    NewLimitedCacheFunc(5),
+		Logger:      logger.New(logger.Error),
+	})
+	srv := newTestServer(t, g)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		g.Start(ctx, NewFixedScheduleFunc(10*time.Millisecond))
+	}()
+
+	getPairs := func() []string {
+		resp, err := http.Get(srv.URL + "/pairs")
+		require.Nil(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var pairs []string
+		require.Nil(t, json.NewDecoder(resp.Body).Decode(&pairs))
+		return pairs
+	}
+	postPair := func(body string) int {
+		resp, err := http.Post(srv.URL+"/pairs", "application/json", strings.NewReader(body))
+		require.Nil(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	deletePair := func(pair string) int {
+		req, err := http.NewRequest(http.MethodDelete, srv.URL+"/pairs/"+pair, nil)
+		require.Nil(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	getRates := func(pair string) ([]v1.ExchangeRate, int) {
+		resp, err := http.Get(srv.URL + "/rates/" + pair)
+		require.Nil(t, err)
+		defer resp.Body.Close()
+
+		var rates []v1.ExchangeRate
+		if resp.StatusCode == http.StatusOK {
+			require.Nil(t, json.NewDecoder(resp.Body).Decode(&rates))
+		}
+		return rates, resp.StatusCode
+	}
+
+	require.Equal(t, []string{"EURUSD", "USDJPY"}, getPairs())
+
+	// scale of added pair is precision of its instrument
+	require.Equal(t, http.StatusBadRequest, postPair(`{"currency_pair":"GBPUSD","scale":2}`))
+	require.Equal(t, []string{"EURUSD", "USDJPY"}, getPairs())
+
+	// added pair is generated without restart
+	require.Equal(t, http.StatusCreated, postPair(`{"currency_pair":"GBPUSD","scale":5}`))
+	require.Equal(t, []string{"EURUSD", "GBPUSD", "USDJPY"}, getPairs())
+	require.Eventually(t, func() bool {
+		rates, code := getRates("GBPUSD")
+		return code == http.StatusOK && len(rates) >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, http.StatusConflict, postPair(`{"currency_pair":"GBPUSD"}`))
+	require.Equal(t, http.StatusNotFound, postPair(`{"currency_pair":"USDRUB"}`))
+	require.Equal(t, http.StatusNotFound, postPair(`{"currency_pair":"AUDUSD"}`))
+	require.Equal(t, http.StatusBadRequest, postPair(`{"currency_pair":"gbpusd"}`))
+	require.Equal(t, http.StatusBadRequest, postPair(`{}`))
+
+	// deleted pair is neither generated nor cached
+	require.Equal(t, http.StatusNoContent, deletePair("EURUSD"))
+	require.Equal(t, http.StatusNotFound, deletePair("EURUSD"))
+	require.Equal(t, []string{"GBPUSD", "USDJPY"}, getPairs())
+	_, code := getRates("EURUSD")
+	require.Equal(t, http.StatusNotFound, code)
+
+	// generation keeps running without pairs
+	require.Equal(t, http.StatusNoContent, deletePair("GBPUSD"))
+	require.Equal(t, http.StatusNoContent, deletePair("USDJPY"))
+	require.Empty(t, getPairs())
+	require.Equal(t, http.StatusCreated, postPair(`{"currency_pair":"EURUSD"}`))
+	require.Eventually(t, func() bool {
+		rates, code := getRates("EURUSD")
+		return code == http.StatusOK && len(rates) != 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("generation isn't stopped")
+	}
+}
+
+func TestSimplePriceGenerator_AddPair_BeforeStart(t *testing.T) {
+	clock := NewVirtualClock(epoch, 0, 4*time.Second)
+	g := NewSimplePriceGenerator(nil, GeneratorOptions{
+		Prices:   ExchangeRateFromTime,
+		Clock:    clock,
+		NewCache: NewLimitedCacheFunc(10),
+		Logger:   logger.New(logger.Error),
+	})
+
+	require.Nil(t, g.AddPair("EURUSD", 0))
+	require.ErrorIs(t, g.AddPair("EURUSD", 0), ErrCurrencyPairExists)
+	require.ErrorIs(t, g.DeletePair("USDJPY"), ErrUnknownCurrencyPair)
+
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	out, _, err := g.rates("EURUSD", nil, nil, make([]v1.ExchangeRate, 0, 10))
+	require.Nil(t, err)
+	require.Len(t, out, 5)
+}
+
+func TestSimplePriceGenerator_DeletePair_AddAgain(t *testing.T) {
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(1),
+		Clock:    RealClock{},
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Error),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		g.Start(ctx, NewFixedScheduleFunc(time.Microsecond))
+	}()
+
+	// deleted generation is stopped before the pair is added again, so -race finds no shared stream of prices
+	for i := 0; i < 50; i++ {
+		require.Eventually(t, func() bool {
+			out, _, err := g.rates("EURUSD", nil, nil, nil)
+			return err == nil && len(out) != 0
+		}, 5*time.Second, time.Millisecond)
+		require.Nil(t, g.DeletePair("EURUSD"))
+		require.Nil(t, g.AddPair("EURUSD", 0))
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("generation isn't stopped")
+	}
+}
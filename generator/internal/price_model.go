@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"math"
+	"math/rand"
+)
+
+// NormalSource is a source of standard normal shocks of price model
+type NormalSource interface {
+	NormFloat64() float64
+}
+
+var _ NormalSource = (*rand.Rand)(nil)
+
+// PriceModel produces a price path for one currency pair.
+//
+// Models are stateful and must not be shared between pairs.
+type PriceModel interface {
+	// Next advances the model by one tick and returns the new price
+	Next() int64
+}
+
+var (
+	_ PriceModel = (*RandomWalk)(nil)
+	_ PriceModel = (*GeometricBrownianMotion)(nil)
+	_ PriceModel = (*OrnsteinUhlenbeck)(nil)
+)
+
+// RandomWalk adds normally distributed steps to the price:
+//
+//	p[t+1] = p[t] + step * Z
+type RandomWalk struct {
+	price float64
+	step  float64
+	r     NormalSource
+}
+
+func NewRandomWalk(start int64, step float64, r NormalSource) *RandomWalk {
+	return &RandomWalk{price: float64(start), step: step, r: r}
+}
+
+func (m *RandomWalk) Next() int64 {
+	m.price = math.Max(m.price+m.step*m.r.NormFloat64(), 0)
+	return int64(math.Round(m.price))
+}
+
+// GeometricBrownianMotion models a price with constant drift and volatility per tick:
+//
+//	p[t+1] = p[t] * exp(drift - volatility^2/2 + volatility * Z)
+type GeometricBrownianMotion struct {
+	price      float64
+	drift      float64
+	volatility float64
+	r          NormalSource
+}
+
+func NewGeometricBrownianMotion(start int64, drift float64, volatility float64, r NormalSource) *GeometricBrownianMotion {
+	return &GeometricBrownianMotion{price: float64(start), drift: drift, volatility: volatility, r: r}
+}
+
+func (m *GeometricBrownianMotion) Next() int64 {
+	m.price *= math.Exp(m.drift - m.volatility*m.volatility/2 + m.volatility*m.r.NormFloat64())
+	return int64(math.Round(m.price))
+}
+
+// OrnsteinUhlenbeck pulls the price back to mean with the given reversion speed per tick:
+//
+//	p[t+1] = p[t] + reversion * (mean - p[t]) + volatility * Z
+type OrnsteinUhlenbeck struct {
+	price      float64
+	mean       float64
+	reversion  float64
+	volatility float64
+	r          NormalSource
+}
+
+func NewOrnsteinUhlenbeck(start int64, mean int64, reversion float64, volatility float64, r NormalSource) *OrnsteinUhlenbeck {
+	return &OrnsteinUhlenbeck{price: float64(start), mean: float64(mean), reversion: reversion, volatility: volatility, r: r}
+}
+
+func (m *OrnsteinUhlenbeck) Next() int64 {
+	m.price += m.reversion*(m.mean-m.price) + m.volatility*m.r.NormFloat64()
+	m.price = math.Max(m.price, 0)
+	return int64(math.Round(m.price))
+}
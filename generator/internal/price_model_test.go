@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestGeometricBrownianMotion_Next(t *testing.T) {
+	m := NewGeometricBrownianMotion(100000, 0, 0.01, rand.New(rand.NewSource(1)))
+	prev := int64(100000)
+	for i := 0; i < 1000; i++ {
+		p := m.Next()
+		require.Greater(t, p, int64(0))
+		// one tick moves price by far less than 10% with 1% volatility
+		require.InDelta(t, prev, p, float64(prev)/10)
+		prev = p
+	}
+}
+
+func TestOrnsteinUhlenbeck_Next(t *testing.T) {
+	m := NewOrnsteinUhlenbeck(10000, 13500, 0.2, 5, rand.New(rand.NewSource(1)))
+	var p int64
+	for i := 0; i < 200; i++ {
+		p = m.Next()
+	}
+	require.InDelta(t, 13500, p, 100)
+}
+
+func TestRandomWalk_Next(t *testing.T) {
+	m := NewRandomWalk(10, 100, rand.New(rand.NewSource(1)))
+	for i := 0; i < 1000; i++ {
+		require.GreaterOrEqual(t, m.Next(), int64(0))
+	}
+
+	m = NewRandomWalk(500, 0, rand.New(rand.NewSource(1)))
+	require.Equal(t, int64(500), m.Next())
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Quote is a two-sided price and synthetic trade size around mid price.
+type Quote struct {
+	Bid    int64
+	Ask    int64
+	Volume int64
+}
+
+// QuoteModel quotes mid prices of currency pair.
+// QuoteModel is used by one goroutine only.
+type QuoteModel interface {
+	Quote(mid int64) Quote
+}
+
+// QuoteFunc creates quote model of currency pair when its generation starts
+type QuoteFunc func(pair string) QuoteModel
+
+var (
+	_ QuoteModel = MidQuote{}
+	_ QuoteModel = (*SyntheticQuote)(nil)
+)
+
+// MidQuote quotes mid price without spread and volume.
+type MidQuote struct{}
+
+func (MidQuote) Quote(mid int64) Quote {
+	return Quote{Bid: mid, Ask: mid}
+}
+
+// SyntheticQuote spreads bid and ask around mid price:
+//
+//	spread = max(minSpread, mid * relativeSpread) * (1 + widening * |Z|)
+//
+// Volume is exponentially distributed with mean volume, zero mean volume means no volume.
+type SyntheticQuote struct {
+	minSpread      float64
+	relativeSpread float64
+	widening       float64
+	volume         float64
+	r              *rand.Rand
+}
+
+func NewSyntheticQuote(minSpread int64, relativeSpread float64, widening float64, volume int64, r *rand.Rand) *SyntheticQuote {
+	return &SyntheticQuote{
+		minSpread:      float64(minSpread),
+		relativeSpread: relativeSpread,
+		widening:       widening,
+		volume:         float64(volume),
+		r:              r,
+	}
+}
+
+func (q *SyntheticQuote) Quote(mid int64) Quote {
+	spread := math.Max(q.minSpread, float64(mid)*q.relativeSpread)
+	if q.widening != 0 {
+		spread *= 1 + q.widening*math.Abs(q.r.NormFloat64())
+	}
+	half := int64(math.Round(spread / 2))
+
+	quote := Quote{
+		Bid: mid - half,
+		Ask: mid + half,
+	}
+	if quote.Bid < 0 {
+		quote.Bid = 0
+	}
+
+	if q.volume != 0 {
+		quote.Volume = int64(math.Max(1, math.Round(q.volume*q.r.ExpFloat64())))
+	}
+
+	return quote
+}
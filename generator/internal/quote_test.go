@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"context"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSyntheticQuote(t *testing.T) {
+	tests := []struct {
+		name      string
+		quote     *SyntheticQuote
+		mid       int64
+		minSpread int64
+		maxSpread int64
+	}{
+		{
+			name:      "minimal spread",
+			quote:     NewSyntheticQuote(10, 0.00001, 0, 0, rand.New(rand.NewSource(1))),
+			mid:       100000,
+			minSpread: 10,
+			maxSpread: 10,
+		},
+		{
+			name:      "relative spread",
+			quote:     NewSyntheticQuote(2, 0.0002, 0, 0, rand.New(rand.NewSource(1))),
+			mid:       100000,
+			minSpread: 20,
+			maxSpread: 20,
+		},
+		{
+			name:      "widening spread",
+			quote:     NewSyntheticQuote(10, 0, 0.5, 1000, rand.New(rand.NewSource(1))),
+			mid:       100000,
+			minSpread: 10,
+			maxSpread: 60,
+		},
+		{
+			name:      "bid isn't negative",
+			quote:     NewSyntheticQuote(10, 0, 0, 0, rand.New(rand.NewSource(1))),
+			mid:       2,
+			minSpread: 7,
+			maxSpread: 7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				q := tc.quote.Quote(tc.mid)
+				require.GreaterOrEqual(t, q.Bid, int64(0))
+				require.LessOrEqual(t, q.Bid, tc.mid)
+				require.GreaterOrEqual(t, q.Ask, tc.mid)
+				require.GreaterOrEqual(t, q.Ask-q.Bid, tc.minSpread)
+				require.LessOrEqual(t, q.Ask-q.Bid, tc.maxSpread)
+				if tc.quote.volume == 0 {
+					require.Zero(t, q.Volume)
+				} else {
+					require.Positive(t, q.Volume)
+				}
+			}
+		})
+	}
+}
+
+func TestSimplePriceGenerator_Quotes(t *testing.T) {
+	clock := NewVirtualClock(epoch, 0, 9*time.Second)
+	quote := func(string) QuoteModel { return NewSyntheticQuote(10, 0, 0, 1000, rand.New(rand.NewSource(1))) }
+	instruments, err := NewInstrumentRegistry([]v1.Instrument{NewInstrument("EURUSD", 5)})
+	require.Nil(t, err)
+
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: instruments,
+		Prices:      func(string) int64 { return 100000 },
+		Quote:       quote,
+		Clock:       clock,
+		NewCache:    NewLimitedCacheFunc(10),
+		Logger:      logger.New(logger.Error),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	out, _, err := g.rates("EURUSD", nil, nil, make([]v1.ExchangeRate, 0, 10))
+	require.Nil(t, err)
+	require.Len(t, out, 10)
+	for _, r := range out {
+		require.Equal(t, "1.00000", r.Rate)
+		require.Equal(t, r.Rate, r.Mid)
+		require.Equal(t, "0.99995", r.Bid)
+		require.Equal(t, "1.00005", r.Ask)
+		require.Positive(t, r.Volume)
+
+		p := toProto(r)
+		require.Equal(t, int64(100000), p.Mid)
+		require.Equal(t, int64(99995), p.Bid)
+		require.Equal(t, int64(100005), p.Ask)
+		require.Equal(t, int32(5), p.Scale)
+	}
+}
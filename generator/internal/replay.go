@@ -0,0 +1,281 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mtsbank/pkg/decimal"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrReplayFormat = errors.New("replay file must be .csv, .ndjson or .jsonl")
+	ErrReplayTick   = errors.New("invalid replay tick")
+	ErrReplayEmpty  = errors.New("replay file has no ticks")
+)
+
+// Tick is a recorded rate of currency pair, Rate is a decimal string
+type Tick struct {
+	Pair string    `json:"pair"`
+	Time time.Time `json:"time"`
+	Rate string    `json:"rate"`
+}
+
+// ReadTicksFile reads ticks of CSV or NDJSON file, format is chosen by extension
+func ReadTicksFile(name string) ([]Tick, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ticks []Tick
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		ticks, err = ReadTicksCSV(f)
+	case ".ndjson", ".jsonl":
+		ticks, err = ReadTicksNDJSON(f)
+	default:
+		return nil, fmt.Errorf("%s: %w", name, ErrReplayFormat)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return ticks, nil
+}
+
+// ReadTicksCSV reads CSV with header, columns are found by names pair, time and rate, other columns are skipped
+func ReadTicksCSV(r io.Reader) ([]Tick, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	columns := map[string]int{"pair": -1, "time": -1, "rate": -1}
+	for i, name := range header {
+		if _, ok := columns[strings.TrimSpace(name)]; ok {
+			columns[strings.TrimSpace(name)] = i
+		}
+	}
+	for name, i := range columns {
+		if i < 0 {
+			return nil, fmt.Errorf("header: %w: no column %s", ErrReplayTick, name)
+		}
+	}
+
+	var ticks []Tick
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return ticks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) <= columns["pair"] || len(record) <= columns["time"] || len(record) <= columns["rate"] {
+			return nil, fmt.Errorf("line %d: %w", line, ErrReplayTick)
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, record[columns["time"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w: %v", line, ErrReplayTick, err)
+		}
+		ticks = append(ticks, Tick{Pair: record[columns["pair"]], Time: t, Rate: record[columns["rate"]]})
+	}
+}
+
+// ReadTicksNDJSON reads one JSON tick per line, rate is either a string or a number. Empty lines are skipped.
+func ReadTicksNDJSON(r io.Reader) ([]Tick, error) {
+	var ticks []Tick
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		if len(strings.TrimSpace(s.Text())) == 0 {
+			continue
+		}
+
+		var tick struct {
+			Pair string      `json:"pair"`
+			Time time.Time   `json:"time"`
+			Rate json.Number `json:"rate"`
+		}
+		if err := json.Unmarshal(s.Bytes(), &tick); err != nil {
+			return nil, fmt.Errorf("line %d: %w: %v", line, ErrReplayTick, err)
+		}
+		ticks = append(ticks, Tick{Pair: tick.Pair, Time: tick.Time, Rate: tick.Rate.String()})
+	}
+	return ticks, s.Err()
+}
+
+// replayTick is a tick with price in units of currency pair precision
+type replayTick struct {
+	time  time.Time
+	price int64
+}
+
+// Replay generates recorded ticks: Price is a GeneratorFunc and Schedule is a ScheduleFunc, they must be used together.
+// Times of ticks are shifted, so the first tick of recording is at origin.
+// Replay with loop repeats recording after its last tick, without loop pairs get no rates after their last ticks.
+type Replay struct {
+	ticks      map[string][]replayTick
+	start, end time.Time
+	loop       bool
+
+	// mu guards cursors and their state
+	mu      sync.Mutex
+	cursors map[string]*replayCursor
+}
+
+// NewReplay creates replay of ticks, prices of currency pair are rescaled to scale(pair) digits after decimal point.
+// Zero origin keeps original times of ticks.
+func NewReplay(ticks []Tick, scale func(pair string) int32, origin time.Time, loop bool) (*Replay, error) {
+	if len(ticks) == 0 {
+		return nil, ErrReplayEmpty
+	}
+
+	r := &Replay{
+		ticks:   map[string][]replayTick{},
+		loop:    loop,
+		cursors: map[string]*replayCursor{},
+	}
+	for i, tick := range ticks {
+		if tick.Pair == "" {
+			return nil, fmt.Errorf("tick %d: %w: empty pair", i+1, ErrReplayTick)
+		}
+		value, from, err := decimal.Parse(tick.Rate)
+		if err == nil {
+			value, err = decimal.Rescale(value, from, scale(tick.Pair))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tick %d: %s: %w: %v", i+1, tick.Pair, ErrReplayTick, err)
+		}
+
+		r.ticks[tick.Pair] = append(r.ticks[tick.Pair], replayTick{time: tick.Time, price: value})
+		if r.start.IsZero() || tick.Time.Before(r.start) {
+			r.start = tick.Time
+		}
+		if tick.Time.After(r.end) {
+			r.end = tick.Time
+		}
+	}
+
+	shift := time.Duration(0)
+	if !origin.IsZero() {
+		shift = origin.Sub(r.start)
+		r.start, r.end = origin, r.end.Add(shift)
+	}
+	r.start, r.end = r.start.Round(time.Microsecond), r.end.Round(time.Microsecond)
+	for _, pairTicks := range r.ticks {
+		// ticks of the same time keep order of file
+		sort.SliceStable(pairTicks, func(i, j int) bool { return pairTicks[i].time.Before(pairTicks[j].time) })
+		for i := range pairTicks {
+			pairTicks[i].time = pairTicks[i].time.Add(shift).Round(time.Microsecond)
+		}
+	}
+
+	return r, nil
+}
+
+// Start returns time of the first tick of recording
+func (r *Replay) Start() time.Time {
+	return r.start
+}
+
+// End returns time of the last tick of recording
+func (r *Replay) End() time.Time {
+	return r.end
+}
+
+// Pairs returns currency pairs of recording in alphabetical order
+func (r *Replay) Pairs() []string {
+	pairs := make([]string, 0, len(r.ticks))
+	for p := range r.ticks {
+		pairs = append(pairs, p)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// Price returns price of the current tick of currency pair schedule, zero if the pair isn't replayed
+func (r *Replay) Price(pair string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.cursors[pair]
+	if !ok || len(c.ticks) == 0 {
+		return 0
+	}
+	return c.ticks[c.i].price
+}
+
+// Schedule starts replay of currency pair, the previous replay of the pair is replaced
+func (r *Replay) Schedule(pair string) Schedule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := &replayCursor{replay: r, ticks: r.ticks[pair]}
+	r.cursors[pair] = c
+	return c
+}
+
+// period is a duration of one loop of replay
+func (r *Replay) period() time.Duration {
+	return r.end.Sub(r.start) + minInterval
+}
+
+var _ TimedSchedule = (*replayCursor)(nil)
+
+// replayCursor is a schedule of currency pair replay, it points to the current tick
+type replayCursor struct {
+	replay *Replay
+	ticks  []replayTick
+	i      int
+}
+
+// First returns time of the tick current at now, or of the next tick if now is before the first tick.
+// Replay with loop starts the pair in the loop of now.
+func (c *replayCursor) First(now time.Time) (time.Time, bool) {
+	c.replay.mu.Lock()
+	defer c.replay.mu.Unlock()
+
+	if len(c.ticks) == 0 {
+		return time.Time{}, false
+	}
+
+	var loops time.Duration
+	if c.replay.loop && now.After(c.replay.end) {
+		period := c.replay.period()
+		loops = (now.Sub(c.replay.start) / period) * period
+	}
+	c.i = sort.Search(len(c.ticks), func(i int) bool { return c.ticks[i].time.Add(loops).After(now) })
+	if c.i > 0 {
+		c.i--
+	}
+	return c.ticks[c.i].time.Add(loops), true
+}
+
+func (c *replayCursor) Next() time.Duration {
+	c.replay.mu.Lock()
+	defer c.replay.mu.Unlock()
+
+	last := len(c.ticks) - 1
+	if c.i < last {
+		c.i++
+		return c.ticks[c.i].time.Sub(c.ticks[c.i-1].time)
+	}
+	if !c.replay.loop || last < 0 {
+		return ScheduleFinished
+	}
+	c.i = 0
+	return c.ticks[0].time.Sub(c.ticks[last].time) + c.replay.period()
+}
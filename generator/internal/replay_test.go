@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadTicks(t *testing.T) {
+	expected := []Tick{
+		{Pair: "EURUSD", Time: epoch, Rate: "1.0195"},
+		{Pair: "USDJPY", Time: epoch.Add(500 * time.Millisecond), Rate: "133.1"},
+	}
+
+	tests := []struct {
+		name  string
+		read  func(string) ([]Tick, error)
+		input string
+		ticks []Tick
+		err   error
+	}{
+		{
+			name:  "csv",
+			read:  func(s string) ([]Tick, error) { return ReadTicksCSV(strings.NewReader(s)) },
+			input: "time,pair,bid,rate\n2022-08-01T00:00:00Z,EURUSD,1.0194,1.0195\n2022-08-01T00:00:00.5Z,USDJPY,133,133.1\n",
+			ticks: expected,
+		},
+		{
+			name:  "csv without rate",
+			read:  func(s string) ([]Tick, error) { return ReadTicksCSV(strings.NewReader(s)) },
+			input: "time,pair\n2022-08-01T00:00:00Z,EURUSD\n",
+			err:   ErrReplayTick,
+		},
+		{
+			name:  "csv with invalid time",
+			read:  func(s string) ([]Tick, error) { return ReadTicksCSV(strings.NewReader(s)) },
+			input: "pair,time,rate\nEURUSD,yesterday,1.0195\n",
+			err:   ErrReplayTick,
+		},
+		{
+			name: "ndjson",
+			read: func(s string) ([]Tick, error) { return ReadTicksNDJSON(strings.NewReader(s)) },
+			input: `{"pair":"EURUSD","time":"2022-08-01T00:00:00Z","rate":"1.0195"}
+
+{"pair":"USDJPY","time":"2022-08-01T00:00:00.5Z","rate":133.1}`,
+			ticks: expected,
+		},
+		{
+			name:  "ndjson with invalid rate",
+			read:  func(s string) ([]Tick, error) { return ReadTicksNDJSON(strings.NewReader(s)) },
+			input: `{"pair":"EURUSD","time":"2022-08-01T00:00:00Z","rate":"high"}`,
+			err:   ErrReplayTick,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticks, err := tc.read(tc.input)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.ticks, ticks)
+		})
+	}
+}
+
+func TestNewReplay(t *testing.T) {
+	scale := func(string) int32 { return 2 }
+
+	_, err := NewReplay(nil, scale, time.Time{}, false)
+	require.ErrorIs(t, err, ErrReplayEmpty)
+
+	_, err = NewReplay([]Tick{{Pair: "EURUSD", Time: epoch, Rate: "1.015"}}, scale, time.Time{}, false)
+	require.ErrorIs(t, err, ErrReplayTick)
+
+	origin := epoch.Add(24 * time.Hour)
+	r, err := NewReplay([]Tick{
+		{Pair: "USDJPY", Time: epoch.Add(time.Second), Rate: "133"},
+		{Pair: "EURUSD", Time: epoch.Add(3 * time.Second), Rate: "1.02"},
+		{Pair: "EURUSD", Time: epoch.Add(2 * time.Second), Rate: "1.01"},
+	}, scale, origin, false)
+	require.Nil(t, err)
+	require.Equal(t, origin, r.Start())
+	require.Equal(t, origin.Add(2*time.Second), r.End())
+	require.Equal(t, []string{"EURUSD", "USDJPY"}, r.Pairs())
+
+	s := r.Schedule("EURUSD").(TimedSchedule)
+	first, ok := s.First(origin)
+	require.True(t, ok)
+	require.Equal(t, origin.Add(time.Second), first)
+	require.Equal(t, int64(101), r.Price("EURUSD"))
+	require.Equal(t, time.Second, s.Next())
+	require.Equal(t, int64(102), r.Price("EURUSD"))
+	require.Equal(t, ScheduleFinished, s.Next())
+
+	_, ok = r.Schedule("GBPUSD").(TimedSchedule).First(origin)
+	require.False(t, ok)
+	require.Equal(t, int64(0), r.Price("GBPUSD"))
+}
+
+func TestReplay_Loop(t *testing.T) {
+	r, err := NewReplay([]Tick{
+		{Pair: "EURUSD", Time: epoch, Rate: "1"},
+		{Pair: "EURUSD", Time: epoch.Add(time.Second), Rate: "2"},
+		{Pair: "USDJPY", Time: epoch.Add(2 * time.Second), Rate: "3"},
+	}, func(string) int32 { return 0 }, time.Time{}, true)
+	require.Nil(t, err)
+
+	// pair started in the second loop gets the tick current at start
+	s := r.Schedule("EURUSD").(TimedSchedule)
+	first, ok := s.First(epoch.Add(2*time.Second + 500*time.Millisecond))
+	require.True(t, ok)
+	require.Equal(t, epoch.Add(2*time.Second+time.Microsecond), first)
+	require.Equal(t, int64(1), r.Price("EURUSD"))
+
+	require.Equal(t, time.Second, s.Next())
+	require.Equal(t, int64(2), r.Price("EURUSD"))
+	// the next loop starts a microsecond after the last tick of recording
+	require.Equal(t, time.Second+time.Microsecond, s.Next())
+	require.Equal(t, int64(1), r.Price("EURUSD"))
+}
+
+func TestSimplePriceGenerator_Replay(t *testing.T) {
+	r, err := NewReplay([]Tick{
+		{Pair: "EURUSD", Time: epoch.Add(time.Second), Rate: "1.01950"},
+		{Pair: "EURUSD", Time: epoch.Add(3 * time.Second), Rate: "1.01951"},
+		{Pair: "USDJPY", Time: epoch, Rate: "133.100"},
+	}, func(pair string) int32 { return map[string]int32{"EURUSD": 5, "USDJPY": 3}[pair] }, time.Time{}, true)
+	require.Nil(t, err)
+
+	// two loops of replay
+	clock := NewVirtualClock(r.Start(), 0, 2*(r.End().Sub(r.Start())+time.Microsecond)-time.Microsecond)
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Prices:      r.Price,
+		Clock:       clock,
+		NewCache:    NewLimitedCacheFunc(10),
+		Logger:      logger.New(logger.Info),
+	})
+	g.Start(context.Background(), r.Schedule)
+
+	rates := func(pair string) []v1.ExchangeRate {
+		w := httptest.NewRecorder()
+		g.GetRatesCurrencyPair(w, httptest.NewRequest(http.MethodGet, "/rates/"+pair, nil), pair, v1.GetRatesCurrencyPairParams{})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var out []v1.ExchangeRate
+		require.Nil(t, json.NewDecoder(w.Body).Decode(&out))
+		return out
+	}
+
+	eur := rates("EURUSD")
+	require.Len(t, eur, 4)
+	for i, expected := range []struct {
+		time time.Time
+		rate string
+	}{
+		{epoch.Add(time.Second), "1.01950"},
+		{epoch.Add(3 * time.Second), "1.01951"},
+		{epoch.Add(4*time.Second + time.Microsecond), "1.01950"},
+		{epoch.Add(6*time.Second + time.Microsecond), "1.01951"},
+	} {
+		require.Equal(t, expected.time, eur[i].Time.UTC())
+		require.Equal(t, expected.rate, eur[i].Rate)
+	}
+
+	jpy := rates("USDJPY")
+	require.Len(t, jpy, 2)
+	require.Equal(t, "133.100", jpy[1].Rate)
+}
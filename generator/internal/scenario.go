@@ -0,0 +1,322 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/invopop/yaml"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownScenario = errors.New("unknown scenario")
+	ErrScenarioCycle   = errors.New("scenario includes itself")
+	ErrScenarioStep    = errors.New("invalid scenario step")
+)
+
+// scenarioSegment is a compiled step, start and end are offsets from the start of timelines
+type scenarioSegment struct {
+	kind       v1.ScenarioStepKind
+	start, end time.Duration
+	change     float64
+	pips       int64
+	volatility float64
+}
+
+// scenarioTrack is a timeline of currency pair and state of its prices
+type scenarioTrack struct {
+	segments []scenarioSegment
+	// pip is a pip size in price units
+	pip int64
+
+	started  bool
+	prevBase int64
+	// level follows the model, its moves are scaled by regimes
+	level float64
+}
+
+// ScenarioPlayer changes prices of the model on timelines of played scenario.
+//
+// Prices depend only on prices of the model and times of rates, so scenario is deterministic under SEED and virtual clock.
+type ScenarioPlayer struct {
+	instruments *InstrumentRegistry
+
+	mu     sync.Mutex
+	played *v1.PlayedScenario
+	origin time.Time
+	tracks map[string]*scenarioTrack
+}
+
+func NewScenarioPlayer(instruments *InstrumentRegistry) *ScenarioPlayer {
+	return &ScenarioPlayer{instruments: instruments}
+}
+
+// ParseScenario parses YAML or JSON scenario set
+func ParseScenario(data []byte) (v1.ScenarioSet, error) {
+	var set v1.ScenarioSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return v1.ScenarioSet{}, err
+	}
+	if _, _, err := compileScenario(set); err != nil {
+		return v1.ScenarioSet{}, err
+	}
+	return set, nil
+}
+
+// YAMLBodyDecoder decodes YAML request body for request validator the same way as JSON body, so numbers are float64
+func YAMLBodyDecoder(body io.Reader, _ http.Header, _ *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (interface{}, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = yaml.YAMLToJSON(data); err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Play replaces played scenario, timelines start at now plus start of set
+func (p *ScenarioPlayer) Play(set v1.ScenarioSet, now time.Time) error {
+	timelines, start, err := compileScenario(set)
+	if err != nil {
+		return err
+	}
+
+	tracks := make(map[string]*scenarioTrack, len(timelines))
+	for pair, segments := range timelines {
+		tracks[pair] = &scenarioTrack{segments: segments, pip: p.instruments.PipUnits(pair)}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.played = &v1.PlayedScenario{Origin: now, Scenario: set}
+	p.origin = now.Add(start)
+	p.tracks = tracks
+	return nil
+}
+
+// Stop stops played scenario
+func (p *ScenarioPlayer) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.played, p.tracks = nil, nil
+}
+
+// Played returns played scenario
+func (p *ScenarioPlayer) Played() (v1.PlayedScenario, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.played == nil {
+		return v1.PlayedScenario{}, false
+	}
+	return *p.played, true
+}
+
+// Apply returns price of currency pair at t from price of the model. False means that the pair is halted and rate isn't generated.
+func (p *ScenarioPlayer) Apply(currencyPair string, t time.Time, base int64) (int64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	track := p.tracks[currencyPair]
+	if track == nil {
+		return base, true
+	}
+
+	var (
+		d          = t.Sub(p.origin)
+		factor     = 1.0
+		offset     int64
+		volatility = 1.0
+		halted     bool
+	)
+	for _, s := range track.segments {
+		if d < s.start {
+			break
+		}
+		switch s.kind {
+		case v1.ScenarioStepKindTrend:
+			progress := math.Min(1, float64(d-s.start)/float64(s.end-s.start))
+			factor *= 1 + s.change*progress
+		case v1.ScenarioStepKindShock:
+			factor *= 1 + s.change
+			offset += s.pips * track.pip
+		case v1.ScenarioStepKindHalt:
+			halted = halted || d < s.end
+		case v1.ScenarioStepKindRegime:
+			if d < s.end {
+				volatility = s.volatility
+			}
+		}
+	}
+
+	if !track.started {
+		track.started, track.level = true, float64(base)
+	} else {
+		track.level += float64(base-track.prevBase) * volatility
+	}
+	track.prevBase = base
+
+	return int64(math.Round(track.level*factor)) + offset, !halted
+}
+
+// compileScenario expands includes of the played scenario and returns segments of currency pairs and start of timelines
+func compileScenario(set v1.ScenarioSet) (map[string][]scenarioSegment, time.Duration, error) {
+	scenarios := make(map[string]v1.Scenario, len(set.Scenarios))
+	for _, s := range set.Scenarios {
+		scenarios[s.Name] = s
+	}
+
+	var start time.Duration
+	if set.Start != nil {
+		var err error
+		if start, err = time.ParseDuration(*set.Start); err != nil || start < 0 {
+			return nil, 0, fmt.Errorf("start: %w", ErrScenarioStep)
+		}
+	}
+
+	run, ok := scenarios[set.Run]
+	if !ok {
+		return nil, 0, fmt.Errorf("%s: %w", set.Run, ErrUnknownScenario)
+	}
+
+	timelines := map[string][]scenarioSegment{}
+	for pair := range run.Pairs.AdditionalProperties {
+		segments, _, err := expandScenario(scenarios, set.Run, pair, 0, map[string]bool{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", pair, err)
+		}
+		timelines[pair] = segments
+	}
+
+	return timelines, start, nil
+}
+
+// expandScenario returns segments of currency pair in scenario name starting at offset and the end of the last segment
+func expandScenario(scenarios map[string]v1.Scenario, name string, pair string, offset time.Duration, stack map[string]bool) ([]scenarioSegment, time.Duration, error) {
+	s, ok := scenarios[name]
+	if !ok {
+		return nil, 0, fmt.Errorf("%s: %w", name, ErrUnknownScenario)
+	}
+	if stack[name] {
+		return nil, 0, fmt.Errorf("%s: %w", name, ErrScenarioCycle)
+	}
+	stack[name] = true
+	defer delete(stack, name)
+
+	var segments []scenarioSegment
+	for i, step := range s.Pairs.AdditionalProperties[pair] {
+		if step.Kind == v1.ScenarioStepKindInclude {
+			if step.Scenario == nil {
+				return nil, 0, fmt.Errorf("%s step %d: %w", name, i, ErrScenarioStep)
+			}
+			included, end, err := expandScenario(scenarios, *step.Scenario, pair, offset, stack)
+			if err != nil {
+				return nil, 0, err
+			}
+			segments, offset = append(segments, included...), end
+			continue
+		}
+
+		segment, err := newScenarioSegment(step, offset)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s step %d: %w", name, i, err)
+		}
+		segments, offset = append(segments, segment), segment.end
+	}
+
+	return segments, offset, nil
+}
+
+func newScenarioSegment(step v1.ScenarioStep, offset time.Duration) (scenarioSegment, error) {
+	s := scenarioSegment{kind: step.Kind, start: offset, end: offset, volatility: 1}
+	if step.Change != nil {
+		s.change = *step.Change
+	}
+	if step.Pips != nil {
+		s.pips = *step.Pips
+	}
+	if step.Volatility != nil {
+		s.volatility = *step.Volatility
+	}
+
+	if step.Kind != v1.ScenarioStepKindShock {
+		if step.Duration == nil {
+			return s, ErrScenarioStep
+		}
+		d, err := time.ParseDuration(*step.Duration)
+		if err != nil || d <= 0 {
+			return s, ErrScenarioStep
+		}
+		s.end = offset + d
+	}
+
+	switch step.Kind {
+	case v1.ScenarioStepKindTrend, v1.ScenarioStepKindShock:
+		if s.change <= -1 {
+			return s, ErrScenarioStep
+		}
+	case v1.ScenarioStepKindRegime:
+		if s.volatility < 0 {
+			return s, ErrScenarioStep
+		}
+	case v1.ScenarioStepKindHalt, v1.ScenarioStepKindWait:
+	default:
+		return s, ErrScenarioStep
+	}
+
+	return s, nil
+}
+
+func (s *SimplePriceGenerator) GetScenario(w http.ResponseWriter, r *http.Request) {
+	played, ok := s.scenario.Played()
+	if !ok {
+		s.writeError(w, http.StatusNotFound, "no scenario is played")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(played); err != nil {
+		s.logger.Error("Encode.Err: %v", err)
+	}
+}
+
+func (s *SimplePriceGenerator) PutScenario(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	set, err := ParseScenario(data)
+	if err == nil {
+		err = s.PlayScenario(set)
+	}
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid scenario: "+err.Error())
+		return
+	}
+	s.addAuditEntry(r, v1.AuditEntry{Action: v1.AuditEntryActionPutScenario, ScenarioRun: &set.Run})
+
+	s.GetScenario(w, r)
+}
+
+func (s *SimplePriceGenerator) DeleteScenario(w http.ResponseWriter, r *http.Request) {
+	s.scenario.Stop()
+	s.logger.Info("scenario stopped")
+	s.addAuditEntry(r, v1.AuditEntry{Action: v1.AuditEntryActionDeleteScenario})
+	w.WriteHeader(http.StatusNoContent)
+}
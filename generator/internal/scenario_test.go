@@ -0,0 +1,291 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"generator/internal/api/http/v1"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flashCrash trends EURUSD +2% over 10 minutes, then crashes it by 50 pips and halts it for 5 minutes
+const flashCrash = `
+run: qa
+scenarios:
+  - name: crash
+    pairs:
+      EURUSD:
+        - kind: shock
+          pips: -50
+        - kind: halt
+          duration: 5m
+  - name: qa
+    pairs:
+      EURUSD:
+        - kind: trend
+          change: 0.02
+          duration: 10m
+        - kind: include
+          scenario: crash
+`
+
+func newScenarioRegistry(t *testing.T) *InstrumentRegistry {
+	r, err := NewInstrumentRegistry([]v1.Instrument{NewInstrument("EURUSD", 5), NewInstrument("USDJPY", 3)})
+	require.Nil(t, err)
+	return r
+}
+
+func TestScenarioPlayer_Apply(t *testing.T) {
+	set, err := ParseScenario([]byte(flashCrash))
+	require.Nil(t, err)
+
+	p := NewScenarioPlayer(newScenarioRegistry(t))
+	require.Nil(t, p.Play(set, epoch))
+
+	tests := []struct {
+		pair   string
+		at     time.Duration
+		price  int64
+		halted bool
+	}{
+		{pair: "EURUSD", at: -time.Minute, price: 100000},
+		{pair: "EURUSD", at: 0, price: 100000},
+		{pair: "EURUSD", at: 5 * time.Minute, price: 101000},
+		{pair: "EURUSD", at: 10 * time.Minute, price: 101500, halted: true},
+		{pair: "EURUSD", at: 15*time.Minute - time.Second, price: 101500, halted: true},
+		{pair: "EURUSD", at: 15 * time.Minute, price: 101500},
+		{pair: "USDJPY", at: 10 * time.Minute, price: 100000},
+	}
+
+	for _, tc := range tests {
+		price, ok := p.Apply(tc.pair, epoch.Add(tc.at), 100000)
+		require.Equal(t, tc.price, price, "%s at %v", tc.pair, tc.at)
+		require.Equal(t, !tc.halted, ok, "%s at %v", tc.pair, tc.at)
+	}
+
+	p.Stop()
+	price, ok := p.Apply("EURUSD", epoch.Add(10*time.Minute), 100000)
+	require.Equal(t, int64(100000), price)
+	require.True(t, ok)
+}
+
+func TestScenarioPlayer_Apply_Regime(t *testing.T) {
+	set, err := ParseScenario([]byte(`{"run":"calm","start":"1m","scenarios":[{"name":"calm","pairs":{"EURUSD":[{"kind":"wait","duration":"1m"},{"kind":"regime","volatility":0.5,"duration":"2m"}]}}]}`))
+	require.Nil(t, err)
+
+	p := NewScenarioPlayer(newScenarioRegistry(t))
+	require.Nil(t, p.Play(set, epoch))
+
+	// regime starts at 2m and halves moves of the model until 4m
+	steps := []struct {
+		at    time.Duration
+		base  int64
+		price int64
+	}{
+		{at: 0, base: 1000, price: 1000},
+		{at: 2 * time.Minute, base: 1100, price: 1050},
+		{at: 3 * time.Minute, base: 1000, price: 1000},
+		{at: 4 * time.Minute, base: 1100, price: 1100},
+	}
+	for _, s := range steps {
+		price, ok := p.Apply("EURUSD", epoch.Add(s.at), s.base)
+		require.True(t, ok)
+		require.Equal(t, s.price, price, "at %v", s.at)
+	}
+}
+
+func TestParseScenario_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		err      error
+	}{
+		{
+			name:     "unknown run",
+			scenario: `{"run":"qa","scenarios":[]}`,
+			err:      ErrUnknownScenario,
+		},
+		{
+			name:     "unknown include",
+			scenario: `{"run":"qa","scenarios":[{"name":"qa","pairs":{"EURUSD":[{"kind":"include","scenario":"crash"}]}}]}`,
+			err:      ErrUnknownScenario,
+		},
+		{
+			name:     "cycle",
+			scenario: `{"run":"a","scenarios":[{"name":"a","pairs":{"EURUSD":[{"kind":"include","scenario":"b"}]}},{"name":"b","pairs":{"EURUSD":[{"kind":"include","scenario":"a"}]}}]}`,
+			err:      ErrScenarioCycle,
+		},
+		{
+			name:     "missing duration",
+			scenario: `{"run":"qa","scenarios":[{"name":"qa","pairs":{"EURUSD":[{"kind":"halt"}]}}]}`,
+			err:      ErrScenarioStep,
+		},
+		{
+			name:     "invalid duration",
+			scenario: `{"run":"qa","scenarios":[{"name":"qa","pairs":{"EURUSD":[{"kind":"trend","change":0.1,"duration":"-1m"}]}}]}`,
+			err:      ErrScenarioStep,
+		},
+		{
+			name:     "crash to zero",
+			scenario: `{"run":"qa","scenarios":[{"name":"qa","pairs":{"EURUSD":[{"kind":"shock","change":-1}]}}]}`,
+			err:      ErrScenarioStep,
+		},
+		{
+			name:     "negative volatility",
+			scenario: `{"run":"qa","scenarios":[{"name":"qa","pairs":{"EURUSD":[{"kind":"regime","volatility":-1,"duration":"1m"}]}}]}`,
+			err:      ErrScenarioStep,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseScenario([]byte(tc.scenario))
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestSimplePriceGenerator_Scenario(t *testing.T) {
+	set, err := ParseScenario([]byte(flashCrash))
+	require.Nil(t, err)
+
+	f := func(string) int64 { return 100000 }
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Prices:      f,
+		Clock:       NewVirtualClock(epoch, 0, 20*time.Minute),
+		NewCache:    NewLimitedCacheFunc(100),
+		Logger:      logger.New(logger.Info),
+	})
+	require.Nil(t, g.PlayScenario(set))
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Minute))
+
+	out, _, err := g.rates("EURUSD", nil, nil, make([]v1.ExchangeRate, 0, 100))
+	require.Nil(t, err)
+
+	prices := map[int]string{}
+	for _, r := range out {
+		prices[int(r.Time.Sub(epoch)/time.Minute)] = r.Mid
+	}
+
+	// no rates while EURUSD is halted
+	require.Len(t, prices, 16)
+	require.Equal(t, "1.00000", prices[0])
+	require.Equal(t, "1.01000", prices[5])
+	require.Equal(t, "1.01800", prices[9])
+	require.NotContains(t, prices, 10)
+	require.NotContains(t, prices, 14)
+	require.Equal(t, "1.01500", prices[15])
+	require.Equal(t, "1.01500", prices[20])
+}
+
+func TestSimplePriceGenerator_PlayScenario_Origin(t *testing.T) {
+	set, err := ParseScenario([]byte(flashCrash))
+	require.Nil(t, err)
+	newGenerator := func(clock Clock) *SimplePriceGenerator {
+		return NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+			Instruments: newScenarioRegistry(t),
+			Prices:      func(string) int64 { return 100000 },
+			Clock:       clock,
+			NewCache:    NewLimitedCacheFunc(10),
+			Logger:      logger.New(logger.Info),
+		})
+	}
+
+	// scenario played before start begins at epoch of clock, however long the service starts
+	g := newGenerator(NewVirtualClock(epoch, 3600, 0))
+	time.Sleep(10 * time.Millisecond)
+	require.Nil(t, g.PlayScenario(set))
+	played, ok := g.scenario.Played()
+	require.True(t, ok)
+	require.Equal(t, epoch, played.Origin)
+
+	// clock of zero scale moves with generated rates, so scenario played later begins at the latest rate
+	clock := NewVirtualClock(epoch, 0, 0)
+	g = newGenerator(clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx, NewFixedScheduleFunc(time.Minute))
+	require.Eventually(t, func() bool {
+		return clock.Now().After(epoch.Add(time.Hour))
+	}, 5*time.Second, time.Millisecond)
+	require.Nil(t, g.PlayScenario(set))
+	played, _ = g.scenario.Played()
+	require.True(t, played.Origin.After(epoch.Add(time.Hour)))
+}
+
+func TestSimplePriceGenerator_PutScenario(t *testing.T) {
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Prices:      func(string) int64 { return 100000 },
+		Clock:       NewVirtualClock(epoch, 0, 0),
+		NewCache:    NewLimitedCacheFunc(10),
+		Logger:      logger.New(logger.Info),
+	})
+
+	w := httptest.NewRecorder()
+	g.GetScenario(w, httptest.NewRequest(http.MethodGet, "/scenario", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	g.PutScenario(w, httptest.NewRequest(http.MethodPut, "/scenario", strings.NewReader(`{"run":"qa","scenarios":[]}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	g.PutScenario(w, httptest.NewRequest(http.MethodPut, "/scenario", strings.NewReader(flashCrash)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var played v1.PlayedScenario
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&played))
+	require.Equal(t, epoch, played.Origin.UTC())
+	require.Equal(t, "qa", played.Scenario.Run)
+	require.Len(t, played.Scenario.Scenarios, 2)
+
+	w = httptest.NewRecorder()
+	g.DeleteScenario(w, httptest.NewRequest(http.MethodDelete, "/scenario", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	g.GetScenario(w, httptest.NewRequest(http.MethodGet, "/scenario", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestYAMLBodyDecoder(t *testing.T) {
+	value, err := YAMLBodyDecoder(strings.NewReader(flashCrash), nil, nil, nil)
+	require.Nil(t, err)
+
+	swagger, err := v1.GetSwagger()
+	require.Nil(t, err)
+	require.Nil(t, swagger.Components.Schemas["ScenarioSet"].Value.VisitJSON(value))
+}
+
+func TestSimplePriceGenerator_PutScenario_YAML(t *testing.T) {
+	openapi3filter.RegisterBodyDecoder("application/yaml", YAMLBodyDecoder)
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Prices:      func(string) int64 { return 100000 },
+		Clock:       NewVirtualClock(epoch, 0, 0),
+		NewCache:    NewLimitedCacheFunc(10),
+		Logger:      logger.New(logger.Info),
+	})
+	srv := newTestServer(t, g)
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL+"/scenario", strings.NewReader(flashCrash))
+	require.Nil(t, err)
+	req.Header.Set("Content-Type", "application/yaml")
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var played v1.PlayedScenario
+	require.Nil(t, json.NewDecoder(resp.Body).Decode(&played))
+	require.Equal(t, "qa", played.Scenario.Run)
+	require.Len(t, played.Scenario.Scenarios, 2)
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// minInterval keeps generated rates of currency pair ordered: times are rounded to microseconds
+const minInterval = time.Microsecond
+
+// Schedule returns intervals between rates of currency pair.
+// Schedule is used by one goroutine only.
+type Schedule interface {
+	Next() time.Duration
+}
+
+// ScheduleFinished is returned by Next when currency pair gets no more rates
+const ScheduleFinished = time.Duration(math.MaxInt64)
+
+// TimedSchedule chooses time of the first rate, other schedules start at the current time of clock.
+type TimedSchedule interface {
+	Schedule
+	// First returns time of the first rate of generation started at now, false if currency pair gets no rates
+	First(now time.Time) (time.Time, bool)
+}
+
+// ScheduleFunc creates schedule of currency pair when its generation starts
+type ScheduleFunc func(pair string) Schedule
+
+var (
+	_ Schedule = FixedSchedule(0)
+	_ Schedule = (*JitterSchedule)(nil)
+	_ Schedule = (*PoissonSchedule)(nil)
+)
+
+// FixedSchedule generates rates every period.
+type FixedSchedule time.Duration
+
+func (s FixedSchedule) Next() time.Duration {
+	return time.Duration(s)
+}
+
+// NewFixedScheduleFunc uses the same fixed period for every currency pair
+func NewFixedScheduleFunc(period time.Duration) ScheduleFunc {
+	return func(string) Schedule {
+		return FixedSchedule(period)
+	}
+}
+
+// JitterSchedule generates rates every period ± jitter, the interval is uniformly distributed.
+type JitterSchedule struct {
+	period time.Duration
+	jitter time.Duration
+	r      *rand.Rand
+}
+
+func NewJitterSchedule(period, jitter time.Duration, r *rand.Rand) *JitterSchedule {
+	return &JitterSchedule{
+		period: period,
+		jitter: jitter,
+		r:      r,
+	}
+}
+
+func (s *JitterSchedule) Next() time.Duration {
+	d := s.period - s.jitter + time.Duration(s.r.Int63n(int64(2*s.jitter)+1))
+	if d < minInterval {
+		return minInterval
+	}
+	return d
+}
+
+// PoissonSchedule generates rates as Poisson process: intervals are exponentially distributed
+// with mean 1/rate seconds.
+type PoissonSchedule struct {
+	rate float64
+	r    *rand.Rand
+}
+
+// NewPoissonSchedule creates schedule with rate of rates per second
+func NewPoissonSchedule(rate float64, r *rand.Rand) *PoissonSchedule {
+	return &PoissonSchedule{
+		rate: rate,
+		r:    r,
+	}
+}
+
+func (s *PoissonSchedule) Next() time.Duration {
+	d := time.Duration(s.r.ExpFloat64() / s.rate * float64(time.Second))
+	if d < minInterval {
+		return minInterval
+	}
+	return d
+}
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"context"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestJitterSchedule(t *testing.T) {
+	s := NewJitterSchedule(time.Second, 200*time.Millisecond, rand.New(rand.NewSource(1)))
+
+	var sum time.Duration
+	for i := 0; i < 10000; i++ {
+		d := s.Next()
+		require.GreaterOrEqual(t, d, 800*time.Millisecond)
+		require.LessOrEqual(t, d, 1200*time.Millisecond)
+		sum += d
+	}
+	require.InDelta(t, float64(time.Second), float64(sum/10000), float64(10*time.Millisecond))
+}
+
+func TestPoissonSchedule(t *testing.T) {
+	s := NewPoissonSchedule(50, rand.New(rand.NewSource(1)))
+
+	var sum time.Duration
+	for i := 0; i < 10000; i++ {
+		d := s.Next()
+		require.GreaterOrEqual(t, d, minInterval)
+		sum += d
+	}
+	// mean interval is 1/rate
+	require.InDelta(t, float64(20*time.Millisecond), float64(sum/10000), float64(time.Millisecond))
+}
+
+func TestSimplePriceGenerator_Start_Schedules(t *testing.T) {
+	clock := NewVirtualClock(epoch, 0, 10*time.Second)
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY", "USDRUB"}, GeneratorOptions{
+		Prices:   ExchangeRateFromTime,
+		Clock:    clock,
+		NewCache: NewLimitedCacheFunc(100),
+		Logger:   logger.New(logger.Error),
+	})
+
+	schedules := map[string]Schedule{
+		"EURUSD": FixedSchedule(time.Second),
+		"USDJPY": FixedSchedule(250 * time.Millisecond),
+		"USDRUB": NewPoissonSchedule(5, rand.New(rand.NewSource(1))),
+	}
+	g.Start(context.Background(), func(pair string) Schedule { return schedules[pair] })
+
+	buffer := make([]v1.ExchangeRate, 0, 100)
+
+	out, _, err := g.rates("EURUSD", nil, nil, buffer)
+	require.Nil(t, err)
+	require.Len(t, out, 11)
+
+	out, _, err = g.rates("USDJPY", nil, nil, buffer)
+	require.Nil(t, err)
+	require.Len(t, out, 41)
+	require.Equal(t, epoch.Add(250*time.Millisecond), out[1].Time)
+
+	out, _, err = g.rates("USDRUB", nil, nil, buffer)
+	require.Nil(t, err)
+	for i := 1; i < len(out); i++ {
+		require.True(t, out[i].Time.After(out[i-1].Time))
+	}
+	require.InDelta(t, 50, len(out), 25)
+}
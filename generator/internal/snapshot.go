@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"generator/pkg/cache"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ratesSnapshot is an encoded response of all cached rates of currency pair, its body is never changed after creation
+type ratesSnapshot struct {
+	// version is a version of cache, rates of which are encoded
+	version uint64
+	body    []byte
+	etag    string
+	// gz makes gzipped body, it's created on the first request accepting gzip
+	gz       bool
+	gzipOnce sync.Once
+	gzip     []byte
+}
+
+// newRatesSnapshot encodes rates as json.Encoder does, gzipped body is created lazily if gz is set
+func newRatesSnapshot(rates []v1.ExchangeRate, gz bool) (*ratesSnapshot, error) {
+	body, err := json.Marshal(rates)
+	if err != nil {
+		return nil, err
+	}
+	body = append(body, '\n')
+
+	h := fnv.New64a()
+	_, _ = h.Write(body)
+	return &ratesSnapshot{body: body, etag: fmt.Sprintf(`"%016x"`, h.Sum64()), gz: gz}, nil
+}
+
+// gzipped returns gzipped body, it is nil if body can't be gzipped
+func (r *ratesSnapshot) gzipped() []byte {
+	r.gzipOnce.Do(func() {
+		var b bytes.Buffer
+		zw := gzip.NewWriter(&b)
+		if _, err := zw.Write(r.body); err != nil {
+			return
+		}
+		if err := zw.Close(); err != nil {
+			return
+		}
+		r.gzip = b.Bytes()
+	})
+	return r.gzip
+}
+
+// serve writes snapshot, gzipped body is written if client accepts it.
+// Client that already has the snapshot of If-None-Match gets 304 without body.
+func (r *ratesSnapshot) serve(w http.ResponseWriter, req *http.Request) {
+	body, etag := r.body, r.etag
+	if r.gz {
+		w.Header().Set("Vary", "Accept-Encoding")
+		if gz := r.gzipped(); gz != nil && acceptsGzip(req.Header.Get("Accept-Encoding")) {
+			// representations of different encodings have different tags
+			body, etag = gz, strings.TrimSuffix(etag, `"`)+`-gzip"`
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+	}
+	w.Header().Set("ETag", etag)
+
+	if etagMatch(req.Header.Get("If-None-Match"), etag) {
+		w.Header().Del("Content-Encoding")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// content is set to application/json only that order
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// acceptsGzip reports whether Accept-Encoding allows gzip
+func acceptsGzip(acceptEncoding string) bool {
+	for _, e := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(e, ";")
+		if !strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			continue
+		}
+		// gzip;q=0 refuses gzip
+		params = strings.TrimSpace(params)
+		if strings.HasPrefix(params, "q=") {
+			q, err := strconv.ParseFloat(params[2:], 64)
+			return err != nil || q > 0
+		}
+		return true
+	}
+	return false
+}
+
+// etagMatch reports whether If-None-Match has etag
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, t := range strings.Split(ifNoneMatch, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotCache keeps encoded response of its rates, so requests of all rates aren't encoded.
+// Put only bumps version of rates, they are encoded at most once per version by the first request of snapshot.
+type snapshotCache struct {
+	// version is a number of Put calls, it's the first field to be aligned for atomic operations
+	version uint64
+	cache.Cache[v1.ExchangeRate]
+	gzip bool
+
+	// mu serializes encoding, requests of the same version wait for one snapshot
+	mu       sync.Mutex
+	buffer   []v1.ExchangeRate
+	snapshot atomic.Value
+}
+
+// NewSnapshotCacheFunc creates caches of newCache, which keep encoded response of their rates, gzipped too if gzip is set
+func NewSnapshotCacheFunc(newCache CacheFunc, gzip bool) CacheFunc {
+	return func() cache.Cache[v1.ExchangeRate] {
+		return &snapshotCache{Cache: newCache(), gzip: gzip}
+	}
+}
+
+func (c *snapshotCache) Put(v v1.ExchangeRate) {
+	c.Cache.Put(v)
+	atomic.AddUint64(&c.version, 1)
+}
+
+// Snapshot returns snapshot of the current rates, it's encoded if rates have changed since the last snapshot.
+// Request of rates is encoded if snapshot can't be created.
+func (c *snapshotCache) Snapshot() (*ratesSnapshot, bool) {
+	version := atomic.LoadUint64(&c.version)
+	if snapshot, ok := c.snapshot.Load().(*ratesSnapshot); ok && snapshot.version == version {
+		return snapshot, true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// snapshot could be encoded while waiting
+	version = atomic.LoadUint64(&c.version)
+	if snapshot, ok := c.snapshot.Load().(*ratesSnapshot); ok && snapshot.version == version {
+		return snapshot, true
+	}
+
+	// rates put after version was taken are encoded too, they are encoded again by the next request
+	c.buffer = c.Cache.Since(time.Time{}, c.buffer[:0])
+	if c.buffer == nil {
+		c.buffer = []v1.ExchangeRate{}
+	}
+	snapshot, err := newRatesSnapshot(c.buffer, c.gzip)
+	if err != nil {
+		return nil, false
+	}
+	snapshot.version = version
+	c.snapshot.Store(snapshot)
+	return snapshot, true
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"compress/gzip"
+	"context"
+	"generator/internal/api/http/v1"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSimplePriceGenerator_GetRatesCurrencyPair_Snapshot(t *testing.T) {
+	newCache := NewSnapshotCacheFunc(NewLimitedCacheFunc(5), true)
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Instruments: newScenarioRegistry(t),
+		Prices:      NewExchangeRateFromSeed(123),
+		Clock:       NewVirtualClock(epoch, 0, 9*time.Second),
+		NewCache:    newCache,
+		Logger:      logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	get := func(header http.Header, params v1.GetRatesCurrencyPairParams) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/rates/EURUSD", nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		g.GetRatesCurrencyPair(w, r, "EURUSD", params)
+		return w
+	}
+
+	// snapshot is the same as encoded response
+	limit := int32(10)
+	encoded := get(nil, v1.GetRatesCurrencyPairParams{Limit: &limit})
+	require.Empty(t, encoded.Header().Get("ETag"))
+
+	plain := get(nil, v1.GetRatesCurrencyPairParams{})
+	require.Equal(t, http.StatusOK, plain.Code)
+	require.Equal(t, encoded.Body.String(), plain.Body.String())
+	require.Equal(t, "application/json", plain.Header().Get("Content-Type"))
+	require.Empty(t, plain.Header().Get("Content-Encoding"))
+	etag := plain.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	gzipped := get(http.Header{"Accept-Encoding": {"deflate, gzip"}}, v1.GetRatesCurrencyPairParams{})
+	require.Equal(t, "gzip", gzipped.Header().Get("Content-Encoding"))
+	require.NotEqual(t, etag, gzipped.Header().Get("ETag"))
+	zr, err := gzip.NewReader(gzipped.Body)
+	require.Nil(t, err)
+	body, err := io.ReadAll(zr)
+	require.Nil(t, err)
+	require.Equal(t, encoded.Body.String(), string(body))
+
+	refused := get(http.Header{"Accept-Encoding": {"gzip;q=0"}}, v1.GetRatesCurrencyPairParams{})
+	require.Empty(t, refused.Header().Get("Content-Encoding"))
+
+	// client that has the snapshot gets no body
+	notModified := get(http.Header{"If-None-Match": {`"0", ` + etag}}, v1.GetRatesCurrencyPairParams{})
+	require.Equal(t, http.StatusNotModified, notModified.Code)
+	require.Empty(t, notModified.Body.String())
+
+	notModified = get(http.Header{"If-None-Match": {gzipped.Header().Get("ETag")}, "Accept-Encoding": {"gzip"}}, v1.GetRatesCurrencyPairParams{})
+	require.Equal(t, http.StatusNotModified, notModified.Code)
+
+	modified := get(http.Header{"If-None-Match": {`"0"`}}, v1.GetRatesCurrencyPairParams{})
+	require.Equal(t, http.StatusOK, modified.Code)
+}
+
+func TestSnapshotCache_Put(t *testing.T) {
+	c := NewSnapshotCacheFunc(NewLimitedCacheFunc(2), false)().(*snapshotCache)
+
+	snapshot, ok := c.Snapshot()
+	require.True(t, ok)
+	require.Equal(t, "[]\n", string(snapshot.body))
+	require.False(t, snapshot.gz)
+	// rates are encoded once per version
+	again, _ := c.Snapshot()
+	require.Same(t, snapshot, again)
+
+	c.Put(v1.ExchangeRate{Time: epoch, Rate: "1", Bid: "1", Ask: "1", Mid: "1"})
+	// Put doesn't encode rates
+	require.Same(t, snapshot, c.snapshot.Load())
+	next, _ := c.Snapshot()
+	require.NotEqual(t, snapshot.etag, next.etag)
+	// the previous snapshot isn't changed
+	require.Equal(t, "[]\n", string(snapshot.body))
+	require.Equal(t, `[{"ask":"1","bid":"1","mid":"1","rate":"1","time":"2022-08-01T00:00:00Z","volume":0}]`+"\n", string(next.body))
+}
@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"generator/internal/api/http/v1"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sseKeepAlive is a period of comments that keep idle stream open
+const sseKeepAlive = 15 * time.Second
+
+func (s *SimplePriceGenerator) GetRatesCurrencyPairStream(w http.ResponseWriter, r *http.Request, currencyPair string, params v1.GetRatesCurrencyPairStreamParams) {
+	s.stream(w, r, []string{currencyPair}, params.LastEventID)
+}
+
+func (s *SimplePriceGenerator) GetRatesStream(w http.ResponseWriter, r *http.Request, params v1.GetRatesStreamParams) {
+	s.stream(w, r, params.Pairs, params.LastEventID)
+}
+
+// stream writes rates of currency pairs as Server-Sent Events until request is done
+func (s *SimplePriceGenerator) stream(w http.ResponseWriter, r *http.Request, currencyPairs []string, lastEventID *string) {
+	loggerLine := "SimplePriceGenerator.stream: "
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeError(w, http.StatusInternalServerError, "streaming isn't supported")
+		return
+	}
+
+	cursor := streamCursor{}
+	if lastEventID != nil {
+		var err error
+		if cursor, err = parseStreamCursor(*lastEventID); err != nil {
+			s.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// without Last-Event-ID stream starts after the newest cached rate
+	feed := s.newRateFeed(cursor)
+	defer feed.close()
+	for _, p := range currencyPairs {
+		if err := feed.follow(p); err != nil {
+			s.writeError(w, http.StatusNotFound, fmt.Sprintf("service doesn't generate values for '%s'", p))
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	s.logger.Debug(loggerLine+"start: %v", currencyPairs)
+	defer s.logger.Debug(loggerLine+"end: %v", currencyPairs)
+
+	for {
+		err := feed.next(func(currencyPair string, event feedEvent, rate *v1.ExchangeRate) error {
+			switch event {
+			case feedGap:
+				_, err := fmt.Fprintf(w, "event: gap\ndata: {\"currency_pair\":%q}\n\n", currencyPair)
+				return err
+			case feedEnd:
+				// deleted currency pair is dropped, other pairs go on
+				_, err := fmt.Fprintf(w, "event: end\ndata: {\"currency_pair\":%q}\n\n", currencyPair)
+				return err
+			}
+			return writeEvent(w, currencyPair, feed.cursor.String(), *rate)
+		})
+		if err != nil {
+			s.logger.Debug(loggerLine+"write: %v", err)
+			return
+		}
+		flusher.Flush()
+
+		// stream ends when all its currency pairs are deleted
+		if len(feed.pairs) == 0 {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-feed.notify:
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event string, id string, rate v1.ExchangeRate) error {
+	data, err := json.Marshal(rate)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", id, event, data)
+	return err
+}
+
+// streamCursor keeps time of the last sent rate per currency pair.
+// It is encoded into event id as "EURUSD=<RFC 3339 time>,USDJPY=<RFC 3339 time>".
+type streamCursor map[string]time.Time
+
+func parseStreamCursor(id string) (streamCursor, error) {
+	c := streamCursor{}
+	for _, part := range strings.Split(id, ",") {
+		p, t, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid Last-Event-ID: %s", id)
+		}
+		tm, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Last-Event-ID: %w", err)
+		}
+		c[p] = tm
+	}
+	return c, nil
+}
+
+func (c streamCursor) String() string {
+	pairs := make([]string, 0, len(c))
+	for p := range c {
+		pairs = append(pairs, p)
+	}
+	sort.Strings(pairs)
+
+	b := strings.Builder{}
+	for _, p := range pairs {
+		if b.Len() != 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(p)
+		b.WriteByte('=')
+		b.WriteString(c[p].Format(time.RFC3339Nano))
+	}
+	return b.String()
+}
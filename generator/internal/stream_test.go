@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"generator/internal/api/http/v1"
+	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/go-chi/chi/v5"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+func newTestServer(t *testing.T, g *SimplePriceGenerator) *httptest.Server {
+	swagger, err := v1.GetSwagger()
+	require.Nil(t, err)
+	swagger.Servers = nil
+
+	r := chi.NewRouter()
+	// admin tokens aren't checked, as without AdminAuthenticator
+	r.Use(middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
+		Options: openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+	}))
+	v1.HandlerFromMux(g, r)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// readEvents reads n events from the stream at url
+func readEvents(t *testing.T, url string, lastEventID string, n int) []sseEvent {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.Nil(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	var (
+		events []sseEvent
+		e      sseEvent
+	)
+	scanner := bufio.NewScanner(resp.Body)
+	for len(events) < n && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			events = append(events, e)
+			e = sseEvent{}
+		case strings.HasPrefix(line, "id: "):
+			e.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			e.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	require.Len(t, events, n)
+	return events
+}
+
+func TestSimplePriceGenerator_Stream(t *testing.T) {
+	// one simulated second every 10 milliseconds
+	clock := NewVirtualClock(epoch, 100, 0)
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    clock,
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx, NewFixedScheduleFunc(time.Second))
+
+	srv := newTestServer(t, g)
+
+	events := readEvents(t, srv.URL+"/rates/EURUSD/stream", "", 3)
+	for i, e := range events {
+		require.Equal(t, "EURUSD", e.event)
+		require.True(t, strings.HasPrefix(e.id, "EURUSD="))
+		if i > 0 {
+			require.Greater(t, e.id, events[i-1].id)
+		}
+	}
+
+	events = readEvents(t, srv.URL+"/rates/stream?pairs=EURUSD,USDJPY", "", 6)
+	seen := map[string]bool{}
+	for _, e := range events {
+		seen[e.event] = true
+	}
+	require.Equal(t, map[string]bool{"EURUSD": true, "USDJPY": true}, seen)
+	require.Contains(t, events[len(events)-1].id, "EURUSD=")
+	require.Contains(t, events[len(events)-1].id, "USDJPY=")
+}
+
+func TestSimplePriceGenerator_Stream_Resume(t *testing.T) {
+	// rates are generated every second from epoch to epoch+9s, cache keeps the last 5
+	g := NewSimplePriceGenerator([]string{"EURUSD"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 0, 9*time.Second),
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	g.Start(context.Background(), NewFixedScheduleFunc(time.Second))
+
+	srv := newTestServer(t, g)
+
+	events := readEvents(t, srv.URL+"/rates/EURUSD/stream", "EURUSD=2022-08-01T00:00:06Z", 3)
+	require.Equal(t, "EURUSD=2022-08-01T00:00:07Z", events[0].id)
+	require.Equal(t, "EURUSD=2022-08-01T00:00:09Z", events[2].id)
+	require.Contains(t, events[0].data, `"time":"2022-08-01T00:00:07Z"`)
+
+	events = readEvents(t, srv.URL+"/rates/EURUSD/stream", "EURUSD=2022-08-01T00:00:03Z", 2)
+	require.Equal(t, "gap", events[0].event)
+	require.Equal(t, "EURUSD=2022-08-01T00:00:05Z", events[1].id)
+}
+
+func TestSimplePriceGenerator_Stream_DeletedPair(t *testing.T) {
+	// one simulated second every 10 milliseconds
+	g := NewSimplePriceGenerator([]string{"EURUSD", "USDJPY"}, GeneratorOptions{
+		Prices:   NewExchangeRateFromSeed(123),
+		Clock:    NewVirtualClock(epoch, 100, 0),
+		NewCache: NewLimitedCacheFunc(5),
+		Logger:   logger.New(logger.Info),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx, NewFixedScheduleFunc(time.Second))
+
+	srv := newTestServer(t, g)
+
+	reqCtx, reqCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer reqCancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, srv.URL+"/rates/stream?pairs=EURUSD,USDJPY", nil)
+	require.Nil(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// deleted pair gets end event and is dropped, stream goes on until all its pairs are deleted
+	var (
+		events []string
+		after  []string
+		ended  bool
+	)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "event: ") {
+			continue
+		}
+		event := strings.TrimPrefix(line, "event: ")
+		if event == "end" && scanner.Scan() {
+			event += " " + strings.TrimPrefix(scanner.Text(), "data: ")
+		}
+		events = append(events, event)
+		if ended {
+			after = append(after, event)
+		}
+
+		switch {
+		case len(events) == 1:
+			require.Nil(t, g.DeletePair("EURUSD"))
+		case event == `end {"currency_pair":"EURUSD"}`:
+			ended = true
+		case ended && event == "USDJPY" && len(after) == 1:
+			require.Nil(t, g.DeletePair("USDJPY"))
+		}
+	}
+	require.Nil(t, scanner.Err())
+
+	require.True(t, ended)
+	require.NotContains(t, after, "EURUSD")
+	require.Equal(t, "USDJPY", after[0])
+	require.Equal(t, `end {"currency_pair":"USDJPY"}`, events[len(events)-1])
+}
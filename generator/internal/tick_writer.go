@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"generator/internal/api/http/v1"
+	"github.com/xitongsys/parquet-go/writer"
+	"io"
+	"strconv"
+	"time"
+)
+
+var ErrTickFormat = errors.New("unknown tick format, must be csv, ndjson or parquet")
+
+const (
+	TickFormatCSV     = "csv"
+	TickFormatNDJSON  = "ndjson"
+	TickFormatParquet = "parquet"
+)
+
+// TickWriter writes rates of currency pairs to tick file. Files of CSV and NDJSON writers can be replayed by REPLAY pattern.
+type TickWriter interface {
+	Write(pair string, rate v1.ExchangeRate) error
+	// Close flushes written rates, underlying writer isn't closed
+	Close() error
+}
+
+var (
+	_ TickWriter = (*CSVTickWriter)(nil)
+	_ TickWriter = (*NDJSONTickWriter)(nil)
+	_ TickWriter = (*ParquetTickWriter)(nil)
+)
+
+// NewTickWriter creates writer of format
+func NewTickWriter(w io.Writer, format string) (TickWriter, error) {
+	switch format {
+	case TickFormatCSV:
+		return NewCSVTickWriter(w), nil
+	case TickFormatNDJSON:
+		return NewNDJSONTickWriter(w), nil
+	case TickFormatParquet:
+		return NewParquetTickWriter(w)
+	}
+	return nil, ErrTickFormat
+}
+
+// csvTickHeader are columns of CSV tick file
+var csvTickHeader = []string{"pair", "time", "rate", "bid", "ask", "mid", "volume"}
+
+// CSVTickWriter writes rates as CSV with header, times are in RFC 3339 format
+type CSVTickWriter struct {
+	w      *csv.Writer
+	header bool
+	record []string
+}
+
+func NewCSVTickWriter(w io.Writer) *CSVTickWriter {
+	return &CSVTickWriter{w: csv.NewWriter(w), record: make([]string, len(csvTickHeader))}
+}
+
+func (c *CSVTickWriter) Write(pair string, rate v1.ExchangeRate) error {
+	if !c.header {
+		c.header = true
+		if err := c.w.Write(csvTickHeader); err != nil {
+			return err
+		}
+	}
+
+	c.record[0] = pair
+	c.record[1] = rate.Time.UTC().Format(time.RFC3339Nano)
+	c.record[2] = rate.Rate
+	c.record[3] = rate.Bid
+	c.record[4] = rate.Ask
+	c.record[5] = rate.Mid
+	c.record[6] = strconv.FormatInt(rate.Volume, 10)
+	return c.w.Write(c.record)
+}
+
+func (c *CSVTickWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// NDJSONTickWriter writes each rate as JSON object with pair on its own line
+type NDJSONTickWriter struct {
+	e *json.Encoder
+}
+
+func NewNDJSONTickWriter(w io.Writer) *NDJSONTickWriter {
+	return &NDJSONTickWriter{e: json.NewEncoder(w)}
+}
+
+func (n *NDJSONTickWriter) Write(pair string, rate v1.ExchangeRate) error {
+	rate.Time = rate.Time.UTC()
+	return n.e.Encode(struct {
+		Pair string `json:"pair"`
+		v1.ExchangeRate
+	}{Pair: pair, ExchangeRate: rate})
+}
+
+func (n *NDJSONTickWriter) Close() error {
+	return nil
+}
+
+// parquetTick is a row of Parquet tick file, prices are decimal strings as in JSON
+type parquetTick struct {
+	Pair   string `parquet:"name=pair, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Time   int64  `parquet:"name=time, type=INT64, convertedtype=TIMESTAMP_MICROS"`
+	Rate   string `parquet:"name=rate, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Bid    string `parquet:"name=bid, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Ask    string `parquet:"name=ask, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Mid    string `parquet:"name=mid, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Volume int64  `parquet:"name=volume, type=INT64"`
+}
+
+// ParquetTickWriter writes rates as Parquet file, times are microseconds since Unix epoch
+type ParquetTickWriter struct {
+	w *writer.ParquetWriter
+}
+
+func NewParquetTickWriter(w io.Writer) (*ParquetTickWriter, error) {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(parquetTick), 1)
+	if err != nil {
+		return nil, err
+	}
+	return &ParquetTickWriter{w: pw}, nil
+}
+
+func (p *ParquetTickWriter) Write(pair string, rate v1.ExchangeRate) error {
+	return p.w.Write(parquetTick{
+		Pair:   pair,
+		Time:   rate.Time.UnixMicro(),
+		Rate:   rate.Rate,
+		Bid:    rate.Bid,
+		Ask:    rate.Ask,
+		Mid:    rate.Mid,
+		Volume: rate.Volume,
+	})
+}
+
+func (p *ParquetTickWriter) Close() error {
+	return p.w.WriteStop()
+}
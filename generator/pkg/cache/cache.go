@@ -1,30 +1,67 @@
 package cache
 
-import "sync"
+import (
+	"sort"
+	"sync"
+	"time"
+)
 
+// Cache keeps the latest values in order they were put. Time of value is given to constructor of cache.
 type Cache[T any] interface {
 	Put(v T)
 	Fill([]T) []T
+	// Len returns number of cached values
+	Len() int
+	// Last returns the last put value
+	Last() (T, bool)
+	// Range appends values created from from until to inclusive to out
+	Range(from, to time.Time, out []T) []T
+	// Since appends values put after the last value created at t to out, or values created after t if there is no such value
+	Since(t time.Time, out []T) []T
+	// Evicted returns the last value that was pushed out of cache
+	Evicted() (T, bool)
+	// Subscribe makes cache signal to ch after every Put until returned func is called.
+	// Signals are dropped while ch is full, so buffered ch of size 1 coalesces them.
+	Subscribe(ch chan<- struct{}) func()
+	// Close signals subscribers that cache won't get new values. Subscribers should check Closed after every signal.
+	Close()
+	// Closed reports whether Close was called
+	Closed() bool
 }
 
 var _ Cache[any] = (*LimitedCache[any])(nil)
 
+// LimitedCache keeps the last limit values
 type LimitedCache[T any] struct {
-	mu    sync.RWMutex
-	index int
-	s     []T
+	notifier
+	mu         sync.RWMutex
+	timeOf     func(T) time.Time
+	index      int
+	s          []T
+	evicted    T
+	hasEvicted bool
 }
 
-func NewLimitedCache[T any](limit uint64) *LimitedCache[T] {
+func NewLimitedCache[T any](limit uint64, timeOf func(T) time.Time) *LimitedCache[T] {
 	return &LimitedCache[T]{
-		index: 0,
-		s:     make([]T, 0, limit),
+		timeOf: timeOf,
+		index:  0,
+		s:      make([]T, 0, limit),
 	}
 }
 
 func (l *LimitedCache[T]) Put(v T) {
+	l.put(v)
+	l.notify()
+}
+
+func (l *LimitedCache[T]) put(v T) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if len(l.s) == cap(l.s) && len(l.s) != 0 {
+		l.evicted = l.s[l.index]
+		l.hasEvicted = true
+	}
 	_ = append(l.s[:l.index], v)
 	if len(l.s) != cap(l.s) {
 		l.s = l.s[:l.index+1]
@@ -49,3 +86,81 @@ func (l *LimitedCache[T]) Fill(out []T) []T {
 
 	return out
 }
+
+// at returns i-th value from the oldest one. Must be called with mu held.
+func (l *LimitedCache[T]) at(i int) T {
+	return l.s[(l.index+i)%len(l.s)]
+}
+
+func (l *LimitedCache[T]) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.s)
+}
+
+func (l *LimitedCache[T]) Last() (T, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if len(l.s) == 0 {
+		var zero T
+		return zero, false
+	}
+	return l.at(len(l.s) - 1), true
+}
+
+func (l *LimitedCache[T]) Range(from, to time.Time, out []T) []T {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return appendRange(out, len(l.s), l.at, l.timeOf, from, to)
+}
+
+func (l *LimitedCache[T]) Since(t time.Time, out []T) []T {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for i := sinceIndex(len(l.s), l.at, l.timeOf, t); i < len(l.s); i++ {
+		out = append(out, l.at(i))
+	}
+	return out
+}
+
+func (l *LimitedCache[T]) Evicted() (T, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.evicted, l.hasEvicted
+}
+
+// appendRange appends values created from from until to of n values to out.
+// Values put out of time order are checked too.
+func appendRange[T any](out []T, n int, at func(int) T, timeOf func(T) time.Time, from, to time.Time) []T {
+	for i := 0; i < n; i++ {
+		v := at(i)
+		if t := timeOf(v); !t.Before(from) && !t.After(to) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// sinceIndex returns index of the value put after the last value created at t, or the first value created after t.
+// Values are ordered by time unless some were put late, late values put after the value at t are included too.
+func sinceIndex[T any](n int, at func(int) T, timeOf func(T) time.Time, t time.Time) int {
+	i := sort.Search(n, func(i int) bool {
+		return timeOf(at(i)).After(t)
+	})
+	if i > 0 && timeOf(at(i-1)).Equal(t) {
+		return i
+	}
+
+	// binary search is only right for ordered values, so late values are found by linear scan
+	for j := n - 1; j >= 0; j-- {
+		if timeOf(at(j)).Equal(t) {
+			return j + 1
+		}
+	}
+	for j := 0; j < n; j++ {
+		if timeOf(at(j)).After(t) {
+			return j
+		}
+	}
+	return n
+}
@@ -3,11 +3,19 @@ package cache
 import (
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
+var epoch = time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+
+// seconds is time of value: value seconds after epoch
+func seconds(v int) time.Time {
+	return epoch.Add(time.Duration(v) * time.Second)
+}
+
 func TestLimitedCache1(t *testing.T) {
 
-	c := NewLimitedCache[int](3)
+	c := NewLimitedCache[int](3, seconds)
 	out := make([]int, 0, 5)
 	c.Fill(out)
 	require.ElementsMatch(t, c.Fill(out), []int{})
@@ -36,7 +44,7 @@ func TestLimitedCache1(t *testing.T) {
 
 func TestLimitedCache2(t *testing.T) {
 
-	c := NewLimitedCache[int](3)
+	c := NewLimitedCache[int](3, seconds)
 	out := make([]int, 0, 2)
 	out = c.Fill(out)
 	require.ElementsMatch(t, out, []int{})
@@ -56,7 +64,7 @@ func TestLimitedCache2(t *testing.T) {
 
 func TestLimitedCache_Fill_DecreaseSliceLen(t *testing.T) {
 
-	c := NewLimitedCache[int](5)
+	c := NewLimitedCache[int](5, seconds)
 	out := make([]int, 4)
 	c.Put(1)
 	c.Put(2)
@@ -64,3 +72,116 @@ func TestLimitedCache_Fill_DecreaseSliceLen(t *testing.T) {
 	out = c.Fill(out)
 	require.ElementsMatch(t, out, []int{1, 2, 3})
 }
+
+func TestLimitedCache_Evicted(t *testing.T) {
+
+	c := NewLimitedCache[int](2, seconds)
+	_, ok := c.Evicted()
+	require.False(t, ok)
+
+	c.Put(1)
+	c.Put(2)
+	_, ok = c.Evicted()
+	require.False(t, ok)
+
+	c.Put(3)
+	v, ok := c.Evicted()
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	c.Put(4)
+	v, _ = c.Evicted()
+	require.Equal(t, 2, v)
+}
+
+func TestLimitedCache_Subscribe(t *testing.T) {
+
+	c := NewLimitedCache[int](2, seconds)
+	ch := make(chan struct{}, 1)
+	unsubscribe := c.Subscribe(ch)
+
+	c.Put(1)
+	c.Put(2)
+	require.Len(t, ch, 1)
+	<-ch
+	require.Len(t, ch, 0)
+
+	unsubscribe()
+	c.Put(3)
+	require.Len(t, ch, 0)
+}
+
+func TestLimitedCache_Close(t *testing.T) {
+
+	c := NewLimitedCache[int](2, seconds)
+	ch := make(chan struct{}, 1)
+	unsubscribe := c.Subscribe(ch)
+	defer unsubscribe()
+
+	c.Put(1)
+	<-ch
+	require.False(t, c.Closed())
+
+	c.Close()
+	require.Len(t, ch, 1)
+	require.True(t, c.Closed())
+
+	// late subscriber is signaled at once
+	late := make(chan struct{}, 1)
+	unsubscribeLate := c.Subscribe(late)
+	defer unsubscribeLate()
+	require.Len(t, late, 1)
+}
+
+func TestLimitedCache_Since(t *testing.T) {
+
+	c := NewLimitedCache[int](4, seconds)
+	_, ok := c.Last()
+	require.False(t, ok)
+	require.Empty(t, c.Since(epoch, nil))
+
+	for _, v := range []int{1, 2, 3, 4, 5} {
+		c.Put(v)
+	}
+	require.Equal(t, 4, c.Len())
+	v, ok := c.Last()
+	require.True(t, ok)
+	require.Equal(t, 5, v)
+
+	require.Equal(t, []int{2, 3, 4, 5}, c.Since(time.Time{}, nil))
+	require.Equal(t, []int{4, 5}, c.Since(seconds(3), nil))
+	require.Equal(t, []int{4, 5}, c.Since(seconds(3).Add(time.Millisecond), nil))
+	require.Empty(t, c.Since(seconds(5), nil))
+	// values are appended to out
+	require.Equal(t, []int{0, 5}, c.Since(seconds(4), []int{0}))
+
+	require.Equal(t, []int{3, 4}, c.Range(seconds(3), seconds(4), nil))
+	require.Equal(t, []int{2, 3, 4, 5}, c.Range(epoch, seconds(10), nil))
+	require.Empty(t, c.Range(seconds(6), seconds(10), nil))
+}
+
+func TestLimitedCache_Since_Late(t *testing.T) {
+
+	c := NewLimitedCache[int](5, seconds)
+	for _, v := range []int{1, 3, 2, 4} {
+		c.Put(v)
+	}
+
+	// late value put after the value at t is returned too
+	require.Equal(t, []int{2, 4}, c.Since(seconds(3), nil))
+	require.Equal(t, []int{3, 2, 4}, c.Since(seconds(1), nil))
+	require.Equal(t, []int{3, 2}, c.Range(seconds(2), seconds(3), nil))
+}
+
+func TestLimitedCache_Since_LateMissing(t *testing.T) {
+
+	c := NewLimitedCache[int](5, seconds)
+	for _, v := range []int{1, 5, 2, 4, 6} {
+		c.Put(v)
+	}
+
+	// without value at t every value put after the first value created after t is returned
+	require.Equal(t, []int{5, 2, 4, 6}, c.Since(seconds(3), nil))
+	require.Equal(t, []int{2, 4, 6}, c.Since(seconds(5), nil))
+	require.Empty(t, c.Since(seconds(7), nil))
+}
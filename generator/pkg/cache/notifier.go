@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// notifier signals subscribers about new values and about closing of cache.
+// Subscribers are copied on write, so notify takes no lock.
+type notifier struct {
+	// mu serializes changes of subs and closed
+	mu sync.Mutex
+	// subs keeps []chan<- struct{}, the slice is never changed after Store
+	subs   atomic.Value
+	closed int32
+}
+
+// load returns subscribers, zero notifier has none
+func (n *notifier) load() []chan<- struct{} {
+	subs, _ := n.subs.Load().([]chan<- struct{})
+	return subs
+}
+
+func (n *notifier) Subscribe(ch chan<- struct{}) func() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	subs := n.load()
+	if indexOf(subs, ch) < 0 {
+		n.subs.Store(append(subs[:len(subs):len(subs)], ch))
+	}
+
+	// subscriber of closed cache learns about it at once
+	if n.Closed() {
+		signal(ch)
+	}
+
+	return func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		subs := n.load()
+		if i := indexOf(subs, ch); i >= 0 {
+			rest := make([]chan<- struct{}, 0, len(subs)-1)
+			n.subs.Store(append(append(rest, subs[:i]...), subs[i+1:]...))
+		}
+	}
+}
+
+func (n *notifier) Close() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !atomic.CompareAndSwapInt32(&n.closed, 0, 1) {
+		return
+	}
+	for _, ch := range n.load() {
+		signal(ch)
+	}
+}
+
+func (n *notifier) Closed() bool {
+	return atomic.LoadInt32(&n.closed) == 1
+}
+
+// notify never blocks: it takes no lock, and subscriber that hasn't received previous signal misses the new one
+func (n *notifier) notify() {
+	for _, ch := range n.load() {
+		signal(ch)
+	}
+}
+
+func signal(ch chan<- struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func indexOf(subs []chan<- struct{}, ch chan<- struct{}) int {
+	for i := range subs {
+		if subs[i] == ch {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var _ Cache[any] = (*RingCache[any])(nil)
+
+// RingCache keeps the last limit values like LimitedCache, but its readers never block the writer.
+// Put must be called from one goroutine, other methods are safe for concurrent use.
+//
+// Each value gets a sequence number. Writer stores immutable entry of value to its slot and then publishes
+// number of put values. Readers take values from the last published ones and skip slots overwritten meanwhile.
+type RingCache[T any] struct {
+	notifier
+	timeOf func(T) time.Time
+	slots  []atomic.Value
+	// n is a number of put values, the next value gets sequence number n
+	n       uint64
+	evicted atomic.Value
+}
+
+// ringEntry is a value with its sequence number, entries are never changed after Put
+type ringEntry[T any] struct {
+	seq uint64
+	v   T
+}
+
+func NewRingCache[T any](limit uint64, timeOf func(T) time.Time) *RingCache[T] {
+	return &RingCache[T]{
+		timeOf: timeOf,
+		slots:  make([]atomic.Value, limit),
+	}
+}
+
+func (r *RingCache[T]) Put(v T) {
+	r.put(v)
+	r.notify()
+}
+
+func (r *RingCache[T]) put(v T) {
+	if len(r.slots) == 0 {
+		return
+	}
+
+	n := atomic.LoadUint64(&r.n)
+	slot := &r.slots[n%uint64(len(r.slots))]
+	if old := slot.Load(); old != nil {
+		r.evicted.Store(old)
+	}
+	slot.Store(&ringEntry[T]{seq: n, v: v})
+	atomic.StoreUint64(&r.n, n+1)
+}
+
+// entry returns value of sequence number seq, it is false if the value was overwritten
+func (r *RingCache[T]) entry(seq uint64) (T, bool) {
+	e, _ := r.slots[seq%uint64(len(r.slots))].Load().(*ringEntry[T])
+	if e == nil || e.seq != seq {
+		var zero T
+		return zero, false
+	}
+	return e.v, true
+}
+
+// snapshot appends cached values from the oldest one to out
+func (r *RingCache[T]) snapshot(out []T) []T {
+	n := atomic.LoadUint64(&r.n)
+	start := uint64(0)
+	if size := uint64(len(r.slots)); n > size {
+		start = n - size
+	}
+	for seq := start; seq < n; seq++ {
+		// the oldest values are overwritten while writer is ahead of reader
+		if v, ok := r.entry(seq); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (r *RingCache[T]) Fill(out []T) []T {
+	values := r.snapshot(nil)
+	if cap(out) < len(values) {
+		return out
+	}
+
+	out = out[:len(values)]
+	copy(out, values)
+
+	return out
+}
+
+func (r *RingCache[T]) Len() int {
+	n := atomic.LoadUint64(&r.n)
+	if size := uint64(len(r.slots)); n > size {
+		return len(r.slots)
+	}
+	return int(n)
+}
+
+func (r *RingCache[T]) Last() (T, bool) {
+	for {
+		n := atomic.LoadUint64(&r.n)
+		if n == 0 {
+			var zero T
+			return zero, false
+		}
+		// the last value is overwritten only if writer has put the whole ring since n was loaded
+		if v, ok := r.entry(n - 1); ok {
+			return v, true
+		}
+	}
+}
+
+func (r *RingCache[T]) Range(from, to time.Time, out []T) []T {
+	base := len(out)
+	out = r.snapshot(out)
+	values := out[base:]
+	return appendRange(out[:base], len(values), func(i int) T { return values[i] }, r.timeOf, from, to)
+}
+
+func (r *RingCache[T]) Since(t time.Time, out []T) []T {
+	base := len(out)
+	out = r.snapshot(out)
+	values := out[base:]
+	i := sinceIndex(len(values), func(i int) T { return values[i] }, r.timeOf, t)
+	return append(out[:base], values[i:]...)
+}
+
+func (r *RingCache[T]) Evicted() (T, bool) {
+	e, _ := r.evicted.Load().(*ringEntry[T])
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return e.v, true
+}
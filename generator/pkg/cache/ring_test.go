@@ -0,0 +1,218 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRingCache(t *testing.T) {
+
+	c := NewRingCache[int](3, seconds)
+	out := make([]int, 0, 5)
+	require.Empty(t, c.Fill(out))
+	_, ok := c.Last()
+	require.False(t, ok)
+	_, ok = c.Evicted()
+	require.False(t, ok)
+
+	for _, v := range []int{1, 2, 3} {
+		c.Put(v)
+	}
+	require.Equal(t, []int{1, 2, 3}, c.Fill(out))
+	_, ok = c.Evicted()
+	require.False(t, ok)
+
+	c.Put(4)
+	c.Put(5)
+	require.Equal(t, []int{3, 4, 5}, c.Fill(out))
+	require.Equal(t, 3, c.Len())
+	v, ok := c.Evicted()
+	require.True(t, ok)
+	require.Equal(t, 2, v)
+	v, ok = c.Last()
+	require.True(t, ok)
+	require.Equal(t, 5, v)
+
+	require.Equal(t, []int{4, 5}, c.Since(seconds(3), nil))
+	require.Equal(t, []int{0, 3, 4}, c.Range(seconds(2), seconds(4), []int{0}))
+	// small slice isn't filled
+	require.Equal(t, []int{0}, c.Fill([]int{0}))
+}
+
+func TestRingCache_Empty(t *testing.T) {
+
+	c := NewRingCache[int](0, seconds)
+	c.Put(1)
+	require.Equal(t, 0, c.Len())
+	require.Empty(t, c.Since(time.Time{}, nil))
+	_, ok := c.Evicted()
+	require.False(t, ok)
+}
+
+func TestRingCache_Subscribe(t *testing.T) {
+
+	c := NewRingCache[int](2, seconds)
+	ch := make(chan struct{}, 1)
+	unsubscribe := c.Subscribe(ch)
+
+	c.Put(1)
+	require.Len(t, ch, 1)
+	<-ch
+
+	other := make(chan struct{}, 1)
+	unsubscribeOther := c.Subscribe(other)
+	defer unsubscribeOther()
+
+	unsubscribe()
+	c.Put(2)
+	require.Len(t, ch, 0)
+	require.Len(t, other, 1)
+	<-other
+
+	// Put doesn't wait for subscribing and closing
+	c.mu.Lock()
+	put := make(chan struct{})
+	go func() {
+		c.Put(3)
+		close(put)
+	}()
+	blocked := false
+	select {
+	case <-put:
+	case <-time.After(time.Second):
+		blocked = true
+	}
+	c.mu.Unlock()
+	<-put
+	require.False(t, blocked, "Put is blocked by notifier")
+	require.Len(t, other, 1)
+}
+
+// TestRingCache_Concurrent is meant to be run with -race: readers see contiguous values while writer puts new ones
+func TestRingCache_Concurrent(t *testing.T) {
+
+	const puts = 10000
+	c := NewRingCache[int](16, seconds)
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out := make([]int, 0, 16)
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				// value evicted before taking values is never newer than the oldest of them
+				evicted, evictedOK := c.Evicted()
+				out = c.Since(time.Time{}, out[:0])
+				assert.LessOrEqual(t, len(out), 16)
+				for j := 1; j < len(out); j++ {
+					assert.Equal(t, out[j-1]+1, out[j])
+				}
+				if len(out) == 0 {
+					continue
+				}
+				if evictedOK {
+					assert.LessOrEqual(t, evicted, out[0])
+				}
+				last, _ := c.Last()
+				assert.GreaterOrEqual(t, last, out[len(out)-1])
+			}
+		}()
+	}
+
+	for v := 1; v <= puts; v++ {
+		c.Put(v)
+	}
+	close(done)
+	wg.Wait()
+
+	last, _ := c.Last()
+	require.Equal(t, puts, last)
+	require.Equal(t, puts-16, c.Fill(make([]int, 0, 16))[0]-1)
+}
+
+// benchmarkContention measures readers taking all values while writer puts a new value every tick
+func benchmarkContention(b *testing.B, c Cache[int], tick time.Duration) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for v := 0; ; v++ {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				c.Put(v)
+			}
+		}
+	}()
+	for v := 0; v < 100; v++ {
+		c.Put(v)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		out := make([]int, 0, 100)
+		for pb.Next() {
+			out = c.Since(time.Time{}, out[:0])
+		}
+	})
+}
+
+func BenchmarkLimitedCache_Contention(b *testing.B) {
+	benchmarkContention(b, NewLimitedCache[int](100, seconds), time.Microsecond)
+}
+
+func BenchmarkRingCache_Contention(b *testing.B) {
+	benchmarkContention(b, NewRingCache[int](100, seconds), time.Microsecond)
+}
+
+// benchmarkPut measures writer putting values while readers take all values
+func benchmarkPut(b *testing.B, c Cache[int]) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out := make([]int, 0, 100)
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					out = c.Since(time.Time{}, out[:0])
+				}
+			}
+		}()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for v := 0; v < b.N; v++ {
+		c.Put(v)
+	}
+	b.StopTimer()
+	close(done)
+	wg.Wait()
+}
+
+func BenchmarkLimitedCache_Put(b *testing.B) {
+	benchmarkPut(b, NewLimitedCache[int](100, seconds))
+}
+
+func BenchmarkRingCache_Put(b *testing.B) {
+	benchmarkPut(b, NewRingCache[int](100, seconds))
+}
@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+var _ Cache[any] = (*WindowCache[any])(nil)
+
+// WindowCache keeps values created within window before now of its clock, but no more than limit values
+type WindowCache[T any] struct {
+	notifier
+	mu         sync.Mutex
+	timeOf     func(T) time.Time
+	now        func() time.Time
+	window     time.Duration
+	limit      int
+	s          []T
+	evicted    T
+	hasEvicted bool
+}
+
+// NewWindowCache creates cache of values created within window before now, zero limit doesn't limit number of values
+func NewWindowCache[T any](window time.Duration, limit uint64, timeOf func(T) time.Time, now func() time.Time) *WindowCache[T] {
+	return &WindowCache[T]{
+		timeOf: timeOf,
+		now:    now,
+		window: window,
+		limit:  int(limit),
+	}
+}
+
+func (w *WindowCache[T]) Put(v T) {
+	w.put(v)
+	w.notify()
+}
+
+func (w *WindowCache[T]) put(v T) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	oldest := w.expire()
+	// late value older than window is evicted at once
+	if w.timeOf(v).Before(oldest) {
+		w.evict(v)
+		return
+	}
+	w.s = append(w.s, v)
+
+	for w.limit != 0 && len(w.s) > w.limit {
+		var zero T
+		w.evict(w.s[0])
+		w.s[0] = zero
+		w.s = w.s[1:]
+	}
+}
+
+// expire evicts all values created before window, late values among newer ones too, and returns start of window.
+// Must be called with mu held.
+func (w *WindowCache[T]) expire() time.Time {
+	oldest := w.now().Add(-w.window)
+
+	kept := w.s[:0]
+	for _, v := range w.s {
+		if w.timeOf(v).Before(oldest) {
+			w.evict(v)
+			continue
+		}
+		kept = append(kept, v)
+	}
+	var zero T
+	for i := len(kept); i < len(w.s); i++ {
+		w.s[i] = zero
+	}
+	w.s = kept
+
+	return oldest
+}
+
+// evict keeps the newest of evicted values. Must be called with mu held.
+func (w *WindowCache[T]) evict(v T) {
+	if !w.hasEvicted || w.timeOf(v).After(w.timeOf(w.evicted)) {
+		w.evicted, w.hasEvicted = v, true
+	}
+}
+
+func (w *WindowCache[T]) Fill(out []T) []T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	if cap(out) < len(w.s) {
+		return out
+	}
+
+	out = out[:len(w.s)]
+	copy(out, w.s)
+
+	return out
+}
+
+func (w *WindowCache[T]) at(i int) T {
+	return w.s[i]
+}
+
+func (w *WindowCache[T]) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	return len(w.s)
+}
+
+func (w *WindowCache[T]) Last() (T, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	if len(w.s) == 0 {
+		var zero T
+		return zero, false
+	}
+	return w.s[len(w.s)-1], true
+}
+
+func (w *WindowCache[T]) Range(from, to time.Time, out []T) []T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	return appendRange(out, len(w.s), w.at, w.timeOf, from, to)
+}
+
+func (w *WindowCache[T]) Since(t time.Time, out []T) []T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	return append(out, w.s[sinceIndex(len(w.s), w.at, w.timeOf, t):]...)
+}
+
+func (w *WindowCache[T]) Evicted() (T, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.evicted, w.hasEvicted
+}
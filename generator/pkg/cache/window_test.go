@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// clockAt returns clock showing *now
+func clockAt(now *time.Time) func() time.Time {
+	return func() time.Time { return *now }
+}
+
+func TestWindowCache(t *testing.T) {
+
+	now := seconds(0)
+	c := NewWindowCache[int](3*time.Second, 0, seconds, clockAt(&now))
+	out := make([]int, 0, 10)
+	require.Empty(t, c.Fill(out))
+	_, ok := c.Evicted()
+	require.False(t, ok)
+
+	put := func(v int) {
+		if seconds(v).After(now) {
+			now = seconds(v)
+		}
+		c.Put(v)
+	}
+	for _, v := range []int{1, 2, 3, 4} {
+		put(v)
+	}
+	// values older than 3 seconds before now are evicted
+	require.Equal(t, []int{1, 2, 3, 4}, c.Fill(out))
+
+	put(5)
+	require.Equal(t, []int{2, 3, 4, 5}, c.Fill(out))
+	require.Equal(t, 4, c.Len())
+	v, ok := c.Evicted()
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	put(10)
+	require.Equal(t, []int{10}, c.Fill(out))
+	v, _ = c.Evicted()
+	require.Equal(t, 5, v)
+	v, ok = c.Last()
+	require.True(t, ok)
+	require.Equal(t, 10, v)
+
+	// late value out of window is evicted at once
+	put(6)
+	require.Equal(t, []int{10}, c.Fill(out))
+	v, _ = c.Evicted()
+	require.Equal(t, 6, v)
+
+	// small slice isn't filled
+	put(11)
+	require.Equal(t, []int{0}, c.Fill([]int{0}))
+}
+
+func TestWindowCache_Expire(t *testing.T) {
+
+	now := seconds(5)
+	c := NewWindowCache[int](3*time.Second, 0, seconds, clockAt(&now))
+	for _, v := range []int{5, 3, 6} {
+		c.Put(v)
+	}
+	require.Equal(t, []int{5, 3, 6}, c.Since(time.Time{}, nil))
+
+	// late value among newer ones is evicted once it is out of window
+	now = seconds(7)
+	require.Equal(t, []int{5, 6}, c.Since(time.Time{}, nil))
+	v, _ := c.Evicted()
+	require.Equal(t, 3, v)
+
+	// values are evicted as clock goes on without new values
+	now = seconds(9)
+	require.Equal(t, []int{6}, c.Range(seconds(0), seconds(10), nil))
+	now = seconds(10)
+	require.Empty(t, c.Since(time.Time{}, nil))
+	v, _ = c.Evicted()
+	require.Equal(t, 6, v)
+}
+
+func TestWindowCache_Limit(t *testing.T) {
+
+	now := seconds(5)
+	c := NewWindowCache[int](time.Minute, 3, seconds, clockAt(&now))
+	for _, v := range []int{1, 2, 3, 4, 5} {
+		c.Put(v)
+	}
+	require.Equal(t, []int{3, 4, 5}, c.Since(time.Time{}, nil))
+	v, _ := c.Evicted()
+	require.Equal(t, 2, v)
+}
+
+func TestWindowCache_Since(t *testing.T) {
+
+	now := seconds(5)
+	c := NewWindowCache[int](time.Minute, 0, seconds, clockAt(&now))
+	for _, v := range []int{1, 3, 2, 4, 5} {
+		c.Put(v)
+	}
+
+	require.Equal(t, []int{2, 4, 5}, c.Since(seconds(3), nil))
+	require.Equal(t, []int{4, 5}, c.Since(seconds(3).Add(time.Millisecond), nil))
+	require.Empty(t, c.Since(seconds(5), nil))
+	require.Equal(t, []int{3, 2, 4}, c.Range(seconds(2), seconds(4), nil))
+}
+
+func TestWindowCache_Subscribe(t *testing.T) {
+
+	now := seconds(5)
+	c := NewWindowCache[int](time.Minute, 0, seconds, clockAt(&now))
+	ch := make(chan struct{}, 1)
+	unsubscribe := c.Subscribe(ch)
+
+	c.Put(1)
+	require.Len(t, ch, 1)
+	<-ch
+
+	unsubscribe()
+	c.Put(2)
+	require.Len(t, ch, 0)
+}
@@ -11,6 +11,7 @@ import (
 	gs "mtsbank/history/internal/client/generator_service"
 	"mtsbank/history/internal/config"
 	"mtsbank/history/internal/repo"
+	"mtsbank/pkg/instrument"
 	"net"
 	"net/http"
 	"os"
@@ -44,7 +45,14 @@ func main() {
 	genClient, err := gs.NewClientWithResponses("http://" + net.JoinHostPort(cfg.Generator.Host, cfg.Generator.Port))
 	checkErr(err)
 
-	service := internal.NewSimpleHistoryService(repoPG, genClient, l)
+	instruments := instrument.NewRegistry(genClient, l)
+	// shutdown gracefully, signals also interrupt waiting for instrument registry
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	checkErr(instruments.Wait(ctx, cfg.Period))
+
+	service := internal.NewSimpleHistoryService(repoPG, genClient, instruments, l)
 
 	// configure router
 	swagger, err := v1.GetSwagger()
@@ -54,24 +62,20 @@ func main() {
 
 	r := chi.NewRouter()
 	r.Use(middleware.OapiRequestValidator(swagger))
-	v1.HandlerFromMux(service, r)
+	v1.HandlerWithOptions(service, v1.ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: []v1.MiddlewareFunc{instruments.Validator()},
+	})
 
 	s := &http.Server{
 		Handler: r,
 		Addr:    net.JoinHostPort(cfg.Host, cfg.Port),
 	}
 
-	// shutdown gracefully
-	ctx, cancel := context.WithCancel(context.Background())
-
 	idleConnsClosed := make(chan struct{})
 
-	exit := make(chan os.Signal, 1)
-
-	signal.Notify(exit, os.Interrupt, syscall.SIGTERM)
 	go func() {
-		<-exit
-		cancel()
+		<-ctx.Done()
 		if err := s.Shutdown(context.Background()); err != nil {
 			log.Printf("HTTP server Shutdown: %v", err)
 		}
@@ -14,6 +14,7 @@ require (
 	github.com/testcontainers/testcontainers-go v0.13.0
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
 	gotest.tools/v3 v3.3.0
+	mtsbank/pkg v0.0.0
 )
 
 require (
@@ -59,3 +60,5 @@ require (
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace mtsbank/pkg => ../pkg
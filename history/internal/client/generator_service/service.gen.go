@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -22,16 +23,437 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AuditEntryAction.
+const (
+	AuditEntryActionAddPair        AuditEntryAction = "add_pair"
+	AuditEntryActionBackfill       AuditEntryAction = "backfill"
+	AuditEntryActionDeletePair     AuditEntryAction = "delete_pair"
+	AuditEntryActionDeleteScenario AuditEntryAction = "delete_scenario"
+	AuditEntryActionFreeze         AuditEntryAction = "freeze"
+	AuditEntryActionHttpFaults     AuditEntryAction = "http_faults"
+	AuditEntryActionPutScenario    AuditEntryAction = "put_scenario"
+	AuditEntryActionResume         AuditEntryAction = "resume"
+	AuditEntryActionShock          AuditEntryAction = "shock"
+)
+
+// Defines values for ScenarioStepKind.
+const (
+	ScenarioStepKindHalt    ScenarioStepKind = "halt"
+	ScenarioStepKindInclude ScenarioStepKind = "include"
+	ScenarioStepKindRegime  ScenarioStepKind = "regime"
+	ScenarioStepKindShock   ScenarioStepKind = "shock"
+	ScenarioStepKindTrend   ScenarioStepKind = "trend"
+	ScenarioStepKindWait    ScenarioStepKind = "wait"
+)
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	Action AuditEntryAction `json:"action"`
+
+	// Name of admin token
+	Actor string `json:"actor"`
+
+	// Rates are synthesised by models of SEED pattern, so the same request returns the same rates. Backfill is off for REPLAY pattern.
+	// Prices are scaled to end at the first live price of the currency pair, backfilled rates are continuous with live rates.
+	// Range of currency pair is limited by RATE_GENERATOR_BACKFILL_MAX_RANGE, 7 days by default.
+	Backfill     *BackfillRequest `json:"backfill,omitempty"`
+	CurrencyPair *string          `json:"currency_pair,omitempty"`
+	HttpFaults   *HTTPFaults      `json:"http_faults,omitempty"`
+
+	// Run of played scenario
+	ScenarioRun *string `json:"scenario_run,omitempty"`
+
+	// Price jump added to all following prices of the currency pair
+	Shock *Shock    `json:"shock,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// AuditEntryAction defines model for AuditEntry.Action.
+type AuditEntryAction string
+
+// BackfillPage defines model for BackfillPage.
+type BackfillPage struct {
+	// Cursor of the next page, missing on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+
+	// Number of rates pushed to history
+	Pushed *int64 `json:"pushed,omitempty"`
+
+	// Rates of all pairs ordered by time, empty when rates are pushed
+	Ticks []BackfillTick `json:"ticks"`
+}
+
+// Rates are synthesised by models of SEED pattern, so the same request returns the same rates. Backfill is off for REPLAY pattern.
+// Prices are scaled to end at the first live price of the currency pair, backfilled rates are continuous with live rates.
+// Range of currency pair is limited by RATE_GENERATOR_BACKFILL_MAX_RANGE, 7 days by default.
+type BackfillRequest struct {
+	// Cursor of the next page from the previous response of the same request.
+	// It keeps time of the last returned rate per currency pair: `EURUSD=<time>,USDJPY=<time>`.
+	Cursor   *string   `json:"cursor,omitempty"`
+	From     time.Time `json:"from"`
+	PageSize *int32    `json:"page_size,omitempty"`
+	Pairs    []string  `json:"pairs"`
+
+	// Push all rates to history ingest URL instead of returning them
+	Push *bool `json:"push,omitempty"`
+
+	// End of range, exclusive. Rates are never backfilled after the first live rate, it is the end by default.
+	To *time.Time `json:"to,omitempty"`
+}
+
+// BackfillTick defines model for BackfillTick.
+type BackfillTick struct {
+	CurrencyPair string `json:"currency_pair"`
+
+	// Prices are decimal strings with exactly `scale` digits after decimal point, scale is set per currency pair.
+	Rate ExchangeRate `json:"rate"`
+}
+
+// Trading calendar: market is open during trading days except holidays, rates aren't generated while it's closed.
+// Trading day starts at `rollover` relative to its midnight UTC, e.g. FX trading day of Monday starts on Sunday at 22:00 UTC.
+type Calendar struct {
+	// Trading days of week, SUN, MON, TUE, WED, THU, FRI or SAT
+	Days []string `json:"days"`
+
+	// Closed trading days
+	Holidays []string `json:"holidays"`
+	Name     string   `json:"name"`
+
+	// Start of trading day relative to its midnight UTC, Go duration
+	Rollover string `json:"rollover"`
+}
+
+// CurrencyPair defines model for CurrencyPair.
+type CurrencyPair struct {
+	CurrencyPair string `json:"currency_pair"`
+
+	// Number of digits after decimal point in prices of the currency pair, must equal precision of the instrument
+	Scale *int32 `json:"scale,omitempty"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    int32  `json:"code"`
 	Message string `json:"message"`
 }
 
-// ExchangeRate defines model for ExchangeRate.
+// Prices are decimal strings with exactly `scale` digits after decimal point, scale is set per currency pair.
 type ExchangeRate struct {
-	Rate int64     `json:"rate"`
+	Ask string `json:"ask"`
+	Bid string `json:"bid"`
+	Mid string `json:"mid"`
+
+	// Mid price, kept for compatibility
+	Rate string    `json:"rate"`
 	Time time.Time `json:"time"`
+
+	// Synthetic trade size of the tick
+	Volume int64 `json:"volume"`
+}
+
+// Faults injected into requests of the route. Latency is added independently of other faults,
+// a request gets at most one of error, truncated body, malformed body and connection reset.
+// Body faults aren't injected into streams.
+type HTTPFaultRule struct {
+	// Status code of error, 503 by default
+	ErrorCode        *int32   `json:"error_code,omitempty"`
+	ErrorProbability *float64 `json:"error_probability,omitempty"`
+
+	// Go duration added to response time
+	Latency *string `json:"latency,omitempty"`
+
+	// Probability of latency, 1 by default
+	LatencyProbability *float64 `json:"latency_probability,omitempty"`
+
+	// Probability of complete response with invalid JSON body
+	MalformedProbability *float64 `json:"malformed_probability,omitempty"`
+
+	// Probability of connection reset without response
+	ResetProbability *float64 `json:"reset_probability,omitempty"`
+
+	// Path pattern of path.Match, `*` doesn't match `/`
+	Route string `json:"route"`
+
+	// Probability of body cut in half, connection is closed before the end of body
+	TruncateProbability *float64 `json:"truncate_probability,omitempty"`
+}
+
+// HTTPFaults defines model for HTTPFaults.
+type HTTPFaults struct {
+	// Rules in order of matching, the first matching rule is applied
+	Rules []HTTPFaultRule `json:"rules"`
+}
+
+// Reference data of the currency pair
+type Instrument struct {
+	// ISO 4217 code of the base currency
+	Base string `json:"base"`
+
+	// Name of the trading calendar
+	Calendar     string `json:"calendar"`
+	CurrencyPair string `json:"currency_pair"`
+
+	// Disabled currency pair can't be generated
+	Enabled bool   `json:"enabled"`
+	PipSize string `json:"pip_size"`
+
+	// Number of digits after decimal point in prices, scale of prices
+	Precision int32 `json:"precision"`
+
+	// ISO 4217 code of the quote currency
+	Quote string `json:"quote"`
+}
+
+// PlayedScenario defines model for PlayedScenario.
+type PlayedScenario struct {
+	// Time of rates, when scenario was loaded
+	Origin time.Time `json:"origin"`
+
+	// Library of composable scenarios and the scenario to play
+	Scenario ScenarioSet `json:"scenario"`
+}
+
+// Rates per currency pair
+type RatesBatch struct {
+	AdditionalProperties map[string][]ExchangeRate `json:"-"`
+}
+
+// Scenario defines model for Scenario.
+type Scenario struct {
+	Name string `json:"name"`
+
+	// Timelines of currency pairs
+	Pairs Scenario_Pairs `json:"pairs"`
+}
+
+// Timelines of currency pairs
+type Scenario_Pairs struct {
+	AdditionalProperties map[string][]ScenarioStep `json:"-"`
+}
+
+// Library of composable scenarios and the scenario to play
+type ScenarioSet struct {
+	// Name of the played scenario
+	Run       string     `json:"run"`
+	Scenarios []Scenario `json:"scenarios"`
+
+	// Go duration between loading of scenario and start of timelines, zero by default
+	Start *string `json:"start,omitempty"`
+}
+
+// Step of currency pair timeline. Steps of the pair follow one another, timelines of pairs run in parallel.
+// * `trend` - price changes by `change` (0.02 is +2%) linearly over `duration`
+// * `shock` - price jumps at once by `pips` and by `change`
+// * `halt` - no rates are generated for `duration`
+// * `regime` - price moves of the model are multiplied by `volatility` for `duration`
+// * `wait` - nothing happens for `duration`
+// * `include` - steps of the pair from scenario `scenario`
+type ScenarioStep struct {
+	Change *float64 `json:"change,omitempty"`
+
+	// Go duration, e.g. 10m
+	Duration   *string          `json:"duration,omitempty"`
+	Kind       ScenarioStepKind `json:"kind"`
+	Pips       *int64           `json:"pips,omitempty"`
+	Scenario   *string          `json:"scenario,omitempty"`
+	Volatility *float64         `json:"volatility,omitempty"`
+}
+
+// ScenarioStepKind defines model for ScenarioStep.Kind.
+type ScenarioStepKind string
+
+// Price jump added to all following prices of the currency pair
+type Shock struct {
+	// Relative jump, 0.01 is +1%
+	Change *float64 `json:"change,omitempty"`
+
+	// Jump in pips of the instrument, negative pips move price down
+	Pips *int64 `json:"pips,omitempty"`
+}
+
+// PutAdminHttpFaultsJSONBody defines parameters for PutAdminHttpFaults.
+type PutAdminHttpFaultsJSONBody = HTTPFaults
+
+// PostAdminPairsCurrencyPairShockJSONBody defines parameters for PostAdminPairsCurrencyPairShock.
+type PostAdminPairsCurrencyPairShockJSONBody = Shock
+
+// PostBackfillJSONBody defines parameters for PostBackfill.
+type PostBackfillJSONBody = BackfillRequest
+
+// PostPairsJSONBody defines parameters for PostPairs.
+type PostPairsJSONBody = CurrencyPair
+
+// GetRatesBatchParams defines parameters for GetRatesBatch.
+type GetRatesBatchParams struct {
+	// Currency pairs or `all`
+	Pairs []string `form:"pairs" json:"pairs"`
+
+	// Returns rates created strictly after this time for currency pairs without cursor
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Time of the last received rate per currency pair
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Maximum number of returned rates per currency pair (the oldest are returned first)
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetRatesStreamParams defines parameters for GetRatesStream.
+type GetRatesStreamParams struct {
+	// Currency pairs
+	Pairs []string `form:"pairs" json:"pairs"`
+
+	// Id of the last received event
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// GetRatesCurrencyPairParams defines parameters for GetRatesCurrencyPair.
+type GetRatesCurrencyPairParams struct {
+	// Returns rates created strictly after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Returns rates created at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// Returns rates created at or before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Maximum number of returned rates (the oldest are returned first)
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetRatesCurrencyPairStreamParams defines parameters for GetRatesCurrencyPairStream.
+type GetRatesCurrencyPairStreamParams struct {
+	// Id of the last received event
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// PutScenarioJSONBody defines parameters for PutScenario.
+type PutScenarioJSONBody = ScenarioSet
+
+// PutAdminHttpFaultsJSONRequestBody defines body for PutAdminHttpFaults for application/json ContentType.
+type PutAdminHttpFaultsJSONRequestBody = PutAdminHttpFaultsJSONBody
+
+// PostAdminPairsCurrencyPairShockJSONRequestBody defines body for PostAdminPairsCurrencyPairShock for application/json ContentType.
+type PostAdminPairsCurrencyPairShockJSONRequestBody = PostAdminPairsCurrencyPairShockJSONBody
+
+// PostBackfillJSONRequestBody defines body for PostBackfill for application/json ContentType.
+type PostBackfillJSONRequestBody = PostBackfillJSONBody
+
+// PostPairsJSONRequestBody defines body for PostPairs for application/json ContentType.
+type PostPairsJSONRequestBody = PostPairsJSONBody
+
+// PutScenarioJSONRequestBody defines body for PutScenario for application/json ContentType.
+type PutScenarioJSONRequestBody = PutScenarioJSONBody
+
+// Getter for additional properties for RatesBatch. Returns the specified
+// element and whether it was found
+func (a RatesBatch) Get(fieldName string) (value []ExchangeRate, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for RatesBatch
+func (a *RatesBatch) Set(fieldName string, value []ExchangeRate) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string][]ExchangeRate)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for RatesBatch to handle AdditionalProperties
+func (a *RatesBatch) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string][]ExchangeRate)
+		for fieldName, fieldBuf := range object {
+			var fieldVal []ExchangeRate
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for RatesBatch to handle AdditionalProperties
+func (a RatesBatch) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for Scenario_Pairs. Returns the specified
+// element and whether it was found
+func (a Scenario_Pairs) Get(fieldName string) (value []ScenarioStep, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Scenario_Pairs
+func (a *Scenario_Pairs) Set(fieldName string, value []ScenarioStep) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string][]ScenarioStep)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Scenario_Pairs to handle AdditionalProperties
+func (a *Scenario_Pairs) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string][]ScenarioStep)
+		for fieldName, fieldBuf := range object {
+			var fieldVal []ScenarioStep
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Scenario_Pairs to handle AdditionalProperties
+func (a Scenario_Pairs) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
 }
 
 // RequestEditorFn  is the function signature for the RequestEditor callback function
@@ -107,12 +529,115 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetAdminAudit request
+	GetAdminAudit(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminHttpFaults request
+	GetAdminHttpFaults(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAdminHttpFaults request with any body
+	PutAdminHttpFaultsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAdminHttpFaults(ctx context.Context, body PutAdminHttpFaultsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminPairsCurrencyPairFreeze request
+	PostAdminPairsCurrencyPairFreeze(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminPairsCurrencyPairResume request
+	PostAdminPairsCurrencyPairResume(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminPairsCurrencyPairShock request with any body
+	PostAdminPairsCurrencyPairShockWithBody(ctx context.Context, currencyPair string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminPairsCurrencyPairShock(ctx context.Context, currencyPair string, body PostAdminPairsCurrencyPairShockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostBackfill request with any body
+	PostBackfillWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostBackfill(ctx context.Context, body PostBackfillJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCalendars request
+	GetCalendars(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInstruments request
+	GetInstruments(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInstrumentsCurrencyPair request
+	GetInstrumentsCurrencyPair(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPairs request
+	GetPairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPairs request with any body
+	PostPairsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPairs(ctx context.Context, body PostPairsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeletePairsCurrencyPair request
+	DeletePairsCurrencyPair(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRatesBatch request
+	GetRatesBatch(ctx context.Context, params *GetRatesBatchParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRatesStream request
+	GetRatesStream(ctx context.Context, params *GetRatesStreamParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRatesCurrencyPair request
-	GetRatesCurrencyPair(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetRatesCurrencyPair(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRatesCurrencyPairStream request
+	GetRatesCurrencyPairStream(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairStreamParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteScenario request
+	DeleteScenario(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScenario request
+	GetScenario(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutScenario request with any body
+	PutScenarioWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutScenario(ctx context.Context, body PutScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAdminAudit(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminAuditRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAdminHttpFaults(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminHttpFaultsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminHttpFaultsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminHttpFaultsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *Client) GetRatesCurrencyPair(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetRatesCurrencyPairRequest(c.Server, currencyPair)
+func (c *Client) PutAdminHttpFaults(ctx context.Context, body PutAdminHttpFaultsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminHttpFaultsRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -123,170 +648,3258 @@ func (c *Client) GetRatesCurrencyPair(ctx context.Context, currencyPair string,
 	return c.Client.Do(req)
 }
 
-// NewGetRatesCurrencyPairRequest generates requests for GetRatesCurrencyPair
-func NewGetRatesCurrencyPairRequest(server string, currencyPair string) (*http.Request, error) {
-	var err error
+func (c *Client) PostAdminPairsCurrencyPairFreeze(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminPairsCurrencyPairFreezeRequest(c.Server, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminPairsCurrencyPairResume(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminPairsCurrencyPairResumeRequest(c.Server, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminPairsCurrencyPairShockWithBody(ctx context.Context, currencyPair string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminPairsCurrencyPairShockRequestWithBody(c.Server, currencyPair, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAdminPairsCurrencyPairShock(ctx context.Context, currencyPair string, body PostAdminPairsCurrencyPairShockJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminPairsCurrencyPairShockRequest(c.Server, currencyPair, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostBackfillWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBackfillRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostBackfill(ctx context.Context, body PostBackfillJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBackfillRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCalendars(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCalendarsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInstruments(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInstrumentsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInstrumentsCurrencyPair(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInstrumentsCurrencyPairRequest(c.Server, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPairsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPairsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPairsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPairs(ctx context.Context, body PostPairsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPairsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeletePairsCurrencyPair(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeletePairsCurrencyPairRequest(c.Server, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRatesBatch(ctx context.Context, params *GetRatesBatchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRatesBatchRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRatesStream(ctx context.Context, params *GetRatesStreamParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRatesStreamRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRatesCurrencyPair(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRatesCurrencyPairRequest(c.Server, currencyPair, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRatesCurrencyPairStream(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairStreamParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRatesCurrencyPairStreamRequest(c.Server, currencyPair, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteScenario(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteScenarioRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScenario(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScenarioRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutScenarioWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutScenarioRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutScenario(ctx context.Context, body PutScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutScenarioRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAdminAuditRequest generates requests for GetAdminAudit
+func NewGetAdminAuditRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/audit")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminHttpFaultsRequest generates requests for GetAdminHttpFaults
+func NewGetAdminHttpFaultsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/http-faults")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutAdminHttpFaultsRequest calls the generic PutAdminHttpFaults builder with application/json body
+func NewPutAdminHttpFaultsRequest(server string, body PutAdminHttpFaultsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAdminHttpFaultsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutAdminHttpFaultsRequestWithBody generates requests for PutAdminHttpFaults with any type of body
+func NewPutAdminHttpFaultsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/http-faults")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAdminPairsCurrencyPairFreezeRequest generates requests for PostAdminPairsCurrencyPairFreeze
+func NewPostAdminPairsCurrencyPairFreezeRequest(server string, currencyPair string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/pairs/%s/freeze", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminPairsCurrencyPairResumeRequest generates requests for PostAdminPairsCurrencyPairResume
+func NewPostAdminPairsCurrencyPairResumeRequest(server string, currencyPair string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/pairs/%s/resume", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminPairsCurrencyPairShockRequest calls the generic PostAdminPairsCurrencyPairShock builder with application/json body
+func NewPostAdminPairsCurrencyPairShockRequest(server string, currencyPair string, body PostAdminPairsCurrencyPairShockJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminPairsCurrencyPairShockRequestWithBody(server, currencyPair, "application/json", bodyReader)
+}
+
+// NewPostAdminPairsCurrencyPairShockRequestWithBody generates requests for PostAdminPairsCurrencyPairShock with any type of body
+func NewPostAdminPairsCurrencyPairShockRequestWithBody(server string, currencyPair string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/pairs/%s/shock", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostBackfillRequest calls the generic PostBackfill builder with application/json body
+func NewPostBackfillRequest(server string, body PostBackfillJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostBackfillRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostBackfillRequestWithBody generates requests for PostBackfill with any type of body
+func NewPostBackfillRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/backfill")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetCalendarsRequest generates requests for GetCalendars
+func NewGetCalendarsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/calendars")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInstrumentsRequest generates requests for GetInstruments
+func NewGetInstrumentsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/instruments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInstrumentsCurrencyPairRequest generates requests for GetInstrumentsCurrencyPair
+func NewGetInstrumentsCurrencyPairRequest(server string, currencyPair string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/instruments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPairsRequest generates requests for GetPairs
+func NewGetPairsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pairs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostPairsRequest calls the generic PostPairs builder with application/json body
+func NewPostPairsRequest(server string, body PostPairsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPairsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostPairsRequestWithBody generates requests for PostPairs with any type of body
+func NewPostPairsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pairs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeletePairsCurrencyPairRequest generates requests for DeletePairsCurrencyPair
+func NewDeletePairsCurrencyPairRequest(server string, currencyPair string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pairs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRatesBatchRequest generates requests for GetRatesBatch
+func NewGetRatesBatchRequest(server string, params *GetRatesBatchParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rates")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", false, "pairs", runtime.ParamLocationQuery, params.Pairs); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if params.Since != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Cursor != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRatesStreamRequest generates requests for GetRatesStream
+func NewGetRatesStreamRequest(server string, params *GetRatesStreamParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rates/stream")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", false, "pairs", runtime.ParamLocationQuery, params.Pairs); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.LastEventID != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Last-Event-ID", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewGetRatesCurrencyPairRequest generates requests for GetRatesCurrencyPair
+func NewGetRatesCurrencyPairRequest(server string, currencyPair string, params *GetRatesCurrencyPairParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rates/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Since != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.From != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.To != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRatesCurrencyPairStreamRequest generates requests for GetRatesCurrencyPairStream
+func NewGetRatesCurrencyPairStreamRequest(server string, currencyPair string, params *GetRatesCurrencyPairStreamParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rates/%s/stream", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.LastEventID != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Last-Event-ID", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewDeleteScenarioRequest generates requests for DeleteScenario
+func NewDeleteScenarioRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/scenario")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetScenarioRequest generates requests for GetScenario
+func NewGetScenarioRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/scenario")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutScenarioRequest calls the generic PutScenario builder with application/json body
+func NewPutScenarioRequest(server string, body PutScenarioJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutScenarioRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutScenarioRequestWithBody generates requests for PutScenario with any type of body
+func NewPutScenarioRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/scenario")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAdminAudit request
+	GetAdminAuditWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminAuditResponse, error)
+
+	// GetAdminHttpFaults request
+	GetAdminHttpFaultsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminHttpFaultsResponse, error)
+
+	// PutAdminHttpFaults request with any body
+	PutAdminHttpFaultsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminHttpFaultsResponse, error)
+
+	PutAdminHttpFaultsWithResponse(ctx context.Context, body PutAdminHttpFaultsJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminHttpFaultsResponse, error)
+
+	// PostAdminPairsCurrencyPairFreeze request
+	PostAdminPairsCurrencyPairFreezeWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*PostAdminPairsCurrencyPairFreezeResponse, error)
+
+	// PostAdminPairsCurrencyPairResume request
+	PostAdminPairsCurrencyPairResumeWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*PostAdminPairsCurrencyPairResumeResponse, error)
+
+	// PostAdminPairsCurrencyPairShock request with any body
+	PostAdminPairsCurrencyPairShockWithBodyWithResponse(ctx context.Context, currencyPair string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminPairsCurrencyPairShockResponse, error)
+
+	PostAdminPairsCurrencyPairShockWithResponse(ctx context.Context, currencyPair string, body PostAdminPairsCurrencyPairShockJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminPairsCurrencyPairShockResponse, error)
+
+	// PostBackfill request with any body
+	PostBackfillWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBackfillResponse, error)
+
+	PostBackfillWithResponse(ctx context.Context, body PostBackfillJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBackfillResponse, error)
+
+	// GetCalendars request
+	GetCalendarsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCalendarsResponse, error)
+
+	// GetInstruments request
+	GetInstrumentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInstrumentsResponse, error)
+
+	// GetInstrumentsCurrencyPair request
+	GetInstrumentsCurrencyPairWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*GetInstrumentsCurrencyPairResponse, error)
+
+	// GetPairs request
+	GetPairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPairsResponse, error)
+
+	// PostPairs request with any body
+	PostPairsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPairsResponse, error)
+
+	PostPairsWithResponse(ctx context.Context, body PostPairsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPairsResponse, error)
+
+	// DeletePairsCurrencyPair request
+	DeletePairsCurrencyPairWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*DeletePairsCurrencyPairResponse, error)
+
+	// GetRatesBatch request
+	GetRatesBatchWithResponse(ctx context.Context, params *GetRatesBatchParams, reqEditors ...RequestEditorFn) (*GetRatesBatchResponse, error)
+
+	// GetRatesStream request
+	GetRatesStreamWithResponse(ctx context.Context, params *GetRatesStreamParams, reqEditors ...RequestEditorFn) (*GetRatesStreamResponse, error)
+
+	// GetRatesCurrencyPair request
+	GetRatesCurrencyPairWithResponse(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, reqEditors ...RequestEditorFn) (*GetRatesCurrencyPairResponse, error)
+
+	// GetRatesCurrencyPairStream request
+	GetRatesCurrencyPairStreamWithResponse(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairStreamParams, reqEditors ...RequestEditorFn) (*GetRatesCurrencyPairStreamResponse, error)
+
+	// DeleteScenario request
+	DeleteScenarioWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteScenarioResponse, error)
+
+	// GetScenario request
+	GetScenarioWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetScenarioResponse, error)
+
+	// PutScenario request with any body
+	PutScenarioWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutScenarioResponse, error)
+
+	PutScenarioWithResponse(ctx context.Context, body PutScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*PutScenarioResponse, error)
+}
+
+type GetAdminAuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AuditEntry
+	JSON401      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminAuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminAuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminHttpFaultsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HTTPFaults
+	JSON401      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminHttpFaultsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminHttpFaultsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAdminHttpFaultsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuditEntry
+	JSON400      *Error
+	JSON401      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAdminHttpFaultsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAdminHttpFaultsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminPairsCurrencyPairFreezeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuditEntry
+	JSON401      *Error
+	JSON404      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminPairsCurrencyPairFreezeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminPairsCurrencyPairFreezeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminPairsCurrencyPairResumeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuditEntry
+	JSON401      *Error
+	JSON404      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminPairsCurrencyPairResumeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminPairsCurrencyPairResumeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminPairsCurrencyPairShockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuditEntry
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminPairsCurrencyPairShockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminPairsCurrencyPairShockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBackfillResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BackfillPage
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON502      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBackfillResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBackfillResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCalendarsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Calendar
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCalendarsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCalendarsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInstrumentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Instrument
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInstrumentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInstrumentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInstrumentsCurrencyPairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Instrument
+	JSON404      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInstrumentsCurrencyPairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInstrumentsCurrencyPairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPairsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]string
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPairsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPairsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPairsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CurrencyPair
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPairsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPairsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeletePairsCurrencyPairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeletePairsCurrencyPairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeletePairsCurrencyPairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRatesBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RatesBatch
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRatesBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRatesBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRatesStreamResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRatesStreamResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRatesStreamResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRatesCurrencyPairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ExchangeRate
+	JSON400      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRatesCurrencyPairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRatesCurrencyPairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRatesCurrencyPairStreamResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRatesCurrencyPairStreamResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRatesCurrencyPairStreamResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteScenarioResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteScenarioResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteScenarioResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScenarioResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlayedScenario
+	JSON404      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScenarioResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScenarioResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutScenarioResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlayedScenario
+	JSON400      *Error
+	JSON401      *Error
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutScenarioResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutScenarioResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAdminAuditWithResponse request returning *GetAdminAuditResponse
+func (c *ClientWithResponses) GetAdminAuditWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminAuditResponse, error) {
+	rsp, err := c.GetAdminAudit(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminAuditResponse(rsp)
+}
+
+// GetAdminHttpFaultsWithResponse request returning *GetAdminHttpFaultsResponse
+func (c *ClientWithResponses) GetAdminHttpFaultsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminHttpFaultsResponse, error) {
+	rsp, err := c.GetAdminHttpFaults(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminHttpFaultsResponse(rsp)
+}
+
+// PutAdminHttpFaultsWithBodyWithResponse request with arbitrary body returning *PutAdminHttpFaultsResponse
+func (c *ClientWithResponses) PutAdminHttpFaultsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminHttpFaultsResponse, error) {
+	rsp, err := c.PutAdminHttpFaultsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminHttpFaultsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAdminHttpFaultsWithResponse(ctx context.Context, body PutAdminHttpFaultsJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminHttpFaultsResponse, error) {
+	rsp, err := c.PutAdminHttpFaults(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminHttpFaultsResponse(rsp)
+}
+
+// PostAdminPairsCurrencyPairFreezeWithResponse request returning *PostAdminPairsCurrencyPairFreezeResponse
+func (c *ClientWithResponses) PostAdminPairsCurrencyPairFreezeWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*PostAdminPairsCurrencyPairFreezeResponse, error) {
+	rsp, err := c.PostAdminPairsCurrencyPairFreeze(ctx, currencyPair, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminPairsCurrencyPairFreezeResponse(rsp)
+}
+
+// PostAdminPairsCurrencyPairResumeWithResponse request returning *PostAdminPairsCurrencyPairResumeResponse
+func (c *ClientWithResponses) PostAdminPairsCurrencyPairResumeWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*PostAdminPairsCurrencyPairResumeResponse, error) {
+	rsp, err := c.PostAdminPairsCurrencyPairResume(ctx, currencyPair, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminPairsCurrencyPairResumeResponse(rsp)
+}
+
+// PostAdminPairsCurrencyPairShockWithBodyWithResponse request with arbitrary body returning *PostAdminPairsCurrencyPairShockResponse
+func (c *ClientWithResponses) PostAdminPairsCurrencyPairShockWithBodyWithResponse(ctx context.Context, currencyPair string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminPairsCurrencyPairShockResponse, error) {
+	rsp, err := c.PostAdminPairsCurrencyPairShockWithBody(ctx, currencyPair, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminPairsCurrencyPairShockResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminPairsCurrencyPairShockWithResponse(ctx context.Context, currencyPair string, body PostAdminPairsCurrencyPairShockJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminPairsCurrencyPairShockResponse, error) {
+	rsp, err := c.PostAdminPairsCurrencyPairShock(ctx, currencyPair, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminPairsCurrencyPairShockResponse(rsp)
+}
+
+// PostBackfillWithBodyWithResponse request with arbitrary body returning *PostBackfillResponse
+func (c *ClientWithResponses) PostBackfillWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBackfillResponse, error) {
+	rsp, err := c.PostBackfillWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBackfillResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostBackfillWithResponse(ctx context.Context, body PostBackfillJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBackfillResponse, error) {
+	rsp, err := c.PostBackfill(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBackfillResponse(rsp)
+}
+
+// GetCalendarsWithResponse request returning *GetCalendarsResponse
+func (c *ClientWithResponses) GetCalendarsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCalendarsResponse, error) {
+	rsp, err := c.GetCalendars(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCalendarsResponse(rsp)
+}
+
+// GetInstrumentsWithResponse request returning *GetInstrumentsResponse
+func (c *ClientWithResponses) GetInstrumentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInstrumentsResponse, error) {
+	rsp, err := c.GetInstruments(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInstrumentsResponse(rsp)
+}
+
+// GetInstrumentsCurrencyPairWithResponse request returning *GetInstrumentsCurrencyPairResponse
+func (c *ClientWithResponses) GetInstrumentsCurrencyPairWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*GetInstrumentsCurrencyPairResponse, error) {
+	rsp, err := c.GetInstrumentsCurrencyPair(ctx, currencyPair, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInstrumentsCurrencyPairResponse(rsp)
+}
+
+// GetPairsWithResponse request returning *GetPairsResponse
+func (c *ClientWithResponses) GetPairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPairsResponse, error) {
+	rsp, err := c.GetPairs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPairsResponse(rsp)
+}
+
+// PostPairsWithBodyWithResponse request with arbitrary body returning *PostPairsResponse
+func (c *ClientWithResponses) PostPairsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPairsResponse, error) {
+	rsp, err := c.PostPairsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPairsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPairsWithResponse(ctx context.Context, body PostPairsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPairsResponse, error) {
+	rsp, err := c.PostPairs(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPairsResponse(rsp)
+}
+
+// DeletePairsCurrencyPairWithResponse request returning *DeletePairsCurrencyPairResponse
+func (c *ClientWithResponses) DeletePairsCurrencyPairWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*DeletePairsCurrencyPairResponse, error) {
+	rsp, err := c.DeletePairsCurrencyPair(ctx, currencyPair, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeletePairsCurrencyPairResponse(rsp)
+}
+
+// GetRatesBatchWithResponse request returning *GetRatesBatchResponse
+func (c *ClientWithResponses) GetRatesBatchWithResponse(ctx context.Context, params *GetRatesBatchParams, reqEditors ...RequestEditorFn) (*GetRatesBatchResponse, error) {
+	rsp, err := c.GetRatesBatch(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRatesBatchResponse(rsp)
+}
+
+// GetRatesStreamWithResponse request returning *GetRatesStreamResponse
+func (c *ClientWithResponses) GetRatesStreamWithResponse(ctx context.Context, params *GetRatesStreamParams, reqEditors ...RequestEditorFn) (*GetRatesStreamResponse, error) {
+	rsp, err := c.GetRatesStream(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRatesStreamResponse(rsp)
+}
+
+// GetRatesCurrencyPairWithResponse request returning *GetRatesCurrencyPairResponse
+func (c *ClientWithResponses) GetRatesCurrencyPairWithResponse(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, reqEditors ...RequestEditorFn) (*GetRatesCurrencyPairResponse, error) {
+	rsp, err := c.GetRatesCurrencyPair(ctx, currencyPair, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRatesCurrencyPairResponse(rsp)
+}
+
+// GetRatesCurrencyPairStreamWithResponse request returning *GetRatesCurrencyPairStreamResponse
+func (c *ClientWithResponses) GetRatesCurrencyPairStreamWithResponse(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairStreamParams, reqEditors ...RequestEditorFn) (*GetRatesCurrencyPairStreamResponse, error) {
+	rsp, err := c.GetRatesCurrencyPairStream(ctx, currencyPair, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRatesCurrencyPairStreamResponse(rsp)
+}
+
+// DeleteScenarioWithResponse request returning *DeleteScenarioResponse
+func (c *ClientWithResponses) DeleteScenarioWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteScenarioResponse, error) {
+	rsp, err := c.DeleteScenario(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteScenarioResponse(rsp)
+}
+
+// GetScenarioWithResponse request returning *GetScenarioResponse
+func (c *ClientWithResponses) GetScenarioWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetScenarioResponse, error) {
+	rsp, err := c.GetScenario(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScenarioResponse(rsp)
+}
+
+// PutScenarioWithBodyWithResponse request with arbitrary body returning *PutScenarioResponse
+func (c *ClientWithResponses) PutScenarioWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutScenarioResponse, error) {
+	rsp, err := c.PutScenarioWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutScenarioResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutScenarioWithResponse(ctx context.Context, body PutScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*PutScenarioResponse, error) {
+	rsp, err := c.PutScenario(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutScenarioResponse(rsp)
+}
+
+// ParseGetAdminAuditResponse parses an HTTP response from a GetAdminAuditWithResponse call
+func ParseGetAdminAuditResponse(rsp *http.Response) (*GetAdminAuditResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AuditEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminHttpFaultsResponse parses an HTTP response from a GetAdminHttpFaultsWithResponse call
+func ParseGetAdminHttpFaultsResponse(rsp *http.Response) (*GetAdminHttpFaultsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminHttpFaultsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HTTPFaults
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAdminHttpFaultsResponse parses an HTTP response from a PutAdminHttpFaultsWithResponse call
+func ParsePutAdminHttpFaultsResponse(rsp *http.Response) (*PutAdminHttpFaultsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAdminHttpFaultsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuditEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminPairsCurrencyPairFreezeResponse parses an HTTP response from a PostAdminPairsCurrencyPairFreezeWithResponse call
+func ParsePostAdminPairsCurrencyPairFreezeResponse(rsp *http.Response) (*PostAdminPairsCurrencyPairFreezeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminPairsCurrencyPairFreezeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuditEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminPairsCurrencyPairResumeResponse parses an HTTP response from a PostAdminPairsCurrencyPairResumeWithResponse call
+func ParsePostAdminPairsCurrencyPairResumeResponse(rsp *http.Response) (*PostAdminPairsCurrencyPairResumeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminPairsCurrencyPairResumeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuditEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminPairsCurrencyPairShockResponse parses an HTTP response from a PostAdminPairsCurrencyPairShockWithResponse call
+func ParsePostAdminPairsCurrencyPairShockResponse(rsp *http.Response) (*PostAdminPairsCurrencyPairShockResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminPairsCurrencyPairShockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuditEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBackfillResponse parses an HTTP response from a PostBackfillWithResponse call
+func ParsePostBackfillResponse(rsp *http.Response) (*PostBackfillResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBackfillResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BackfillPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCalendarsResponse parses an HTTP response from a GetCalendarsWithResponse call
+func ParseGetCalendarsResponse(rsp *http.Response) (*GetCalendarsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCalendarsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Calendar
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInstrumentsResponse parses an HTTP response from a GetInstrumentsWithResponse call
+func ParseGetInstrumentsResponse(rsp *http.Response) (*GetInstrumentsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInstrumentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Instrument
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInstrumentsCurrencyPairResponse parses an HTTP response from a GetInstrumentsCurrencyPairWithResponse call
+func ParseGetInstrumentsCurrencyPairResponse(rsp *http.Response) (*GetInstrumentsCurrencyPairResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInstrumentsCurrencyPairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Instrument
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPairsResponse parses an HTTP response from a GetPairsWithResponse call
+func ParseGetPairsResponse(rsp *http.Response) (*GetPairsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPairsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostPairsResponse parses an HTTP response from a PostPairsWithResponse call
+func ParsePostPairsResponse(rsp *http.Response) (*PostPairsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPairsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CurrencyPair
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeletePairsCurrencyPairResponse parses an HTTP response from a DeletePairsCurrencyPairWithResponse call
+func ParseDeletePairsCurrencyPairResponse(rsp *http.Response) (*DeletePairsCurrencyPairResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeletePairsCurrencyPairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRatesBatchResponse parses an HTTP response from a GetRatesBatchWithResponse call
+func ParseGetRatesBatchResponse(rsp *http.Response) (*GetRatesBatchResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRatesBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RatesBatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRatesStreamResponse parses an HTTP response from a GetRatesStreamWithResponse call
+func ParseGetRatesStreamResponse(rsp *http.Response) (*GetRatesStreamResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRatesStreamResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRatesCurrencyPairResponse parses an HTTP response from a GetRatesCurrencyPairWithResponse call
+func ParseGetRatesCurrencyPairResponse(rsp *http.Response) (*GetRatesCurrencyPairResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRatesCurrencyPairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ExchangeRate
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRatesCurrencyPairStreamResponse parses an HTTP response from a GetRatesCurrencyPairStreamWithResponse call
+func ParseGetRatesCurrencyPairStreamResponse(rsp *http.Response) (*GetRatesCurrencyPairStreamResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRatesCurrencyPairStreamResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteScenarioResponse parses an HTTP response from a DeleteScenarioWithResponse call
+func ParseDeleteScenarioResponse(rsp *http.Response) (*DeleteScenarioResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteScenarioResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScenarioResponse parses an HTTP response from a GetScenarioWithResponse call
+func ParseGetScenarioResponse(rsp *http.Response) (*GetScenarioResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScenarioResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlayedScenario
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutScenarioResponse parses an HTTP response from a PutScenarioWithResponse call
+func ParsePutScenarioResponse(rsp *http.Response) (*PutScenarioResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutScenarioResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlayedScenario
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Returns audit log of admin actions
+	// (GET /admin/audit)
+	GetAdminAudit(w http.ResponseWriter, r *http.Request)
+	// Returns rules of HTTP fault injection
+	// (GET /admin/http-faults)
+	GetAdminHttpFaults(w http.ResponseWriter, r *http.Request)
+	// Replaces rules of HTTP fault injection
+	// (PUT /admin/http-faults)
+	PutAdminHttpFaults(w http.ResponseWriter, r *http.Request)
+	// Freezes the currency pair
+	// (POST /admin/pairs/{currency_pair}/freeze)
+	PostAdminPairsCurrencyPairFreeze(w http.ResponseWriter, r *http.Request, currencyPair string)
+	// Resumes the frozen currency pair
+	// (POST /admin/pairs/{currency_pair}/resume)
+	PostAdminPairsCurrencyPairResume(w http.ResponseWriter, r *http.Request, currencyPair string)
+	// Injects a price jump into the currency pair
+	// (POST /admin/pairs/{currency_pair}/shock)
+	PostAdminPairsCurrencyPairShock(w http.ResponseWriter, r *http.Request, currencyPair string)
+	// Synthesises rates of currency pairs before live rates
+	// (POST /backfill)
+	PostBackfill(w http.ResponseWriter, r *http.Request)
+	// Returns trading calendars of instruments
+	// (GET /calendars)
+	GetCalendars(w http.ResponseWriter, r *http.Request)
+	// Returns instrument registry
+	// (GET /instruments)
+	GetInstruments(w http.ResponseWriter, r *http.Request)
+	// Returns reference data of the currency pair
+	// (GET /instruments/{currency_pair})
+	GetInstrumentsCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string)
+	// Returns generated currency pairs
+	// (GET /pairs)
+	GetPairs(w http.ResponseWriter, r *http.Request)
+	// Starts generating rates for the currency pair
+	// (POST /pairs)
+	PostPairs(w http.ResponseWriter, r *http.Request)
+	// Stops generating rates for the currency pair
+	// (DELETE /pairs/{currency_pair})
+	DeletePairsCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string)
+	// Returns rates for several currency pairs
+	// (GET /rates)
+	GetRatesBatch(w http.ResponseWriter, r *http.Request, params GetRatesBatchParams)
+	// Streams rates for several currency pairs
+	// (GET /rates/stream)
+	GetRatesStream(w http.ResponseWriter, r *http.Request, params GetRatesStreamParams)
+	// Returns rates for the currency pair
+	// (GET /rates/{currency_pair})
+	GetRatesCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string, params GetRatesCurrencyPairParams)
+	// Streams rates for the currency pair
+	// (GET /rates/{currency_pair}/stream)
+	GetRatesCurrencyPairStream(w http.ResponseWriter, r *http.Request, currencyPair string, params GetRatesCurrencyPairStreamParams)
+	// Stops played scenario
+	// (DELETE /scenario)
+	DeleteScenario(w http.ResponseWriter, r *http.Request)
+	// Returns played scenario
+	// (GET /scenario)
+	GetScenario(w http.ResponseWriter, r *http.Request)
+	// Plays scenario
+	// (PUT /scenario)
+	PutScenario(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+
+// GetAdminAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminAudit(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetAdminHttpFaults operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminHttpFaults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminHttpFaults(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutAdminHttpFaults operation middleware
+func (siw *ServerInterfaceWrapper) PutAdminHttpFaults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAdminHttpFaults(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAdminPairsCurrencyPairFreeze operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminPairsCurrencyPairFreeze(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "currency_pair" -------------
+	var currencyPair string
+
+	err = runtime.BindStyledParameter("simple", false, "currency_pair", chi.URLParam(r, "currency_pair"), &currencyPair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency_pair", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminPairsCurrencyPairFreeze(w, r, currencyPair)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAdminPairsCurrencyPairResume operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminPairsCurrencyPairResume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "currency_pair" -------------
+	var currencyPair string
+
+	err = runtime.BindStyledParameter("simple", false, "currency_pair", chi.URLParam(r, "currency_pair"), &currencyPair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency_pair", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminPairsCurrencyPairResume(w, r, currencyPair)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAdminPairsCurrencyPairShock operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminPairsCurrencyPairShock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "currency_pair" -------------
+	var currencyPair string
+
+	err = runtime.BindStyledParameter("simple", false, "currency_pair", chi.URLParam(r, "currency_pair"), &currencyPair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency_pair", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminPairsCurrencyPairShock(w, r, currencyPair)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostBackfill operation middleware
+func (siw *ServerInterfaceWrapper) PostBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostBackfill(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetCalendars operation middleware
+func (siw *ServerInterfaceWrapper) GetCalendars(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCalendars(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetInstruments operation middleware
+func (siw *ServerInterfaceWrapper) GetInstruments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInstruments(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetInstrumentsCurrencyPair operation middleware
+func (siw *ServerInterfaceWrapper) GetInstrumentsCurrencyPair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "currency_pair" -------------
+	var currencyPair string
+
+	err = runtime.BindStyledParameter("simple", false, "currency_pair", chi.URLParam(r, "currency_pair"), &currencyPair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency_pair", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInstrumentsCurrencyPair(w, r, currencyPair)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPairs operation middleware
+func (siw *ServerInterfaceWrapper) GetPairs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPairs(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostPairs operation middleware
+func (siw *ServerInterfaceWrapper) PostPairs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPairs(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeletePairsCurrencyPair operation middleware
+func (siw *ServerInterfaceWrapper) DeletePairsCurrencyPair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "currency_pair" -------------
+	var currencyPair string
+
+	err = runtime.BindStyledParameter("simple", false, "currency_pair", chi.URLParam(r, "currency_pair"), &currencyPair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency_pair", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePairsCurrencyPair(w, r, currencyPair)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRatesBatch operation middleware
+func (siw *ServerInterfaceWrapper) GetRatesBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRatesBatchParams
+
+	// ------------- Required query parameter "pairs" -------------
+	if paramValue := r.URL.Query().Get("pairs"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pairs"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "pairs", r.URL.Query(), &params.Pairs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pairs", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+	if paramValue := r.URL.Query().Get("since"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := r.URL.Query().Get("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
 
-	var pathParam0 string
+	}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "currency_pair", runtime.ParamLocationPath, currencyPair)
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRatesBatch(w, r, params)
 	}
 
-	operationPath := fmt.Sprintf("/rates/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRatesStream operation middleware
+func (siw *ServerInterfaceWrapper) GetRatesStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRatesStreamParams
+
+	// ------------- Required query parameter "pairs" -------------
+	if paramValue := r.URL.Query().Get("pairs"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pairs"})
+		return
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	err = runtime.BindQueryParameter("form", false, true, "pairs", r.URL.Query(), &params.Pairs)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pairs", Err: err})
+		return
 	}
 
-	return req, nil
-}
+	headers := r.Header
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
 		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, valueList[0], &LastEventID)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
 		}
-	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+		params.LastEventID = &LastEventID
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
-	if err != nil {
-		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRatesStream(w, r, params)
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetRatesCurrencyPair request
-	GetRatesCurrencyPairWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*GetRatesCurrencyPairResponse, error)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetRatesCurrencyPairResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]ExchangeRate
-	JSONDefault  *Error
+	handler(w, r.WithContext(ctx))
 }
 
-// Status returns HTTPResponse.Status
-func (r GetRatesCurrencyPairResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetRatesCurrencyPair operation middleware
+func (siw *ServerInterfaceWrapper) GetRatesCurrencyPair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "currency_pair" -------------
+	var currencyPair string
+
+	err = runtime.BindStyledParameter("simple", false, "currency_pair", chi.URLParam(r, "currency_pair"), &currencyPair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency_pair", Err: err})
+		return
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetRatesCurrencyPairResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRatesCurrencyPairParams
+
+	// ------------- Optional query parameter "since" -------------
+	if paramValue := r.URL.Query().Get("since"); paramValue != "" {
+
 	}
-	return 0
-}
 
-// GetRatesCurrencyPairWithResponse request returning *GetRatesCurrencyPairResponse
-func (c *ClientWithResponses) GetRatesCurrencyPairWithResponse(ctx context.Context, currencyPair string, reqEditors ...RequestEditorFn) (*GetRatesCurrencyPairResponse, error) {
-	rsp, err := c.GetRatesCurrencyPair(ctx, currencyPair, reqEditors...)
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
 	}
-	return ParseGetRatesCurrencyPairResponse(rsp)
-}
 
-// ParseGetRatesCurrencyPairResponse parses an HTTP response from a GetRatesCurrencyPairWithResponse call
-func ParseGetRatesCurrencyPairResponse(rsp *http.Response) (*GetRatesCurrencyPairResponse, error) {
-	bodyBytes, err := ioutil.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+	// ------------- Optional query parameter "from" -------------
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
 	if err != nil {
-		return nil, err
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
 	}
 
-	response := &GetRatesCurrencyPairResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	// ------------- Optional query parameter "to" -------------
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []ExchangeRate
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSONDefault = &dest
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
 
 	}
 
-	return response, nil
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Returns rates for the currency pair
-	// (GET /rates/{currency_pair})
-	GetRatesCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string)
-}
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRatesCurrencyPair(w, r, currencyPair, params)
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+	handler(w, r.WithContext(ctx))
+}
 
-// GetRatesCurrencyPair operation middleware
-func (siw *ServerInterfaceWrapper) GetRatesCurrencyPair(w http.ResponseWriter, r *http.Request) {
+// GetRatesCurrencyPairStream operation middleware
+func (siw *ServerInterfaceWrapper) GetRatesCurrencyPairStream(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -300,8 +3913,81 @@ func (siw *ServerInterfaceWrapper) GetRatesCurrencyPair(w http.ResponseWriter, r
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRatesCurrencyPairStreamParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, valueList[0], &LastEventID)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRatesCurrencyPairStream(w, r, currencyPair, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteScenario operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScenario(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteScenario(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetScenario operation middleware
+func (siw *ServerInterfaceWrapper) GetScenario(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScenario(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutScenario operation middleware
+func (siw *ServerInterfaceWrapper) PutScenario(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRatesCurrencyPair(w, r, currencyPair)
+		siw.Handler.PutScenario(w, r)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -424,9 +4110,66 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/audit", wrapper.GetAdminAudit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/http-faults", wrapper.GetAdminHttpFaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/http-faults", wrapper.PutAdminHttpFaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/pairs/{currency_pair}/freeze", wrapper.PostAdminPairsCurrencyPairFreeze)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/pairs/{currency_pair}/resume", wrapper.PostAdminPairsCurrencyPairResume)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/pairs/{currency_pair}/shock", wrapper.PostAdminPairsCurrencyPairShock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/backfill", wrapper.PostBackfill)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/calendars", wrapper.GetCalendars)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/instruments", wrapper.GetInstruments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/instruments/{currency_pair}", wrapper.GetInstrumentsCurrencyPair)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pairs", wrapper.GetPairs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pairs", wrapper.PostPairs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/pairs/{currency_pair}", wrapper.DeletePairsCurrencyPair)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/rates", wrapper.GetRatesBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/rates/stream", wrapper.GetRatesStream)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/rates/{currency_pair}", wrapper.GetRatesCurrencyPair)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/rates/{currency_pair}/stream", wrapper.GetRatesCurrencyPairStream)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/scenario", wrapper.DeleteScenario)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/scenario", wrapper.GetScenario)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/scenario", wrapper.PutScenario)
+	})
 
 	return r
 }
@@ -434,15 +4177,93 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/5STT2vbTBDGv8oy73toQbXcpPSgWwmhFHoovoZQJquRvEH7p7OjNMbou5cdqbFdm5Se",
-	"JO2Mnuc3u8/uwUafYqAgGZo9ZLslj/p6yxy5vCSOiVgc6bKNLZVnF9mjQAMuyPUVVCC7RPMn9cQwVeAp",
-	"Z+y1eylmYRd6mKYKmH6MjqmF5m7WPPTfv4jFh0eyUrRun+0WQ08bFDqH4mX1GOrjh4tQ4vxpa4tC73S1",
-	"+gvm0qRm54yl24UuFvGWsmWXxMUADXwKBpMzXWSTiZ+cJSNbFNNToCKWDS3TmfmzdNqRmYLdmYSOc2Fz",
-	"MhS/z/NfBzGo4Ik4z17vV+vVuswZEwVMDhq41qUKEspWd6tWl3r/2+J7sZhKpSc5x9+QjByywWG4BCpb",
-	"OoVdmY0WkclEbonNw067yv6Z2BnLhEXbvOk4ehOH1kg0gX6+BeVmrX5pdVhRsZvF4Bs61lEYPQlxhubu",
-	"T96bYxgohwKNDg8VBCynDyeTQ7XE/lJQ70sEcoohz0m7Wq/nWxCEgm4WpjQ4q8j1Yy4E+yM9J+T1x/+Z",
-	"Omjgv/pw4erlttUn4Z5ekoXMuJuDdTrhV5elbKQeAmi5w3GQfyJ7FUjv/gXnMdBzIivUGlp6Ksij98i7",
-	"o6i8ko6iOv0KAAD//66EOCR3BAAA",
+	"H4sIAAAAAAAC/+w9a3PbNrZ/BcO7O9vtpWXZSdu9ntkPbuMk7iaOx3KmL+eaMHkkoSYBFgDtqBn/9zvn",
+	"AHyJkCwncZrc8Uw+WCQInPcLB8i7KFVFqSRIa6K9d5FJ51Bw+nO/yoQ9kFYv8FepVQnaCqB3PLVCSfwL",
+	"ZFVEe79FZq7SyyiOphrgT4jiSIOpCvxjbm15PuVVbk0URzzLzksudBRHGeRgof5VVvbcpCC5Fqp92Xly",
+	"wdPLqcjz6E0c2UUJ0V5krBZyFt3ECJDSCE8GJtWidOBFR7wApqaMZ4WQzKpLkFHg62bqvXfR3zRMo73o",
+	"v7Zbwmx7qmx/78edwB8VGIufppXWINOFQ2Pv3XDyLv63zP/89PT4qRt5E0c17ue6kkPUTiqJmJU5X0DG",
+	"OnQaAOBYc8vSExp0E0dWFICDp0oX3EZ7UcYtbNHTwdw3yOY/KqEhQxnwgxwv4lpIWnapi98hJarVhDzm",
+	"MxhKl4S39jyttAmx9Ad6jqjbOTAcyko+g5gVwhghZ0xJepNz496ESFJWZg7ZcPKjqrgAmlxzC4a5ccwq",
+	"NhfGKr2I4pYwQtpvH7ezC2lhBtrRML00AZbRnCiOec5QXgxTOgMNGbtYMKRezKAo7YJdz0F6ELgGD0YU",
+	"R8JCYTaV0lPhOerg41rzRYBnCOo6HtXCvgIdhM8spJ2DEcZhUqgMckJ0cnDwhJXcWtAyZkYRZwwqpXaz",
+	"Mg220tJ0XuCsI1avzgRONGVTpdnJwfGL/V/q+UZn8liLtAYh5bnjFMiMcUsTToU2luXiCliJQ2upqZWW",
+	"mBCzWv0h69A8VdIKWanKsGth524WB9yZPOFyRrP1ZkJYc1EI68hwsn96cP7s4OjgZP/01cn59/s//Ofp",
+	"4YsX5y/3fz4/2T96dhCz71jGFwYHZ0AmYnQmo3hJHe6oCWyqVUGPSg1XAhHQYEolTYN/lwOjM3lo2SVA",
+	"aUgE6zGkP447ni6sBN1HeI8lB69PXk+e/PusGo8fpfg9/QXx68mTH49/GTxPHIIDhUSYN7U6cYRonhvx",
+	"JziiEOWivZ3xeNxXz0e7URwV/K0oqsK9H8dRIaT/HVJdUkuctlG1weqFkIfu5c6ycjnLMmTVcWXmpPVO",
+	"vlp7woScoRq8PnnBhDQWeEbWh+iO1szOoWhJcKFUDlySVqvhMgfSfY3SGTN4m+aVEVcwYq2qSrgC3ZV4",
+	"PrWgl7UFwYyZsCjR+Ap1qiOkUbwRo5ZMjSOt5/U6i0OGa+AVbve0CPVtxvHgbTpH8iBFBhD2l/AThiD9",
+	"gecgMx5QylPNM+Rb6kfssYLrSyBKqhIkyypNfPXjSP/hbQqlZXOVC/wdt2ZI/sOyGUjABxm7noscmLD/",
+	"MCzNlYFsdCZP24mYsVxbw7hliVZ5rq5AJ0xDzi0y1SomrGGFyKSYzS17ffpDzGA0G7GnP3fhQRF6qWRn",
+	"QiXZpKIH3LLd3b3xGD8O2SoEfzVRCFk1ZdcAlzGbvD6K2ctXRzE7fX0Qs58OnsTs9PnrmD09OWRKs8n+",
+	"aRRH8JYXZQ7InpevjqI4On19EMXRTwdP8O/nr6M4enpyiFxarbHLSloTOmBTiaw97nTdbgNMtDve3d3a",
+	"2d3a/TaKb19Q8gL63z/9OfRdzbUhYBPkBNnmDqPWs/aZQmHjNEGHjtHW7nxcjM2t+kpAx5GnQQNah3xB",
+	"1fA6dOy19BYl9s482ov+97f9rV/fvPv25m/BIBYVal3AlokZksCZswxSUfCclUpIy4R07t+s8P9FZSyD",
+	"Pyr8QEMqjFCyHiqksboqQNporW/5V8exjIeOZa2hCZHxQGsVop/K+tF5DcnQkxVgjA+v1/OZ5mzHB6Hp",
+	"Ws2hd2vDsJrwbikfOsFbntp8wRJiYrKGVbGL45gwzIAdBhwhk8PNZV+1dkbo6L/tOSm3SDD3E1no88cb",
+	"fl6EP/9mw891kKAvReYkNmaX6Bgw+EVfxq24ELmwiyh+7xXvkt/F0ZXKqyIA4YQifitSskfAMBirVQZT",
+	"ik3ypHD2SBRxbImJt47GDSgh8Wzy5pMqZCTolWFC4njImJBW1eFvYxO0qiyM2AtuSdqEYTzLaHAGJcgM",
+	"JIqwmjJl56CZS+jjM8nrmdgMnO8tlLFMSaIHoBrHzOpKpuTCL1S2iFnBcySP/824zDDfkEAZM9NgAOPy",
+	"7/GdW6iOBfo4GKuBFyakFbTweW0uBr7EVobhyw6M34wfdWK8kLUb2hi3SqnVBfeC2ZMsVV3kHbGSZKzx",
+	"u9xReQhax2d5+lvVpi9eRLpeeFyYkNz6BZZBW7ZbzUukg/8mZjsr6LAan4afd1oQVToHCy2CZC6FvOK5",
+	"yNiPk1dHJB+bwUBSc8f1+yJHy6vKNvBsuDBqTmAxbud1ro6rldzORy+5TecxS75OWKbAoEgX+Igl20mP",
+	"s9sUAm9/HTRhXpvuhCwpWlpRNDDn+TTuoi/qgJpdwFRpaJIe/+EmhFgyZ44qa62VGfp3XeUQqhzhY4Sc",
+	"6kUIFVFNyFncSd3qZwxnIQtWlrnYvHDUN6O3VY4cqCEED9uYaYgJTAH9ObCMWx4MyAa27IKbgHwdTl6x",
+	"x7s73zV2DCfCoc1sONFyePkoHF6mKxO6uoSMs9ul5K5vix6//S449fuGvCD5RR6qUj4Rht70ycZSjup0",
+	"AW3CGCwclKJsaict9GMMInY2jCGaKPlDA/I63lNT/+CDguw4+qNSdlNRobF3l5VbSgYkqzUgHWJ3idYR",
+	"t5bLIUU6puL+pK7tD6yF0mImAkw49XU8sqGxqybXOwTsmhuWK55B1iX22ijQdCBYu4ngx03ADgjlYe1M",
+	"FsKYClXfox3DtXiWCUSJ58c9vDeyZv1KzzAnDxWzBwlHFIBxNT/qNH+oMHVV8YMwashrobwdIxSCXEiX",
+	"9faQMkOswqm/G/xmDQ2Q0wPxeyEuNNdNiKPIWjUCaCjctfP2CbOK9rEGhj+48dU1yJvsftXL3pnMIRJT",
+	"QWx92HoB9hpAko7RftS0RRQxN00lp2ZQzP4ErfpBZye9K241QkinLqZrOYbCE0gJoBzuZ9QQjhi+b1Il",
+	"ejfFetA15TlcUk4Utxi5eE9ow3QlydxzzfMc8tGZ/JolVoPMErbld2WcntI2SOL+TthX49F4lwnD/nv3",
+	"7/9kOCnXmH9h9TqpaZ3QbLS/2c72e1WUlIcpmQLNWYrSJET7zgr06ZznFr+UqrPz09Zbp2qwmIaZKKBd",
+	"rVBXbV2JNr5ojqLKraDgi9a8Ujm3FIomoUmvufBgWIrf5rwsQZrQUCHTvMoIADPkCe78NMKW1H8loU0l",
+	"osKG6VoNwlrB97XknXHRF99xEVLLSyGzbv8AiUQUN30EyBnqIpg5n4QkiuLIox/sAUA2L9fGVmzQdh1a",
+	"qObhmbUReZZ0kRALKmC9DR8onpHQtgkv7hQ5/UJpWFO7XMPW5bDbV4pxoZiNR+MdUq6dv2+W49W07c/6",
+	"I0KN2i1KMyyYxkzCzK1KA1BVvNZk6lpuWCIaOioDaaWFXUzQXvsMAbgGvV/ZwN7bftv7gSnfVMwqHdyg",
+	"3X/y8vDo/PTVfw6OJmROcfpoz0/eQje3toxuEBIhp4FduH3JeClIeQ3oK8TXznm7j0P7PcQnb3Rw5NBB",
+	"C0v688x91U6GpTDQLvrG2t9ojAxSJUheimgvekSPKJydE3G2qftlm2M/D/6eQTAva/fhc4TK+qYZ18Vh",
+	"XJ6p8gzfULqJu4AoeqT8hxmBaonY1DlEyuvKCATF7ngcUQFbWp8YUnaa0ufbvxtnXZwP3thVd3qUhunq",
+	"ICKi0Qyk1QKow+bxeOdOMK0NN6lgH1j1Zd2XolklL6W6lr12JBrvd7DvG5RKwtvS1Q/Bj2nVKdr7ra9I",
+	"v725eRNHpioKrhcdESFBYrmatZ1VXkhoPi9uqCVbbduTF7qwwDy3tnxad4h9kNRs2l01pI0rsagpw2Gu",
+	"6uqrrfj+QVjeU1j0bWQtq4A1OqAuKPctzrP8IbYEjZiz7I1IGd/cMAPrxpuhiTqughJHBXwst9+bsLUh",
+	"gtUV3NyjmHdt4jobuKg9Nu+I+Pj+5erQl7eJuw+KdXfFKnOewq2a1Vpiiii23/UqVTfbvlEXaxgq1N13",
+	"VKdEobhzKU+qpBU5cx2/setEoEzIdZXpSkohZwFlVMZpI/YKmG7jwNO6i7jkmhdgQRsizKABrhcKC3yI",
+	"YU9Ud1wMO3p6ahh3WLmcYb/5XFX0s1CWx+PH9w/GD0vNlb1mqC9IZZ00m6Ea3a6lTqdWa+nJpirKZ1zI",
+	"u6jgSd2//6CCDyr4xaugk2anglOt/gR5V01sTlGEFdGVQsx71HC892yKeMIwd/glu4u6Tnzd7C/W1o8f",
+	"SDvEHmLoQH5EIhn78nmtnBq4a+jmkm3toP3olHRc9e1aVTl2OqSqALf1oHRTq3uwcF+mhTuk0N8w3tkG",
+	"cV1iK8KO7qG3dcEF19CeA7lY0BkTg1wbHI7qHmb4Kjl+NTll7vxHpXP6A7bd756RcW8S1/tEtbtm6/qf",
+	"eNTGKb3pCkfMMNFfNJ13wrBrLawFSZa3rk+5vrihBa3PGdxT6j84JfhpbVfvbF1AzPB5Q2HS/KZlw3OU",
+	"3nzyegBaKqVYruSsPsFSd8Kl/qSTbg75tZL2YK+W7NU34937h+K513gNvh91WXS+EKs5aU4umrbc0d+L",
+	"qVsC2wOAzn7WPTxm5Z5KfUrI2VAhGc/LOb8AK1Ket618GHmZuN7eMawy1H9YoC3L+QXkTd+ZAWOo0El9",
+	"BJeirLsWS9BCZSa4JdNA8Ul2ZOrVNtmPeSEMNUK0lPzrBWdQwF7u+SMJaTc5vTB0H9y2xaYHLZCYKziL",
+	"siR6QZkZsUktKmQeuYXl7yjbN5YJu3cma2uV9i2HpI5c1iQMLOm55cT1sz8eP8ahzt96EQ351WdgDzs0",
+	"+BSi1q53F2Hrse7zE7cWPKZhJoxLQfoStpybrttf6zCldzLr/2tdpysUoVijKzKfxF+/Dmnf5yh5eoPO",
+	"bJLEpplxlcwd+xaGj2MCbjlcucatbGBLP0dGtEXbPgYIazhR28+yQHWZ+dyvbkLpnO6wXNv6VHhzf8B1",
+	"qG58sXATt40z7fUL/VCUTlFeAPMNzUjvgDHrdHwLa+q6mD9mvub05cpMsK2+3DUNbMX04+eAPVu7UQK4",
+	"c49rr88gXM3yk+d7fVlV2gtGJqZT0Mb1Mq4RiIe8z65QMaZcEdmfEnEQ/s+nh5DxXAPPFl9kOW3ibj7w",
+	"oAuqSdS9euv8Yig2c+X8UNO1KtsllKS0LtP4MOVpk04HXfGIvSpB1idAe13AGlLAXFUqVijdubTmI9rP",
+	"J4TSYBfiMwsrHwcvzekLqd9reTAn9gvVU1XeTU1pwMokfcILYNywxI1bVufEt/VegeZ5IMJUsrlYKWYJ",
+	"Pf43z/OEFcCloUR/VXg3OpOJK3Qmq69jIs3+iNcxxWdyCYk6SnSgUDOdI2lKO0v1hUGJETKFZMSeA8/w",
+	"989bFE5uPePlFtmFhOXCWLOEZXwmG6N2PRfpfGleOweh/eKGXYMGBleCpIMiAjKMKwoQncNjd7FDVARP",
+	"kEt0cKHM6dD8lOcGvIX6owK9aE1U2Qkcw6bpPS+PMnZBPd/YFx/dxCsLSD1+4Nx0vUZNQOElZ9hWvsTc",
+	"KIwecTbqorPZZU+rTiFuKr4rwGlgbeFpT5d4eafbeMb/2hrvnI7He/Tv11rmh+92ft0E/Jfu2Glne6R3",
+	"G1rgsCD7qtMj39s2o4b5f65AkK6MC9O7OQK75s6yey2ndFQq1DO98tDknKwCgTMwDLc4ZRMzo+qLAO9u",
+	"JqJ1IcLN51h3aRxW2K10vNa2C/fe13n5zzfxYe3dCKMzeXAF0jKRebdUU90XrvsTvN+VgC6cXM4JqQAN",
+	"tHhCBwYxPsaQTauyrB0CVTgIr9hfzeIQmSlGwJ827zu3PNCJaAReDByUv8bINeCs8TMTx4o7OZrP3L8c",
+	"ZmFzTSyojZfT7BbYF9zYLZKQrcMn0YeVfS28tdu02lYr6Wu0OR4kVMTnenP7cyruTXyidhdtX13mD8cF",
+	"PmkMnC4LpI9tc4fbiPSFvjrmJHMrlGRfkZapnBo9JFxjT8ZPws7rCJApmS/WhYg9Tzhih9O1g1dEfBg2",
+	"etlhc27YfBh17jHUkqQBDz92JiOxKggmRkPC3RZMDQc9SOMGpOZejcr4j/CTAi9OHeBimdI1Rg6AVQix",
+	"u+GDUZsHKPZTxw4xwpBiiMShUs8rDAOZKuxHpKPQ6Kexyosg7zFhaeHk4JTP/KHkw+nWkZKwRZf0JGfS",
+	"m9vZn8KZW4wnc4HqwHiaQkn5Fr5lYtqrNAvTLRgbtc6O3nP5YLX1+MDw+p6D5zAwXfm6DRKUkXsGpLks",
+	"aT0kVn0EOG4Nx7/M4PsjXGWyegfMecFeNI7qHrgwhs9cI0Zbdayv/+3eC3arAVqvcN1UIBC8tkF/2DUo",
+	"7cVutX1FUJD3uTK2dXxks0KgNbciEQ0fBeuFHZKwOb+iW/gcNzJGgPWNrpou21Fm+eyTba64i8lBZk3b",
+	"krDNLb5U72ydmKdr9FnnROuKeCvym5VxEt7CDcb3stSecMXhGcM4tdmA3pqAtMzlQGg0uldmL19O2mRK",
+	"Ar/3JbRVCVGCMUCdaMmZZ0ovnk78oTrTSXM6QUTW1PbdwsmMl4lTWml7/4lAF2KEaXVh7aCTb/VmGhKp",
+	"rZ7HXfjaNMstK+ym/v89cqqPvYnwkBT9pUnRCnXv3teyajftxE9C1xJ1buOhg2/suHfTUMEzuhqontcl",
+	"/a6scC87ZZP2hqrb96jqwaR/loB6OCf9fhtDyxeE3cQru5ZWs+jj+e2lC/1C3flDgD/JfuCRapVB1HT7",
+	"HEODAEeDV0g05+SXPhix9mI8dxUbtx2zY5sKiC/y55VhCQ3EVLxRzZRLLAmQe+TG3dWrNPtl/+WLEfuo",
+	"nUpVXzLv4YTd0q2N3ZkWvMjfd6ZPeurlds2aBMX7k7Y89bX6wZpvbs2RvaZDv5ubm/8bAINny3u7bgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -3,7 +3,13 @@ package v1
 import (
 	"context"
 	"errors"
+	"fmt"
 	api "mtsbank/history/internal/api/http/v1"
+	"mtsbank/pkg/instrument"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
 )
 
 var _ GeneratorService = (*ClientWithResponses)(nil)
@@ -11,21 +17,52 @@ var _ GeneratorService = (*ClientWithResponses)(nil)
 var (
 	ErrNoDecodedValues = errors.New("no decoded values")
 	ErrBufferGrow      = errors.New("buffer had been grown")
+	ErrRatesGap        = errors.New("rates after since were evicted by generator")
+	// ErrUnknownCurrencyPair is returned, if generator doesn't generate values for currency pair
+	ErrUnknownCurrencyPair = errors.New("generator doesn't generate values for currency pair")
 )
 
+const (
+	// headerRatesGap is set by generator when rates requested by since cursor were lost
+	headerRatesGap = "X-Rates-Gap"
+	// headerRatesGapPairs lists currency pairs of batch, rates of which requested by cursors were lost
+	headerRatesGapPairs = "X-Rates-Gap-Pairs"
+)
+
+// AllCurrencyPairs requests rates of all generated currency pairs
+const AllCurrencyPairs = "all"
+
 type GeneratorService interface {
 	GetRates(ctx context.Context, currencyPair string, out []api.ExchangeRate) ([]api.ExchangeRate, error)
+	GetRatesSince(ctx context.Context, currencyPair string, since time.Time, out []api.ExchangeRate) ([]api.ExchangeRate, error)
+	GetPairsRates(ctx context.Context, currencyPairs []string, since map[string]time.Time) (map[string][]api.ExchangeRate, error)
+	instrument.Generator
 }
 
 // GetRates grows out slice and copies new rates to out slice.
 //
 // Makes a blocking http call
 func (c *ClientWithResponses) GetRates(ctx context.Context, currencyPair string, buffer []api.ExchangeRate) ([]api.ExchangeRate, error) {
-	resp, err := c.GetRatesCurrencyPairWithResponse(ctx, currencyPair)
+	return c.getRates(ctx, currencyPair, &GetRatesCurrencyPairParams{}, buffer)
+}
+
+// GetRatesSince works as GetRates, but returns only rates created after since.
+//
+// Returns ErrRatesGap along with rates, if some rates created after since are lost.
+func (c *ClientWithResponses) GetRatesSince(ctx context.Context, currencyPair string, since time.Time, buffer []api.ExchangeRate) ([]api.ExchangeRate, error) {
+	return c.getRates(ctx, currencyPair, &GetRatesCurrencyPairParams{Since: &since}, buffer)
+}
+
+func (c *ClientWithResponses) getRates(ctx context.Context, currencyPair string, params *GetRatesCurrencyPairParams, buffer []api.ExchangeRate) ([]api.ExchangeRate, error) {
+	resp, err := c.GetRatesCurrencyPairWithResponse(ctx, currencyPair, params)
 	if err != nil {
 		return buffer, err
 	}
 
+	if resp.StatusCode() == http.StatusNotFound {
+		return buffer, ErrUnknownCurrencyPair
+	}
+
 	if resp.JSON200 == nil {
 		return buffer, ErrNoDecodedValues
 	}
@@ -41,10 +78,125 @@ func (c *ClientWithResponses) GetRates(ctx context.Context, currencyPair string,
 
 	for i := range buffer {
 		buffer[i] = api.ExchangeRate{
-			Rate: (*resp.JSON200)[i].Rate,
-			Time: (*resp.JSON200)[i].Time,
+			Rate:   (*resp.JSON200)[i].Rate,
+			Time:   (*resp.JSON200)[i].Time,
+			Bid:    (*resp.JSON200)[i].Bid,
+			Ask:    (*resp.JSON200)[i].Ask,
+			Mid:    (*resp.JSON200)[i].Mid,
+			Volume: (*resp.JSON200)[i].Volume,
 		}
 	}
 
+	if ratesGap(resp.HTTPResponse) {
+		err = ErrRatesGap
+	}
+
 	return buffer, err
 }
+
+// GetPairsRates returns rates of currency pairs created after their times in since, pairs without time get all cached rates.
+// AllCurrencyPairs requests all generated currency pairs. Rates of all pairs are requested by one blocking http call.
+//
+// Returns ErrRatesGap along with rates, if some rates created after since are lost.
+func (c *ClientWithResponses) GetPairsRates(ctx context.Context, currencyPairs []string, since map[string]time.Time) (map[string][]api.ExchangeRate, error) {
+	params := &GetRatesBatchParams{Pairs: currencyPairs}
+	if len(since) != 0 {
+		cursor := formatCursor(since)
+		params.Cursor = &cursor
+	}
+
+	resp, err := c.GetRatesBatchWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, ErrUnknownCurrencyPair
+	}
+
+	if resp.JSON200 == nil {
+		return nil, ErrNoDecodedValues
+	}
+
+	batch := make(map[string][]api.ExchangeRate, len(resp.JSON200.AdditionalProperties))
+	for pair, rates := range resp.JSON200.AdditionalProperties {
+		out := make([]api.ExchangeRate, len(rates))
+		for i := range rates {
+			out[i] = api.ExchangeRate{
+				Rate:   rates[i].Rate,
+				Time:   rates[i].Time,
+				Bid:    rates[i].Bid,
+				Ask:    rates[i].Ask,
+				Mid:    rates[i].Mid,
+				Volume: rates[i].Volume,
+			}
+		}
+		batch[pair] = out
+	}
+
+	if gaps := resp.HTTPResponse.Header.Get(headerRatesGapPairs); gaps != "" {
+		return batch, fmt.Errorf("%s: %w", gaps, ErrRatesGap)
+	}
+
+	return batch, nil
+}
+
+// formatCursor encodes times of currency pairs as "EURUSD=<RFC 3339 time>,USDJPY=<RFC 3339 time>"
+func formatCursor(since map[string]time.Time) string {
+	pairs := make([]string, 0, len(since))
+	for p := range since {
+		pairs = append(pairs, p)
+	}
+	sort.Strings(pairs)
+
+	b := strings.Builder{}
+	for _, p := range pairs {
+		if b.Len() != 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(p)
+		b.WriteByte('=')
+		b.WriteString(since[p].Format(time.RFC3339Nano))
+	}
+	return b.String()
+}
+
+// Instruments returns instrument registry of generator
+func (c *ClientWithResponses) Instruments(ctx context.Context) ([]instrument.Instrument, error) {
+	resp, err := c.GetInstrumentsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.JSON200 == nil {
+		return nil, ErrNoDecodedValues
+	}
+
+	instruments := make([]instrument.Instrument, len(*resp.JSON200))
+	for i, in := range *resp.JSON200 {
+		instruments[i] = instrument.Instrument{CurrencyPair: in.CurrencyPair, Calendar: in.Calendar, Enabled: in.Enabled}
+	}
+	return instruments, nil
+}
+
+// Calendars returns trading calendars of instruments
+func (c *ClientWithResponses) Calendars(ctx context.Context) ([]instrument.Calendar, error) {
+	resp, err := c.GetCalendarsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.JSON200 == nil {
+		return nil, ErrNoDecodedValues
+	}
+
+	calendars := make([]instrument.Calendar, len(*resp.JSON200))
+	for i, cal := range *resp.JSON200 {
+		calendars[i] = instrument.Calendar{Name: cal.Name, Days: cal.Days, Rollover: cal.Rollover, Holidays: cal.Holidays}
+	}
+	return calendars, nil
+}
+
+func ratesGap(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(headerRatesGap) == "true"
+}
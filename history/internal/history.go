@@ -3,30 +3,34 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/mazitovt/logger"
 	"golang.org/x/sync/errgroup"
 	api "mtsbank/history/internal/api/http/v1"
 	gs "mtsbank/history/internal/client/generator_service"
 	"mtsbank/history/internal/repo"
+	"mtsbank/pkg/instrument"
 	"net/http"
 	"sync"
 	"time"
 )
 
-var poolExchangeRates = sync.Pool{New: func() any {
-	return []api.ExchangeRate{}
-}}
-
 var _ api.ServerInterface = (*SimpleHistoryService)(nil)
 
 type SimpleHistoryService struct {
 	repo            repo.Repo
 	generatorClient gs.GeneratorService
+	instruments     *instrument.Registry
 	logger          logger.Logger
+
+	// mu guards since
+	mu sync.Mutex
+	// since keeps time of the last collected rate per currency pair
+	since map[string]time.Time
 }
 
-func NewSimpleHistoryService(repo repo.Repo, generatorClient gs.GeneratorService, logger logger.Logger) *SimpleHistoryService {
-	return &SimpleHistoryService{repo: repo, generatorClient: generatorClient, logger: logger}
+func NewSimpleHistoryService(repo repo.Repo, generatorClient gs.GeneratorService, instruments *instrument.Registry, logger logger.Logger) *SimpleHistoryService {
+	return &SimpleHistoryService{repo: repo, generatorClient: generatorClient, instruments: instruments, logger: logger, since: map[string]time.Time{}}
 }
 
 func (s *SimpleHistoryService) GetRatesCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string, params api.GetRatesCurrencyPairParams) {
@@ -42,11 +46,19 @@ func (s *SimpleHistoryService) GetRatesCurrencyPair(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// rates are labeled with trading sessions of calendar
+	cal := s.instruments.Calendar(currencyPair)
 	exchangeRates := make([]api.ExchangeRate, len(res))
 	for i := range res {
+		session := cal.Session(res[i].Time)
 		exchangeRates[i] = api.ExchangeRate{
-			Time: res[i].Time,
-			Rate: res[i].Rate,
+			Time:    res[i].Time,
+			Rate:    res[i].Rate,
+			Bid:     res[i].Bid,
+			Ask:     res[i].Ask,
+			Mid:     res[i].Rate,
+			Volume:  res[i].Volume,
+			Session: &session,
 		}
 	}
 
@@ -59,33 +71,49 @@ func (s *SimpleHistoryService) GetRatesCurrencyPair(w http.ResponseWriter, r *ht
 	}
 }
 
+// PostRatesCurrencyPair stores rates pushed to history, e.g. rates backfilled by generator before its live rates.
+// It isn't authenticated: only generator POST /backfill may call it, so history must be reachable from internal network only.
+func (s *SimpleHistoryService) PostRatesCurrencyPair(w http.ResponseWriter, r *http.Request, currencyPair string) {
+	var rates api.PostRatesCurrencyPairJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&rates); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid rates: "+err.Error())
+		return
+	}
+
+	if err := s.repo.InsertCurrencies(r.Context(), []string{currencyPair}); err != nil {
+		s.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(rates) != 0 {
+		if err := s.repo.InsertWithCurrencyPair(r.Context(), currencyPair, rates); err != nil {
+			s.writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	s.logger.Info("ingested rates: pair=%v, count=%v", currencyPair, len(rates))
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (s *SimpleHistoryService) Start(ctx context.Context, period time.Duration) {
 	ticker := time.NewTicker(period)
 	defer ticker.Stop()
 
 	for {
+		// currency pairs are stored as they are enabled in instrument registry
+		if err := s.instruments.Load(ctx); err != nil {
+			s.logger.Error("instrument.Registry.Load err: %v", err)
+		}
+		if err := s.repo.InsertCurrencies(ctx, s.instruments.Enabled()); err != nil {
+			s.logger.Error("repo.Repo.InsertCurrencies err: %v", err)
+		}
+
 		currencies, err := s.repo.Currencies(ctx)
 		if err != nil {
 			s.logger.Error("repo.Repo.Currencies err: %v", err)
 		}
 
-		g := new(errgroup.Group)
-
-		for _, c := range currencies {
-			g.Go(func() error {
-				var err error
-				out := poolExchangeRates.Get().([]api.ExchangeRate)
-				out = out[:0]
-				defer poolExchangeRates.Put(out)
-				out, err = s.generatorClient.GetRates(ctx, c, out)
-				if err != nil && err != gs.ErrBufferGrow {
-					return err
-				}
-				return s.repo.InsertWithCurrencyPair(ctx, c, out)
-			})
-		}
-
-		if err = g.Wait(); err != nil {
+		if err = s.collect(ctx, currencies); err != nil {
 			s.logger.Error("collecting new rates : err: %v", err)
 		}
 
@@ -98,6 +126,54 @@ func (s *SimpleHistoryService) Start(ctx context.Context, period time.Duration)
 	}
 }
 
+// collect inserts rates of currency pairs created after the last collected ones.
+// Rates of all generated pairs are requested at once, pairs which aren't stored are skipped.
+func (s *SimpleHistoryService) collect(ctx context.Context, currencies []string) error {
+	s.mu.Lock()
+	since := make(map[string]time.Time, len(s.since))
+	for p, t := range s.since {
+		since[p] = t
+	}
+	s.mu.Unlock()
+
+	batch, err := s.generatorClient.GetPairsRates(ctx, []string{gs.AllCurrencyPairs}, since)
+	switch {
+	case err == nil:
+	case errors.Is(err, gs.ErrRatesGap):
+		s.logger.Warn("rates are lost: %v", err)
+	default:
+		return err
+	}
+
+	g := new(errgroup.Group)
+
+	for _, c := range currencies {
+		c := c
+		out, ok := batch[c]
+		if !ok {
+			// instrument isn't generated at the moment
+			s.logger.Debug("generator doesn't generate values for %s", c)
+			continue
+		}
+		if len(out) == 0 {
+			continue
+		}
+
+		g.Go(func() error {
+			if err := s.repo.InsertWithCurrencyPair(ctx, c, out); err != nil {
+				return err
+			}
+
+			s.mu.Lock()
+			s.since[c] = out[len(out)-1].Time
+			s.mu.Unlock()
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
 func (s *SimpleHistoryService) writeError(w http.ResponseWriter, code int, message string) {
 	petErr := api.Error{
 		Code:    int32(code),
@@ -119,8 +195,12 @@ func (s *SimpleHistoryService) getByTime(ctx context.Context, currencyPair strin
 	exchangeRates := make([]api.ExchangeRate, 0)
 	for _, rate := range res {
 		exchangeRates = append(exchangeRates, api.ExchangeRate{
-			Time: rate.Time,
-			Rate: rate.Rate,
+			Time:   rate.Time,
+			Rate:   rate.Rate,
+			Bid:    rate.Bid,
+			Ask:    rate.Ask,
+			Mid:    rate.Rate,
+			Volume: rate.Volume,
 		})
 	}
 
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	api "mtsbank/history/internal/api/http/v1"
+	gs "mtsbank/history/internal/client/generator_service"
+	"mtsbank/history/internal/repo"
+	"mtsbank/pkg/instrument"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+
+type fakeRepo struct {
+	repo.Repo
+
+	mu         sync.Mutex
+	currencies []string
+	rates      map[string][]api.ExchangeRate
+}
+
+func (f *fakeRepo) InsertWithCurrencyPair(_ context.Context, currencyPair string, data []api.ExchangeRate) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rates[currencyPair] = append(f.rates[currencyPair], data...)
+	return nil
+}
+
+func (f *fakeRepo) InsertCurrencies(_ context.Context, currencyPairs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.currencies = append(f.currencies, currencyPairs...)
+	return nil
+}
+
+type fakeGenerator struct {
+	gs.GeneratorService
+	instruments []instrument.Instrument
+	batch       map[string][]api.ExchangeRate
+	err         error
+	// since keeps cursors of GetPairsRates calls
+	since []map[string]time.Time
+}
+
+func (f *fakeGenerator) GetPairsRates(_ context.Context, currencyPairs []string, since map[string]time.Time) (map[string][]api.ExchangeRate, error) {
+	if len(currencyPairs) != 1 || currencyPairs[0] != gs.AllCurrencyPairs {
+		return nil, errors.New("rates of all pairs are expected")
+	}
+	f.since = append(f.since, since)
+	return f.batch, f.err
+}
+
+func (f *fakeGenerator) Instruments(context.Context) ([]instrument.Instrument, error) {
+	return f.instruments, nil
+}
+
+func (f *fakeGenerator) Calendars(context.Context) ([]instrument.Calendar, error) {
+	return nil, nil
+}
+
+// newTestServer routes requests to service as main does
+func newTestServer(t *testing.T, s *SimpleHistoryService) http.Handler {
+	swagger, err := api.GetSwagger()
+	require.Nil(t, err)
+	swagger.Servers = nil
+
+	r := chi.NewRouter()
+	r.Use(middleware.OapiRequestValidator(swagger))
+	api.HandlerWithOptions(s, api.ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: []api.MiddlewareFunc{s.instruments.Validator()},
+	})
+	return r
+}
+
+func TestSimpleHistoryService_PostRatesCurrencyPair(t *testing.T) {
+	g := &fakeGenerator{instruments: []instrument.Instrument{{CurrencyPair: "EURUSD", Enabled: true}}}
+	instruments := instrument.NewRegistry(g, logger.New(logger.Error))
+	require.Nil(t, instruments.Load(context.Background()))
+	r := &fakeRepo{rates: map[string][]api.ExchangeRate{}}
+	h := newTestServer(t, NewSimpleHistoryService(r, g, instruments, logger.New(logger.Error)))
+
+	post := func(pair, body string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/rates/"+pair, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	rate := `[{"time":"2022-08-01T00:00:00Z","rate":"1.00005","bid":"1.00004","ask":"1.00006","mid":"1.00005","volume":1}]`
+	require.Equal(t, http.StatusNoContent, post("EURUSD", rate))
+	require.Equal(t, []string{"EURUSD"}, r.currencies)
+	require.Len(t, r.rates["EURUSD"], 1)
+	require.True(t, r.rates["EURUSD"][0].Time.Equal(epoch))
+
+	// empty body isn't stored
+	require.Equal(t, http.StatusBadRequest, post("EURUSD", ""))
+	// currency pair unknown to instrument registry isn't stored
+	require.Equal(t, http.StatusNotFound, post("GBPUSD", rate))
+	require.Len(t, r.rates, 1)
+	require.Equal(t, []string{"EURUSD"}, r.currencies)
+}
+
+func TestSimpleHistoryService_collect(t *testing.T) {
+	at := func(sec int) api.ExchangeRate {
+		return api.ExchangeRate{Time: epoch.Add(time.Duration(sec) * time.Second), Rate: "1", Bid: "1", Ask: "1", Mid: "1"}
+	}
+	g := &fakeGenerator{batch: map[string][]api.ExchangeRate{
+		"EURUSD": {at(1), at(2)},
+		"USDJPY": {},
+		// GBPUSD isn't stored, so its rates are skipped
+		"GBPUSD": {at(1)},
+	}}
+	r := &fakeRepo{rates: map[string][]api.ExchangeRate{}}
+	s := NewSimpleHistoryService(r, g, instrument.NewRegistry(g, logger.New(logger.Error)), logger.New(logger.Error))
+	currencies := []string{"EURUSD", "USDJPY", "USDRUB"}
+
+	require.Nil(t, s.collect(context.Background(), currencies))
+	require.Equal(t, map[string][]api.ExchangeRate{"EURUSD": {at(1), at(2)}}, r.rates)
+
+	// the next rates are requested after the last stored ones, lost rates don't stop storing
+	g.batch = map[string][]api.ExchangeRate{"EURUSD": {at(3)}, "USDJPY": {at(3)}}
+	g.err = fmt.Errorf("USDJPY: %w", gs.ErrRatesGap)
+	require.Nil(t, s.collect(context.Background(), currencies))
+	require.Equal(t, map[string][]api.ExchangeRate{"EURUSD": {at(1), at(2), at(3)}, "USDJPY": {at(3)}}, r.rates)
+
+	// nothing is stored if generator fails
+	g.err = errors.New("generator is down")
+	require.ErrorIs(t, s.collect(context.Background(), currencies), g.err)
+	require.Len(t, r.rates["EURUSD"], 3)
+
+	require.Equal(t, []map[string]time.Time{
+		{},
+		{"EURUSD": epoch.Add(2 * time.Second)},
+		{"EURUSD": epoch.Add(3 * time.Second), "USDJPY": epoch.Add(3 * time.Second)},
+	}, g.since)
+}
@@ -43,7 +43,10 @@ func (r *RepoPG) InsertWithCurrencyPair(ctx context.Context, currencyPair string
 		registryRows[i] = RegistryRow{
 			CurrencyPair: currencyPair,
 			Time:         data[i].Time,
-			Rate:         data[i].Rate,
+			Rate:         data[i].Mid,
+			Bid:          data[i].Bid,
+			Ask:          data[i].Ask,
+			Volume:       data[i].Volume,
 		}
 	}
 
@@ -103,6 +106,28 @@ func (r *RepoPG) Currencies(ctx context.Context) ([]string, error) {
 	return currencies, nil
 }
 
+func (r *RepoPG) InsertCurrencies(ctx context.Context, currencyPairs []string) error {
+	if len(currencyPairs) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, 0, len(currencyPairs))
+	valueArgs := make([]interface{}, 0, len(currencyPairs))
+	for i, p := range currencyPairs {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d)", i+1))
+		valueArgs = append(valueArgs, p)
+	}
+	stmt := fmt.Sprintf("INSERT INTO currency_pair(name) VALUES %s ON CONFLICT DO NOTHING", strings.Join(valueStrings, ","))
+	r.logger.Info("RepoPG.InsertCurrencies: query: %s", stmt)
+
+	if _, err := r.db.ExecContext(ctx, stmt, valueArgs...); err != nil {
+		r.logger.Debug("DB.ExecContext: err: %s", err)
+		return err
+	}
+
+	return nil
+}
+
 func (r *RepoPG) Insert(ctx context.Context, data []RegistryRow) error {
 	r.logger.Debug("RepoPg.Insert: start")
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault, ReadOnly: false})
@@ -118,12 +143,12 @@ func (r *RepoPG) Insert(ctx context.Context, data []RegistryRow) error {
 	}()
 
 	valueStrings := make([]string, 0, len(data))
-	valueArgs := make([]interface{}, 0, len(data)*3)
+	valueArgs := make([]interface{}, 0, len(data)*6)
 	for i, v := range data {
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d)", 3*i+1, 3*i+2, 3*i+3))
-		valueArgs = append(valueArgs, v.CurrencyPair, v.Time.Round(time.Microsecond), v.Rate)
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", 6*i+1, 6*i+2, 6*i+3, 6*i+4, 6*i+5, 6*i+6))
+		valueArgs = append(valueArgs, v.CurrencyPair, v.Time.Round(time.Microsecond), v.Rate, v.Bid, v.Ask, v.Volume)
 	}
-	stmt := fmt.Sprintf("INSERT INTO registry(name, creation_time, rate) VALUES %s ON CONFLICT DO NOTHING",
+	stmt := fmt.Sprintf("INSERT INTO registry(name, creation_time, rate, bid, ask, volume) VALUES %s ON CONFLICT DO NOTHING",
 		strings.Join(valueStrings, ","))
 
 	// TODO: stmt[:len(stmt)%50]
@@ -155,7 +180,7 @@ func (r *RepoPG) GetByTime(ctx context.Context, currencyPair string, start time.
 		}
 	}()
 
-	q := "SELECT name, creation_time, rate FROM registry WHERE name = $1 AND creation_time >= $2 AND creation_time <= $3 ORDER BY creation_time"
+	q := "SELECT name, creation_time, rate, bid, ask, volume FROM registry WHERE name = $1 AND creation_time >= $2 AND creation_time <= $3 ORDER BY creation_time"
 	r.logger.Info("RepoPG.GetByTime: query: %s", q)
 
 	rows, err := tx.QueryContext(ctx, q, currencyPair, start, end)
@@ -169,7 +194,7 @@ func (r *RepoPG) GetByTime(ctx context.Context, currencyPair string, start time.
 	defer rows.Close()
 	for rows.Next() {
 		row := RegistryRow{}
-		err := rows.Scan(&row.CurrencyPair, &row.Time, &row.Rate)
+		err := rows.Scan(&row.CurrencyPair, &row.Time, &row.Rate, &row.Bid, &row.Ask, &row.Volume)
 		if err != nil {
 			r.logger.Debug("Row.Scan: ", err)
 			return nil, err
@@ -233,6 +258,7 @@ func (r *RepoPG) hasCurrencyPair(ctx context.Context, currencyPair string) (bool
 	return exists, nil
 }
 
+// Migrate creates tables or upgrades registry of previous versions, it can be run on every start.
 // TODO: remove migration to file
 func (r *RepoPG) Migrate() error {
 	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelDefault, ReadOnly: false})
@@ -247,19 +273,30 @@ func (r *RepoPG) Migrate() error {
 		}
 	}()
 
-	q := `CREATE TABLE currency_pair(
+	q := `CREATE TABLE IF NOT EXISTS currency_pair(
                       name text PRIMARY KEY 
 );
 
-CREATE TABLE registry(
+CREATE TABLE IF NOT EXISTS registry(
                         name text REFERENCES currency_pair(name) NOT NULL,
                         creation_time timestamptz NOT NULL,
-                        rate INT NOT NULL,
+                        rate NUMERIC NOT NULL,
+                        bid NUMERIC NOT NULL,
+                        ask NUMERIC NOT NULL,
+                        volume BIGINT NOT NULL DEFAULT 0,
                         PRIMARY KEY (name, creation_time)
 
 );
 
-INSERT INTO currency_pair(name) VALUES ('EURUSD');
+-- registry created before bid, ask and volume were stored keeps integer rates, its rates become bid and ask
+ALTER TABLE registry ALTER COLUMN rate TYPE NUMERIC;
+ALTER TABLE registry ADD COLUMN IF NOT EXISTS bid NUMERIC;
+ALTER TABLE registry ADD COLUMN IF NOT EXISTS ask NUMERIC;
+ALTER TABLE registry ADD COLUMN IF NOT EXISTS volume BIGINT NOT NULL DEFAULT 0;
+UPDATE registry SET bid = rate WHERE bid IS NULL;
+UPDATE registry SET ask = rate WHERE ask IS NULL;
+ALTER TABLE registry ALTER COLUMN bid SET NOT NULL;
+ALTER TABLE registry ALTER COLUMN ask SET NOT NULL;
 `
 
 	_, err = tx.ExecContext(context.Background(), q)
@@ -13,7 +13,106 @@ import (
 )
 
 func TestRepoPG_CreatePrincipal(t *testing.T) {
+	cfg := startPostgres(t)
 
+	if err := migrate(dsn(cfg)); err != nil {
+		t.Fatal("migrate: ", err)
+	}
+
+	r, err := NewRepoPG(cfg, logger.New(logger.Debug))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = r.InsertCurrencies(context.Background(), []string{"EURUSD", "USDRUB"}); err != nil {
+		t.Fatal("InsertCurrencies: ", err)
+	}
+
+	if err = r.Insert(context.Background(), []RegistryRow{
+		{"EURUSD", time.Now(), "1.00045", "1.00044", "1.00046", 1000},
+		{"EURUSD", time.Now(), "1.00045", "1.00044", "1.00046", 1000},
+		{"USDRUB", time.Now(), "60.02", "60.01", "60.03", 500},
+	}); err != nil {
+		t.Fatal("Insert: ", err)
+	}
+
+	f, err := r.hasCurrencyPair(context.Background(), "RUB")
+	t.Log(f, err)
+	f, err = r.hasCurrencyPair(context.Background(), "EURUSD")
+	t.Log(f, err)
+
+	byTime, err := r.GetByTime(context.Background(), "EURUSD", time.Now().Truncate(time.Hour), time.Now().Add(time.Hour))
+	t.Log(byTime, err)
+
+	cur, err := r.Currencies(context.Background())
+	t.Log(cur, err)
+
+}
+
+func TestRepoPG_Migrate_Upgrade(t *testing.T) {
+	cfg := startPostgres(t)
+
+	// schema and rates of the first version of the service
+	db, err := sql.Open("postgres", dsn(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err = db.Exec(`CREATE TABLE currency_pair(
+                      name text PRIMARY KEY 
+);
+
+CREATE TABLE registry(
+                        name text REFERENCES currency_pair(name) NOT NULL,
+                        creation_time timestamptz NOT NULL,
+                        rate INT NOT NULL,
+                        PRIMARY KEY (name, creation_time)
+
+);
+
+INSERT INTO currency_pair(name) VALUES ('EURUSD');
+INSERT INTO registry(name, creation_time, rate) VALUES ('EURUSD', '2022-08-01T00:00:00Z', 100045);
+`); err != nil {
+		t.Fatal("baseline: ", err)
+	}
+
+	r, err := NewRepoPG(cfg, logger.New(logger.Debug))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// migration is idempotent
+	for i := 0; i < 2; i++ {
+		if err = r.Migrate(); err != nil {
+			t.Fatal("Migrate: ", err)
+		}
+	}
+
+	epoch := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	if err = r.Insert(context.Background(), []RegistryRow{
+		{"EURUSD", epoch.Add(time.Second), "1.00045", "1.00044", "1.00046", 1000},
+	}); err != nil {
+		t.Fatal("Insert: ", err)
+	}
+
+	rows, err := r.GetByTime(context.Background(), "EURUSD", epoch, epoch.Add(time.Second))
+	if err != nil {
+		t.Fatal("GetByTime: ", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("GetByTime: got %d rows, want 2", len(rows))
+	}
+	if old := rows[0]; old.Rate != "100045" || old.Bid != "100045" || old.Ask != "100045" || old.Volume != 0 {
+		t.Fatalf("rate of baseline schema: got %+v", old)
+	}
+	if rows[1].Bid != "1.00044" || rows[1].Volume != 1000 {
+		t.Fatalf("rate of upgraded schema: got %+v", rows[1])
+	}
+}
+
+// startPostgres starts database of history in container
+func startPostgres(t *testing.T) *config.PostgresConfig {
 	req := testcontainers.ContainerRequest{
 		Image:        "postgres:14.3-alpine3.16",
 		ExposedPorts: []string{"5432/tcp"},
@@ -33,6 +132,7 @@ func TestRepoPG_CreatePrincipal(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	t.Cleanup(func() { _ = container.Terminate(context.Background()) })
 
 	ip, err := container.Host(context.TODO())
 	if err != nil {
@@ -45,42 +145,18 @@ func TestRepoPG_CreatePrincipal(t *testing.T) {
 	}
 
 	t.Log(ip, mappedPort.Port())
-	if err := migrate(fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=%s", "history", "history", ip, mappedPort.Port(), "history", "disable")); err != nil {
-		t.Fatal("migrate: ", err)
-	}
-
-	r, err := NewRepoPG(&config.PostgresConfig{
+	return &config.PostgresConfig{
 		Host:     ip,
 		Port:     mappedPort.Port(),
 		User:     "history",
 		Password: "history",
 		DBname:   "history",
 		Sslmode:  "disable",
-	}, logger.New(logger.Debug))
-
-	if err != nil {
-		t.Fatal(err)
 	}
+}
 
-	if err = r.Insert(context.Background(), []RegistryRow{
-		{"EURUSD", time.Now(), 45},
-		{"EURUSD", time.Now(), 45},
-		{"USDRUB", time.Now(), 2},
-	}); err != nil {
-		t.Fatal("Insert: ", err)
-	}
-
-	f, err := r.hasCurrencyPair(context.Background(), "RUB")
-	t.Log(f, err)
-	f, err = r.hasCurrencyPair(context.Background(), "EURUSD")
-	t.Log(f, err)
-
-	byTime, err := r.GetByTime(context.Background(), "EURUSD", time.Now().Truncate(time.Hour), time.Now().Add(time.Hour))
-	t.Log(byTime, err)
-
-	cur, err := r.Currencies(context.Background())
-	t.Log(cur, err)
-
+func dsn(cfg *config.PostgresConfig) string {
+	return fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=%s", cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBname, cfg.Sslmode)
 }
 
 func migrate(dsn string) error {
@@ -110,7 +186,10 @@ func migrate(dsn string) error {
 CREATE TABLE registry(
                         name text REFERENCES currency_pair(name) NOT NULL,
                         creation_time timestamp NOT NULL,
-                        rate INT NOT NULL,
+                        rate NUMERIC NOT NULL,
+                        bid NUMERIC NOT NULL,
+                        ask NUMERIC NOT NULL,
+                        volume BIGINT NOT NULL DEFAULT 0,
                         PRIMARY KEY (name, creation_time)
 
 );
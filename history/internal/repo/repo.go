@@ -11,10 +11,14 @@ var (
 	ErrNoCurrencyPair = errors.New("currency pair doesn't exist in database")
 )
 
+// RegistryRow is a stored rate, Rate is a mid price. Prices are decimal strings stored as NUMERIC, so scale is kept
 type RegistryRow struct {
 	CurrencyPair string
 	Time         time.Time
-	Rate         int64
+	Rate         string
+	Bid          string
+	Ask          string
+	Volume       int64
 }
 
 // TODO: receive buffer to write query results
@@ -23,4 +27,6 @@ type Repo interface {
 	InsertWithCurrencyPair(ctx context.Context, currencyPair string, data []api.ExchangeRate) error
 	GetByTime(ctx context.Context, currencyPair string, start time.Time, end time.Time) ([]RegistryRow, error)
 	Currencies(ctx context.Context) ([]string, error)
+	// InsertCurrencies adds currency pairs, which aren't stored yet
+	InsertCurrencies(ctx context.Context, currencyPairs []string) error
 }
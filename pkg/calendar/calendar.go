@@ -0,0 +1,212 @@
+package calendar
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// AlwaysOpen is a name of built-in calendar, which trades all the time
+	AlwaysOpen = "24x7"
+	// FX is a name of built-in calendar, which trades from Sunday 22:00 to Friday 22:00 UTC
+	FX = "FX"
+)
+
+// dateLayout is a format of trading days and holidays
+const dateLayout = "2006-01-02"
+
+const day = 24 * time.Hour
+
+var (
+	ErrName     = errors.New("calendar name must not be empty")
+	ErrUnknown  = errors.New("unknown built-in calendar")
+	ErrDays     = errors.New("trading days must be SUN, MON, TUE, WED, THU, FRI or SAT")
+	ErrRollover = errors.New("rollover must be within a day from midnight UTC")
+	ErrHoliday  = errors.New("holiday must be a date YYYY-MM-DD")
+)
+
+// weekdays are names of trading days in order of time.Weekday
+var weekdays = [7]string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// Calendar is a trading calendar: market is open during trading days except holidays.
+// Trading day starts at rollover relative to its midnight UTC, e.g. FX trading day of Monday starts on Sunday at 22:00 UTC.
+type Calendar struct {
+	name     string
+	days     [7]bool
+	rollover time.Duration
+	holidays map[time.Time]struct{}
+}
+
+// New creates calendar of trading days (e.g. "MON", "FRI"), rollover and closed trading days (e.g. "2022-12-26")
+func New(name string, days []string, rollover time.Duration, holidays []string) (*Calendar, error) {
+	if name == "" {
+		return nil, ErrName
+	}
+	if rollover <= -day || rollover >= day {
+		return nil, ErrRollover
+	}
+
+	c := &Calendar{name: name, rollover: rollover, holidays: make(map[time.Time]struct{}, len(holidays))}
+	for _, d := range days {
+		i := weekdayIndex(d)
+		if i < 0 {
+			return nil, ErrDays
+		}
+		c.days[i] = true
+	}
+	if !c.trades() {
+		return nil, ErrDays
+	}
+
+	for _, h := range holidays {
+		t, err := time.Parse(dateLayout, h)
+		if err != nil {
+			return nil, ErrHoliday
+		}
+		c.holidays[t] = struct{}{}
+	}
+
+	return c, nil
+}
+
+// Parse works as New, but rollover is a duration string, e.g. "-2h"
+func Parse(name string, days []string, rollover string, holidays []string) (*Calendar, error) {
+	d, err := time.ParseDuration(rollover)
+	if err != nil {
+		return nil, ErrRollover
+	}
+	return New(name, days, d, holidays)
+}
+
+// Builtin returns built-in calendar of name with holidays
+func Builtin(name string, holidays []string) (*Calendar, error) {
+	switch name {
+	case AlwaysOpen:
+		return New(AlwaysOpen, weekdays[:], 0, holidays)
+	case FX:
+		return New(FX, weekdays[1:6], -2*time.Hour, holidays)
+	}
+	return nil, ErrUnknown
+}
+
+func weekdayIndex(name string) int {
+	for i, w := range weekdays {
+		if strings.EqualFold(w, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// trades reports whether calendar has trading days
+func (c *Calendar) trades() bool {
+	for _, ok := range c.days {
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Calendar) Name() string {
+	return c.name
+}
+
+// Days returns trading days of week starting from Sunday
+func (c *Calendar) Days() []string {
+	out := make([]string, 0, len(weekdays))
+	for i, ok := range c.days {
+		if ok {
+			out = append(out, weekdays[i])
+		}
+	}
+	return out
+}
+
+// Rollover returns start of trading day relative to its midnight UTC
+func (c *Calendar) Rollover() time.Duration {
+	return c.rollover
+}
+
+// Holidays returns closed trading days in chronological order
+func (c *Calendar) Holidays() []string {
+	out := make([]string, 0, len(c.holidays))
+	for h := range c.holidays {
+		out = append(out, h.Format(dateLayout))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Day returns trading day of t as midnight UTC, it labels trading session of t
+func (c *Calendar) Day(t time.Time) time.Time {
+	y, m, d := t.UTC().Add(-c.rollover).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Session returns trading day of t as date YYYY-MM-DD
+func (c *Calendar) Session(t time.Time) string {
+	return c.Day(t).Format(dateLayout)
+}
+
+// Open reports whether market is open at t
+func (c *Calendar) Open(t time.Time) bool {
+	return c.open(c.Day(t))
+}
+
+// open reports whether trading day is open
+func (c *Calendar) open(d time.Time) bool {
+	if !c.days[d.Weekday()] {
+		return false
+	}
+	_, holiday := c.holidays[d]
+	return !holiday
+}
+
+// NextOpen returns t, if market is open at t, otherwise start of the next open trading day
+func (c *Calendar) NextOpen(t time.Time) time.Time {
+	d := c.Day(t)
+	if c.open(d) {
+		return t
+	}
+	// calendar trades at least one day a week, holidays are finite
+	for !c.open(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d.Add(c.rollover)
+}
+
+// OpenDuration returns time between from and to during which market is open
+func (c *Calendar) OpenDuration(from, to time.Time) time.Duration {
+	var total time.Duration
+	for d := c.Day(from); d.Add(c.rollover).Before(to); d = d.AddDate(0, 0, 1) {
+		if !c.open(d) {
+			continue
+		}
+		start, end := d.Add(c.rollover), d.AddDate(0, 0, 1).Add(c.rollover)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// TradingDays returns number of open trading days from trading day of from until trading day of to
+func (c *Calendar) TradingDays(from, to time.Time) int {
+	n := 0
+	end := c.Day(to)
+	for d := c.Day(from); d.Before(end); d = d.AddDate(0, 0, 1) {
+		if c.open(d) {
+			n++
+		}
+	}
+	return n
+}
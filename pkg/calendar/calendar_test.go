@@ -0,0 +1,97 @@
+package calendar
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// friday is 2022-08-05, a trading day of FX
+var friday = time.Date(2022, 8, 5, 0, 0, 0, 0, time.UTC)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar string
+		days     []string
+		rollover time.Duration
+		holidays []string
+		err      error
+	}{
+		{name: "valid", calendar: "MOEX", days: []string{"mon", "TUE"}, rollover: 7 * time.Hour, holidays: []string{"2023-01-02"}},
+		{name: "empty name", days: []string{"MON"}, err: ErrName},
+		{name: "unknown day", calendar: "MOEX", days: []string{"MONDAY"}, err: ErrDays},
+		{name: "no days", calendar: "MOEX", err: ErrDays},
+		{name: "rollover", calendar: "MOEX", days: []string{"MON"}, rollover: 24 * time.Hour, err: ErrRollover},
+		{name: "holiday", calendar: "MOEX", days: []string{"MON"}, holidays: []string{"02.01.2023"}, err: ErrHoliday},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.calendar, tc.days, tc.rollover, tc.holidays)
+			require.Equal(t, tc.err, err)
+		})
+	}
+
+	c, err := Parse("MOEX", []string{"TUE", "mon"}, "7h", []string{"2023-01-03", "2023-01-02"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"MON", "TUE"}, c.Days())
+	require.Equal(t, 7*time.Hour, c.Rollover())
+	require.Equal(t, []string{"2023-01-02", "2023-01-03"}, c.Holidays())
+
+	_, err = Builtin("NYSE", nil)
+	require.Equal(t, ErrUnknown, err)
+}
+
+func TestCalendar_Open(t *testing.T) {
+	fx, err := Builtin(FX, []string{"2022-08-03"})
+	require.Nil(t, err)
+	always, err := Builtin(AlwaysOpen, nil)
+	require.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		t       time.Time
+		open    bool
+		session string
+	}{
+		{name: "friday", t: friday.Add(21 * time.Hour), open: true, session: "2022-08-05"},
+		{name: "friday close", t: friday.Add(22 * time.Hour), open: false, session: "2022-08-06"},
+		{name: "saturday", t: friday.Add(36 * time.Hour), open: false, session: "2022-08-06"},
+		{name: "sunday before open", t: friday.Add(2*day + 21*time.Hour), open: false, session: "2022-08-07"},
+		{name: "sunday open", t: friday.Add(2*day + 22*time.Hour), open: true, session: "2022-08-08"},
+		{name: "holiday", t: friday.Add(-2*day + 12*time.Hour), open: false, session: "2022-08-03"},
+		{name: "holiday starts on previous day", t: friday.Add(-3*day + 23*time.Hour), open: false, session: "2022-08-03"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.open, fx.Open(tc.t))
+			require.Equal(t, tc.session, fx.Session(tc.t))
+			require.True(t, always.Open(tc.t))
+		})
+	}
+}
+
+func TestCalendar_NextOpen(t *testing.T) {
+	fx, err := Builtin(FX, []string{"2022-08-08"})
+	require.Nil(t, err)
+
+	open := friday.Add(12 * time.Hour)
+	require.Equal(t, open, fx.NextOpen(open))
+	// Monday is a holiday, market opens on Monday at 22:00
+	require.Equal(t, friday.Add(3*day+22*time.Hour), fx.NextOpen(friday.Add(22*time.Hour)))
+}
+
+func TestCalendar_OpenDuration(t *testing.T) {
+	fx, err := Builtin(FX, nil)
+	require.Nil(t, err)
+
+	// the weekend isn't counted
+	require.Equal(t, 2*time.Hour, fx.OpenDuration(friday.Add(21*time.Hour), friday.Add(2*day+23*time.Hour)))
+	require.Equal(t, 5*day, fx.OpenDuration(friday.Add(-5*day), friday.Add(2*day)))
+	require.Equal(t, time.Duration(0), fx.OpenDuration(friday.Add(day), friday.Add(2*day)))
+
+	require.Equal(t, 1, fx.TradingDays(friday.Add(21*time.Hour), friday.Add(2*day+23*time.Hour)))
+	require.Equal(t, 5, fx.TradingDays(friday, friday.Add(7*day)))
+}
@@ -0,0 +1,82 @@
+package decimal
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxScale is the greatest number of digits after decimal point
+const MaxScale = 18
+
+var (
+	ErrSyntax = errors.New("invalid decimal")
+	ErrRange  = errors.New("decimal is out of range")
+	ErrScale  = errors.New("scale must be between 0 and 18")
+)
+
+// Format renders fixed-point value * 10^-scale with exactly scale digits after decimal point:
+// Format(100005, 5) is "1.00005".
+func Format(value int64, scale int32) string {
+	if scale <= 0 {
+		return strconv.FormatInt(value, 10)
+	}
+
+	neg := value < 0
+	digits := strconv.FormatInt(value, 10)
+	if neg {
+		digits = digits[1:]
+	}
+	if pad := int(scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	b := strings.Builder{}
+	b.Grow(len(digits) + 2)
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:len(digits)-int(scale)])
+	b.WriteByte('.')
+	b.WriteString(digits[len(digits)-int(scale):])
+	return b.String()
+}
+
+// Parse returns fixed-point value and scale of decimal string: Parse("1.00005") is 100005, 5.
+func Parse(s string) (int64, int32, error) {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if len(fracPart) > MaxScale {
+		return 0, 0, ErrScale
+	}
+
+	digits := intPart + fracPart
+	if intPart == "" || intPart == "-" || intPart == "+" || strings.ContainsAny(digits[1:], "+-") {
+		return 0, 0, ErrSyntax
+	}
+
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, 0, ErrRange
+		}
+		return 0, 0, ErrSyntax
+	}
+
+	return value, int32(len(fracPart)), nil
+}
+
+// Rescale converts fixed-point value from scale from to scale to. Scale can only grow, so no digits are lost.
+func Rescale(value int64, from, to int32) (int64, error) {
+	if from < 0 || to > MaxScale || to < from {
+		return 0, ErrScale
+	}
+
+	for ; from < to; from++ {
+		if value > math.MaxInt64/10 || value < math.MinInt64/10 {
+			return 0, ErrRange
+		}
+		value *= 10
+	}
+	return value, nil
+}
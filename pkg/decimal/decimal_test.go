@@ -0,0 +1,87 @@
+package decimal
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		value int64
+		scale int32
+		er    string
+	}{
+		{100005, 5, "1.00005"},
+		{110000, 5, "1.10000"},
+		{13512, 3, "13.512"},
+		{5, 5, "0.00005"},
+		{-5, 3, "-0.005"},
+		{-13512, 3, "-13.512"},
+		{0, 2, "0.00"},
+		{6000, 0, "6000"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.er, func(t *testing.T) {
+			require.Equal(t, tc.er, Format(tc.value, tc.scale))
+
+			value, scale, err := Parse(tc.er)
+			require.Nil(t, err)
+			require.Equal(t, tc.value, value)
+			require.Equal(t, tc.scale, scale)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"", ErrSyntax},
+		{".5", ErrSyntax},
+		{"-", ErrSyntax},
+		{"1.-5", ErrSyntax},
+		{"1.5e3", ErrSyntax},
+		{"abc", ErrSyntax},
+		{"1.0000000000000000000", ErrScale},
+		{"92233720368547758.08", ErrRange},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s, func(t *testing.T) {
+			_, _, err := Parse(tc.s)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestRescale(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int64
+		from  int32
+		to    int32
+		er    int64
+		err   error
+	}{
+		{name: "same scale", value: 13512, from: 3, to: 3, er: 13512},
+		{name: "grow scale", value: 13512, from: 3, to: 5, er: 1351200},
+		{name: "negative", value: -5, from: 0, to: 2, er: -500},
+		{name: "shrink scale", value: 13512, from: 3, to: 2, err: ErrScale},
+		{name: "too big scale", value: 1, from: 0, to: 19, err: ErrScale},
+		{name: "overflow", value: 1 << 62, from: 0, to: 1, err: ErrRange},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := Rescale(tc.value, tc.from, tc.to)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tc.er, value)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package instrument
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/mazitovt/logger"
+	"mtsbank/pkg/calendar"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// alwaysOpen is a calendar of instruments, which calendars are unknown
+var alwaysOpen, _ = calendar.Builtin(calendar.AlwaysOpen, nil)
+
+// Instrument is reference data of currency pair in generator instrument registry, which services use
+type Instrument struct {
+	CurrencyPair string
+	// Calendar is a name of trading calendar
+	Calendar string
+	// Enabled currency pair can be generated
+	Enabled bool
+}
+
+// Calendar is a trading calendar of generator, see calendar.Parse
+type Calendar struct {
+	Name     string
+	Days     []string
+	Rollover string
+	Holidays []string
+}
+
+// Generator loads instrument registry and trading calendars of generator
+type Generator interface {
+	Instruments(ctx context.Context) ([]Instrument, error)
+	Calendars(ctx context.Context) ([]Calendar, error)
+}
+
+// Error is a body of error response, it's the same as generator one
+type Error struct {
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+}
+
+// Registry is a copy of generator instrument registry and trading calendars
+type Registry struct {
+	generator Generator
+	logger    logger.Logger
+
+	// mu guards instruments and calendars
+	mu sync.RWMutex
+	// instruments are nil until the first load
+	instruments map[string]Instrument
+	calendars   map[string]*calendar.Calendar
+}
+
+func NewRegistry(generator Generator, logger logger.Logger) *Registry {
+	return &Registry{generator: generator, logger: logger}
+}
+
+// Load replaces instruments and calendars with ones of generator
+func (r *Registry) Load(ctx context.Context) error {
+	instruments, err := r.generator.Instruments(ctx)
+	if err != nil {
+		return err
+	}
+	calendars, err := r.generator.Calendars(ctx)
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]Instrument, len(instruments))
+	for _, in := range instruments {
+		m[in.CurrencyPair] = in
+	}
+
+	cals := make(map[string]*calendar.Calendar, len(calendars))
+	for _, c := range calendars {
+		if cals[c.Name], err = calendar.Parse(c.Name, c.Days, c.Rollover, c.Holidays); err != nil {
+			return fmt.Errorf("calendar %s: %w", c.Name, err)
+		}
+	}
+
+	r.mu.Lock()
+	r.instruments = m
+	r.calendars = cals
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Wait loads instruments every period until the first success or ctx is done
+func (r *Registry) Wait(ctx context.Context, period time.Duration) error {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		err := r.Load(ctx)
+		if err == nil {
+			return nil
+		}
+		r.logger.Warn("instruments aren't loaded: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Get returns instrument of currency pair
+func (r *Registry) Get(currencyPair string) (Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	in, ok := r.instruments[currencyPair]
+	return in, ok
+}
+
+// Calendar returns trading calendar of currency pair, currency pair with unknown calendar trades all the time
+func (r *Registry) Calendar(currencyPair string) *calendar.Calendar {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if c, ok := r.calendars[r.instruments[currencyPair].Calendar]; ok {
+		return c
+	}
+	return alwaysOpen
+}
+
+// Enabled returns enabled currency pairs in alphabetical order
+func (r *Registry) Enabled() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pairs := make([]string, 0, len(r.instruments))
+	for p, in := range r.instruments {
+		if in.Enabled {
+			pairs = append(pairs, p)
+		}
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// Validator responds 404, if path parameter currency_pair isn't in registry
+func (r *Registry) Validator() func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			pair := chi.URLParam(req, "currency_pair")
+			if _, ok := r.Get(pair); pair != "" && !ok {
+				r.writeUnknown(w, pair)
+				return
+			}
+			next(w, req)
+		}
+	}
+}
+
+// writeUnknown writes the same 404 response as generator does for unknown currency pair
+func (r *Registry) writeUnknown(w http.ResponseWriter, currencyPair string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+
+	err := json.NewEncoder(w).Encode(Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("unknown currency pair '%s'", currencyPair),
+	})
+	if err != nil {
+		r.logger.Error("Registry.writeUnknown: err: %v", err)
+	}
+}
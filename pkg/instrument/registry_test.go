@@ -0,0 +1,93 @@
+package instrument
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mazitovt/logger"
+	"github.com/stretchr/testify/require"
+	"mtsbank/pkg/calendar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeGenerator struct {
+	instruments []Instrument
+	calendars   []Calendar
+	err         error
+}
+
+func (f *fakeGenerator) Instruments(context.Context) ([]Instrument, error) {
+	return f.instruments, f.err
+}
+
+func (f *fakeGenerator) Calendars(context.Context) ([]Calendar, error) {
+	return f.calendars, f.err
+}
+
+func TestRegistry(t *testing.T) {
+	g := &fakeGenerator{err: errors.New("generator is down")}
+	r := NewRegistry(g, logger.New(logger.Error))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, r.Wait(ctx, 10*time.Millisecond), context.DeadlineExceeded)
+	require.Empty(t, r.Enabled())
+
+	g.err = nil
+	g.instruments = []Instrument{
+		{CurrencyPair: "USDJPY", Enabled: true},
+		{CurrencyPair: "USDRUB"},
+		{CurrencyPair: "EURUSD", Enabled: true},
+	}
+	require.Nil(t, r.Wait(context.Background(), 10*time.Millisecond))
+	require.Equal(t, []string{"EURUSD", "USDJPY"}, r.Enabled())
+
+	_, ok := r.Get("USDRUB")
+	require.True(t, ok)
+	_, ok = r.Get("GBPUSD")
+	require.False(t, ok)
+}
+
+func TestRegistry_Calendar(t *testing.T) {
+	g := &fakeGenerator{
+		instruments: []Instrument{{CurrencyPair: "EURUSD", Calendar: "FX"}, {CurrencyPair: "USDJPY", Calendar: "24x7"}},
+		calendars:   []Calendar{{Name: "FX", Days: []string{"MON", "TUE", "WED", "THU", "FRI"}, Rollover: "-2h0m0s", Holidays: []string{"2022-12-26"}}},
+	}
+	r := NewRegistry(g, logger.New(logger.Error))
+	require.Nil(t, r.Load(context.Background()))
+
+	require.Equal(t, "FX", r.Calendar("EURUSD").Name())
+	require.Equal(t, []string{"2022-12-26"}, r.Calendar("EURUSD").Holidays())
+	// unknown calendar and currency pair trade all the time
+	require.Equal(t, calendar.AlwaysOpen, r.Calendar("USDJPY").Name())
+	require.Equal(t, calendar.AlwaysOpen, r.Calendar("GBPUSD").Name())
+
+	g.calendars[0].Rollover = "22:00"
+	require.ErrorIs(t, r.Load(context.Background()), calendar.ErrRollover)
+}
+
+func TestRegistry_Validator(t *testing.T) {
+	r := NewRegistry(&fakeGenerator{instruments: []Instrument{{CurrencyPair: "EURUSD", Enabled: true}}}, logger.New(logger.Error))
+	require.Nil(t, r.Load(context.Background()))
+
+	router := chi.NewRouter()
+	router.Get("/rates/{currency_pair}", r.Validator()(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/rates/EURUSD", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/rates/GBPUSD", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	var e Error
+	require.Nil(t, json.NewDecoder(w.Body).Decode(&e))
+	require.Equal(t, Error{Code: http.StatusNotFound, Message: "unknown currency pair 'GBPUSD'"}, e)
+}